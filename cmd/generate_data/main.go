@@ -58,16 +58,25 @@ func worker(input chan common.User, logger *zap.Logger) {
 		}
 
 		user := (*response)["user"].(map[string]any)
+		batch := common.TaskBatch{Mode: common.BatchModeAtomic}
 		for i := range 20 {
-			task := common.Task{
-				UserId:      user["id"].(string),
-				Description: fmt.Sprintf("Task %d", i+1),
-				State:       common.TaskState("to_do"),
-			}
+			batch.Operations = append(batch.Operations, common.BatchOperation{
+				Op: common.BatchOpCreate,
+				Task: &common.Task{
+					UserId:      user["id"].(string),
+					Description: fmt.Sprintf("Task %d", i+1),
+					State:       common.TaskStateToDo,
+				},
+			})
+		}
 
-			_, err := doRequest[map[string]any](http.MethodPost, "http://localhost:8080/tasks", bearerToken, task, logger)
-			if err != nil {
-				logger.Fatal("Error requesting", zap.Error(err))
+		results, err := doRequest[[]map[string]any](http.MethodPost, "http://localhost:8080/tasks:batch", bearerToken, batch, logger)
+		if err != nil {
+			logger.Fatal("Error requesting", zap.Error(err))
+		}
+		for _, result := range *results {
+			if result["status"] != float64(http.StatusCreated) {
+				logger.Fatal("Error adding tasks", zap.Any("result", result))
 			}
 		}
 
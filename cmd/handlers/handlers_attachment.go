@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// attachmentFormName is the name of the multipart form field carrying the uploaded file.
+const attachmentFormName = "file"
+
+// AddAttachment uploads the file sent in the "file" field of a multipart request to a task.
+// The file is streamed to the service without being buffered in memory.
+func (handler *Handler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		handler.Logger.Error("Unable to read multipart request.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			handler.Logger.Error("No file uploaded.")
+			writeResponse(w, http.StatusBadRequest, "missing "+attachmentFormName+" field")
+			return
+		}
+		if err != nil {
+			handler.Logger.Error("Unable to read multipart request.", zap.Error(err))
+			writeResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if part.FormName() != attachmentFormName {
+			continue
+		}
+
+		request := &common.Attachment{
+			TaskId: r.Context().Value(idCtx).(string),
+			Name:   part.FileName(),
+		}
+
+		attachment, err := handler.svc.AddAttachment(request, part)
+		if err != nil {
+			handler.Logger.Error("Unable add attachment.", zap.Error(err))
+			writeResponse(w, errorStatus(err), err.Error())
+			return
+		}
+
+		writeResponse(w, http.StatusCreated, attachment)
+		return
+	}
+}
+
+// DownloadAttachment redirects to a presigned URL of the attachment when the blob store
+// supports them and streams its content otherwise.
+func (handler *Handler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	download, err := handler.svc.DownloadAttachment(taskId, id)
+	if err != nil {
+		handler.Logger.Error("Unable to download attachment.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	if download.URL != "" {
+		http.Redirect(w, r, download.URL, http.StatusFound)
+		return
+	}
+	defer download.Content.Close()
+
+	attachment := download.Attachment
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, download.Content); err != nil {
+		handler.Logger.Error("Unable to send attachment.", zap.Error(err))
+	}
+}
+
+func (handler *Handler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	if err := handler.svc.DeleteAttachment(taskId, id); err != nil {
+		handler.Logger.Error("Unable to delete attachment.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Attachment Deleted",
+	})
+}
+
+func (handler *Handler) ListTaskAttachments(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+
+	attachments, err := handler.svc.ListTaskAttachments(taskId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve task attachments.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, attachments)
+}
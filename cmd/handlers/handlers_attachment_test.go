@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+	"github.com/golang/mock/gomock"
+)
+
+func (hdl *handlerTestSuite) TestAddAttachment() {
+	idTask := "00001"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddAttachment)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	newBody := func(field string) (io.Reader, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("description", "shopping list")
+		part, _ := writer.CreateFormFile(field, "notes.txt")
+		part.Write([]byte("buy milk"))
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	tests := map[string]struct {
+		field          string
+		contentType    string
+		callSvc        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			field:          "file",
+			callSvc:        true,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"0001","task_id":"00001","name":"notes.txt","content_type":"text/plain; charset=utf-8","size":8,"checksum":"933260194ce59178528d37861b7a69a5a7c221c81e8d7035474fd56acf895525","created_at":"2024-05-01T10:00:00Z"}`,
+		},
+		"too large": {
+			field:          "file",
+			callSvc:        true,
+			svcError:       fmt.Errorf("%w: the limit is 4 bytes", service.ErrAttachmentTooLarge),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedResp:   `"attachment too large: the limit is 4 bytes"`,
+		},
+		"task not found": {
+			field:          "file",
+			callSvc:        true,
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+		"missing file": {
+			field:          "document",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"missing file field"`,
+		},
+		"not multipart": {
+			contentType:    "application/json",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"request Content-Type isn't multipart/form-data"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			body, contentType := newBody(test.field)
+			if test.contentType != "" {
+				contentType = test.contentType
+			}
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/attachments", body).WithContext(ctx)
+			req.Header.Set("Content-Type", contentType)
+
+			// set up service mock
+			if test.callSvc {
+				hdl.getService().
+					AddAttachment(&common.Attachment{TaskId: idTask, Name: "notes.txt"}, gomock.Any()).
+					DoAndReturn(func(attachment *common.Attachment, content io.Reader) (*common.Attachment, error) {
+						data, err := io.ReadAll(content)
+						hdl.Assert().NoError(err)
+						hdl.Assert().Equal("buy milk", string(data))
+
+						return &common.Attachment{
+							Id:          "0001",
+							TaskId:      idTask,
+							Name:        "notes.txt",
+							ContentType: "text/plain; charset=utf-8",
+							Size:        8,
+							Checksum:    "933260194ce59178528d37861b7a69a5a7c221c81e8d7035474fd56acf895525",
+							CreatedAt:   createdAt,
+						}, test.svcError
+					})
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDownloadAttachment() {
+	idTask := "00001"
+	idAttachment := "0001"
+	attachment := &common.Attachment{
+		Id:          idAttachment,
+		TaskId:      idTask,
+		Name:        "notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Size:        8,
+	}
+	presignedURL := "https://bucket.s3.amazonaws.com/tasks/00001/0001?X-Amz-Signature=abc"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DownloadAttachment)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idAttachment)
+
+	tests := map[string]struct {
+		url             string
+		svcError        error
+		expectedStatus  int
+		expectedHeaders map[string]string
+		expectedResp    string
+	}{
+		"content": {
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":        "text/plain; charset=utf-8",
+				"Content-Length":      "8",
+				"Content-Disposition": `attachment; filename=notes.txt`,
+			},
+			expectedResp: "buy milk",
+		},
+		"presigned url": {
+			url:            presignedURL,
+			expectedStatus: http.StatusFound,
+			expectedHeaders: map[string]string{
+				"Location": presignedURL,
+			},
+		},
+		"not found": {
+			svcError:       fmt.Errorf("attachment %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"attachment not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/tasks/"+idTask+"/attachments/"+idAttachment, nil).WithContext(ctx)
+
+			// set up service mock
+			download := &common.AttachmentDownload{Attachment: attachment, URL: test.url}
+			if test.url == "" {
+				download.Content = io.NopCloser(strings.NewReader("buy milk"))
+			}
+			hdl.getService().
+				DownloadAttachment(idTask, idAttachment).
+				Return(download, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			for header, value := range test.expectedHeaders {
+				hdl.Assert().Equal(value, rr.Header().Get(header), header)
+			}
+			if test.expectedResp != "" {
+				hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+			}
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteAttachment() {
+	idTask := "00001"
+	idAttachment := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteAttachment)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idAttachment)
+
+	errDeleteAttachment := errors.New("error deleting attachment")
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Attachment Deleted"}`,
+		},
+		"fail": {
+			svcError:       errDeleteAttachment,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error deleting attachment"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/tasks/"+idTask+"/attachments/"+idAttachment, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				DeleteAttachment(idTask, idAttachment).
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListTaskAttachments() {
+	idTask := "00001"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	attachments := []common.Attachment{
+		{Id: "0001", TaskId: idTask, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 8, Checksum: "abc", CreatedAt: createdAt},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListTaskAttachments)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errListAttachments := errors.New("error retrieving attachments")
+	tests := map[string]struct {
+		svcAttachments []common.Attachment
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcAttachments: attachments,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"id":"0001","task_id":"00001","name":"notes.txt","content_type":"text/plain; charset=utf-8","size":8,"checksum":"abc","created_at":"2024-05-01T10:00:00Z"}]`,
+		},
+		"fail": {
+			svcError:       errListAttachments,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving attachments"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/tasks/"+idTask+"/attachments", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				ListTaskAttachments(idTask).
+				Return(test.svcAttachments, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// BatchTasks runs a batch of task operations in a single transaction. The response holds the
+// result of each operation, in order; in an atomic batch where an operation failed, the others
+// result in a 424 status.
+func (handler *Handler) BatchTasks(w http.ResponseWriter, r *http.Request) {
+	request := common.TaskBatch{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := handler.svc.BatchTasks(request, authenticatedUserId(r))
+	if err != nil {
+		handler.Logger.Error("Unable to run task batch.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	response := make([]batchResult, len(results))
+	for i, result := range results {
+		switch {
+		case result.Err != nil:
+			response[i] = batchResult{Status: errorStatus(result.Err), Error: result.Err.Error()}
+		case request.Operations[i].Op == common.BatchOpCreate:
+			response[i] = batchResult{Status: http.StatusCreated, Task: result.Task}
+		default:
+			response[i] = batchResult{Status: http.StatusOK, Task: result.Task}
+		}
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+	"github.com/golang/mock/gomock"
+)
+
+func (hdl *handlerTestSuite) TestBatchTasks() {
+	task := &common.Task{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do"}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.BatchTasks)
+
+	body := `{"mode":"best_effort","operations":[{"op":"create","task":{"user_id":"00001","description":"description 1","state":"to_do"}},{"op":"update","id":"0002","task":{"state":"done"}},{"op":"delete","id":"0003"}]}`
+	tests := map[string]struct {
+		body           string
+		svcResults     []common.BatchResult
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			body:           body,
+			svcResults:     []common.BatchResult{{Task: task}, {Err: fmt.Errorf("task %w", service.ErrNotFound)}, {}},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"status":201,"task":{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}},{"status":404,"error":"task not found"},{"status":200}]`,
+		},
+		"aborted": {
+			body:           body,
+			svcResults:     []common.BatchResult{{Err: service.ErrBatchAborted}, {Err: fmt.Errorf("task %w", service.ErrNotFound)}, {Err: service.ErrBatchAborted}},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"status":424,"error":"batch aborted"},{"status":404,"error":"task not found"},{"status":424,"error":"batch aborted"}]`,
+		},
+		"invalid batch": {
+			body:           `{"operations":[]}`,
+			svcError:       fmt.Errorf("%w: no operations", service.ErrInvalidBatch),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid batch: no operations"`,
+		},
+		"invalid body": {
+			body:           `{"operations":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks:batch", strings.NewReader(test.body))
+
+			// set up service mock
+			if test.svcResults != nil || test.svcError != nil {
+				hdl.getService().
+					BatchTasks(gomock.Any(), "").
+					Return(test.svcResults, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
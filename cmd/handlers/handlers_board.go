@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// GetBoard returns the tasks of a user as a kanban board, filtered like the user's task list.
+func (handler *Handler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	filter, err := taskFilterFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid task filter.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	board, err := handler.svc.Board(id, filter)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve board.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, board)
+}
+
+// SetWIPLimits replaces the work-in-progress limits of the columns of a user's board.
+func (handler *Handler) SetWIPLimits(w http.ResponseWriter, r *http.Request) {
+	request := common.WIPLimits{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.Context().Value(idCtx).(string)
+
+	limits, err := handler.svc.SetWIPLimits(id, request)
+	if err != nil {
+		handler.Logger.Error("Unable to set wip limits.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, limits)
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestGetBoard() {
+	idUser := "00001"
+	limit := 2
+	board := &common.Board{UserId: idUser, Columns: []common.BoardColumn{
+		{State: common.TaskStateToDo, Count: 1, Tasks: []common.Task{{Id: "0001", UserId: idUser, Description: "description 1", State: "to_do"}}},
+		{State: common.TaskStateInProgress, Limit: &limit, Tasks: []common.Task{}},
+	}}
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.GetBoard)
+
+	errGetBoard := errors.New("error retrieving board")
+	tests := map[string]struct {
+		query          string
+		filter         common.TaskFilter
+		svcBoard       *common.Board
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcBoard:       board,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"user_id":"00001","columns":[{"state":"to_do","count":1,"tasks":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}]},{"state":"in_progress","count":0,"limit":2,"tasks":[]}]}`,
+		},
+		"labels": {
+			query:          "?label=home",
+			filter:         common.TaskFilter{Labels: []string{"home"}},
+			svcBoard:       board,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"user_id":"00001","columns":[{"state":"to_do","count":1,"tasks":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}]},{"state":"in_progress","count":0,"limit":2,"tasks":[]}]}`,
+		},
+		"invalid ready": {
+			query:          "?ready=soon",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid ready parameter: \"soon\""`,
+		},
+		"user not found": {
+			svcError:       fmt.Errorf("user %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"user not found"`,
+		},
+		"fail": {
+			svcError:       errGetBoard,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving board"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", fmt.Sprintf("/users/%s/board%s", idUser, test.query), nil).WithContext(ctx)
+
+			// set up service mock
+			if test.expectedStatus != http.StatusBadRequest {
+				hdl.getService().
+					Board(idUser, test.filter).
+					Return(test.svcBoard, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestSetWIPLimits() {
+	idUser := "00001"
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.SetWIPLimits)
+
+	tests := map[string]struct {
+		body           string
+		limits         common.WIPLimits
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			body:           `{"in_progress":3,"blocked":1}`,
+			limits:         common.WIPLimits{common.TaskStateInProgress: 3, common.TaskStateBlocked: 1},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"blocked":1,"in_progress":3}`,
+		},
+		"invalid limit": {
+			body:           `{"in_progress":0}`,
+			limits:         common.WIPLimits{common.TaskStateInProgress: 0},
+			svcError:       fmt.Errorf("%w: the limit of in_progress must be positive", service.ErrInvalidWIPLimit),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid wip limit: the limit of in_progress must be positive"`,
+		},
+		"invalid body": {
+			body:           `{"in_progress":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/users/%s/board/limits", idUser), strings.NewReader(test.body)).WithContext(ctx)
+
+			// set up service mock
+			if test.limits != nil {
+				hdl.getService().
+					SetWIPLimits(idUser, test.limits).
+					Return(test.limits, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// AddChecklistItem adds an item at the end of the checklist of a task.
+func (handler *Handler) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	request := &common.ChecklistItem{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.TaskId = r.Context().Value(idCtx).(string)
+
+	item, err := handler.svc.AddChecklistItem(request)
+	if err != nil {
+		handler.Logger.Error("Unable to add checklist item.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, item)
+}
+
+// ToggleChecklistItem checks or unchecks an item of the checklist of a task.
+func (handler *Handler) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	item, err := handler.svc.ToggleChecklistItem(taskId, id)
+	if err != nil {
+		handler.Logger.Error("Unable to toggle checklist item.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, item)
+}
+
+// MoveChecklistItem places an item of the checklist of a task right before or right after another item.
+func (handler *Handler) MoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	request := common.Move{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	item, err := handler.svc.MoveChecklistItem(taskId, id, request)
+	if err != nil {
+		handler.Logger.Error("Unable to move checklist item.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, item)
+}
+
+func (handler *Handler) DeleteChecklistItem(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	if err := handler.svc.DeleteChecklistItem(taskId, id); err != nil {
+		handler.Logger.Error("Unable to delete checklist item.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Checklist Item Deleted",
+	})
+}
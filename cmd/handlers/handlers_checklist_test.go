@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestAddChecklistItem() {
+	idTask := "00001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddChecklistItem)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errAddItem := errors.New("error inserting checklist item")
+	tests := map[string]struct {
+		body           string
+		svcItem        *common.ChecklistItem
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			body:           `{"text":"toothbrush"}`,
+			svcItem:        &common.ChecklistItem{TaskId: idTask, Text: "toothbrush"},
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"0001","task_id":"00001","text":"toothbrush","checked":false,"position":"i"}`,
+		},
+		"empty text": {
+			body:           `{"text":""}`,
+			svcItem:        &common.ChecklistItem{TaskId: idTask},
+			svcError:       fmt.Errorf("%w: text is required", service.ErrInvalidChecklist),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid checklist item: text is required"`,
+		},
+		"invalid body": {
+			body:           `{"text":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+		"fail": {
+			body:           `{"text":"toothbrush"}`,
+			svcItem:        &common.ChecklistItem{TaskId: idTask, Text: "toothbrush"},
+			svcError:       errAddItem,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error inserting checklist item"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/checklist", strings.NewReader(test.body)).WithContext(ctx)
+
+			// set up service mock
+			if test.svcItem != nil {
+				hdl.getService().
+					AddChecklistItem(test.svcItem).
+					Return(&common.ChecklistItem{Id: "0001", TaskId: idTask, Text: "toothbrush", Position: "i"}, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestToggleChecklistItem() {
+	idTask := "00001"
+	idItem := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ToggleChecklistItem)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idItem)
+
+	tests := map[string]struct {
+		svcItem        *common.ChecklistItem
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcItem:        &common.ChecklistItem{Id: idItem, TaskId: idTask, Text: "toothbrush", Checked: true, Position: "i"},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","task_id":"00001","text":"toothbrush","checked":true,"position":"i"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("checklist item %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"checklist item not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/checklist/"+idItem+"/toggle", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				ToggleChecklistItem(idTask, idItem).
+				Return(test.svcItem, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestMoveChecklistItem() {
+	idTask := "00001"
+	idItem := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.MoveChecklistItem)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idItem)
+
+	tests := map[string]struct {
+		body           string
+		svcMove        *common.Move
+		svcItem        *common.ChecklistItem
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			body:           `{"after":"0002"}`,
+			svcMove:        &common.Move{After: "0002"},
+			svcItem:        &common.ChecklistItem{Id: idItem, TaskId: idTask, Text: "toothbrush", Position: "r"},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","task_id":"00001","text":"toothbrush","checked":false,"position":"r"}`,
+		},
+		"invalid move": {
+			body:           `{"after":"0003"}`,
+			svcMove:        &common.Move{After: "0003"},
+			svcError:       fmt.Errorf("%w: item 0003 is not an item of the same checklist", service.ErrInvalidMove),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid move: item 0003 is not an item of the same checklist"`,
+		},
+		"invalid body": {
+			body:           `{"after":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/checklist/"+idItem+"/move", strings.NewReader(test.body)).WithContext(ctx)
+
+			// set up service mock
+			if test.svcMove != nil {
+				hdl.getService().
+					MoveChecklistItem(idTask, idItem, *test.svcMove).
+					Return(test.svcItem, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteChecklistItem() {
+	idTask := "00001"
+	idItem := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteChecklistItem)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idItem)
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Checklist Item Deleted"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("checklist item %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"checklist item not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/tasks/"+idTask+"/checklist/"+idItem, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				DeleteChecklistItem(idTask, idItem).
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
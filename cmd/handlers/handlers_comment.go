@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// AddComment adds a comment to a task on behalf of the user of the access token.
+func (handler *Handler) AddComment(w http.ResponseWriter, r *http.Request) {
+	request := &common.Comment{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.TaskId = r.Context().Value(idCtx).(string)
+	request.AuthorId = authenticatedUserId(r)
+	if request.AuthorId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	comment, err := handler.svc.AddComment(request)
+	if err != nil {
+		handler.Logger.Error("Unable add comment.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, comment)
+}
+
+func (handler *Handler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	request := &common.Comment{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.TaskId = r.Context().Value(idCtx).(string)
+	request.Id = r.Context().Value(subIdCtx).(string)
+	request.AuthorId = authenticatedUserId(r)
+	if request.AuthorId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	comment, err := handler.svc.UpdateComment(request)
+	if err != nil {
+		handler.Logger.Error("Unable update comment.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, comment)
+}
+
+func (handler *Handler) GetComment(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	comment, err := handler.svc.GetComment(taskId, id)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve comment.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, comment)
+}
+
+func (handler *Handler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+	authorId := authenticatedUserId(r)
+	if authorId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	if err := handler.svc.DeleteComment(taskId, id, authorId); err != nil {
+		handler.Logger.Error("Unable to delete comment.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Comment Deleted",
+	})
+}
+
+func (handler *Handler) ListTaskComments(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+
+	comments, err := handler.svc.ListTaskComments(taskId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve task comments.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, comments)
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestAddComment() {
+	idTask := "00001"
+	idAuthor := "000001"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	comment := &common.Comment{
+		TaskId:   idTask,
+		AuthorId: idAuthor,
+		Body:     "on it",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddComment)
+
+	errAddComment := errors.New("error inserting comment")
+	tests := map[string]struct {
+		claims         *common.Claims
+		callSvc        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			claims:         &common.Claims{UserID: idAuthor},
+			callSvc:        true,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"0001","task_id":"00001","author_id":"000001","body":"on it","created_at":"2024-05-01T10:00:00Z","updated_at":"2024-05-01T10:00:00Z"}`,
+		},
+		"invalid": {
+			claims:         &common.Claims{UserID: idAuthor},
+			callSvc:        true,
+			svcError:       fmt.Errorf("%w: replies cannot be replied to", service.ErrInvalidComment),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid comment: replies cannot be replied to"`,
+		},
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+		"fail": {
+			claims:         &common.Claims{UserID: idAuthor},
+			callSvc:        true,
+			svcError:       errAddComment,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error inserting comment"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			ctx := context.WithValue(context.Background(), idCtx, idTask)
+			if test.claims != nil {
+				ctx = context.WithValue(ctx, claimsCtx, test.claims)
+			}
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/comments", strings.NewReader(`{"body":"on it","author_id":"000009"}`)).WithContext(ctx)
+
+			// set up service mock
+			if test.callSvc {
+				hdl.getService().
+					AddComment(comment).
+					Return(&common.Comment{Id: "0001", TaskId: idTask, AuthorId: idAuthor, Body: "on it", CreatedAt: createdAt, UpdatedAt: createdAt}, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestUpdateComment() {
+	idTask := "00001"
+	idComment := "0001"
+	updatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	comment := &common.Comment{
+		Id:       idComment,
+		TaskId:   idTask,
+		AuthorId: "000002",
+		Body:     "done",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.UpdateComment)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idComment)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: "000002"})
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","task_id":"00001","author_id":"000002","body":"done","created_at":"2024-05-01T10:00:00Z","updated_at":"2024-05-01T10:00:00Z"}`,
+		},
+		"not the author": {
+			svcError:       fmt.Errorf("%w: only the author can edit a comment", service.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedResp:   `"forbidden: only the author can edit a comment"`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("comment %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"comment not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("PUT", "/tasks/"+idTask+"/comments/"+idComment, strings.NewReader(`{"body":"done"}`)).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				UpdateComment(comment).
+				Return(&common.Comment{Id: idComment, TaskId: idTask, AuthorId: "000002", Body: "done", CreatedAt: updatedAt, UpdatedAt: updatedAt}, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteComment() {
+	idTask := "00001"
+	idComment := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteComment)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+	ctx = context.WithValue(ctx, subIdCtx, idComment)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: "000001"})
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Comment Deleted"}`,
+		},
+		"not the author": {
+			svcError:       fmt.Errorf("%w: only the author can delete a comment", service.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedResp:   `"forbidden: only the author can delete a comment"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/tasks/"+idTask+"/comments/"+idComment, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				DeleteComment(idTask, idComment, "000001").
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListTaskComments() {
+	idTask := "00001"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	replyTo := "0001"
+	comments := []common.Comment{
+		{Id: "0001", TaskId: idTask, AuthorId: "000001", Body: "who takes it?", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{Id: "0002", TaskId: idTask, AuthorId: "000002", Body: "me", ReplyTo: &replyTo, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListTaskComments)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errListComments := errors.New("error retrieving comments")
+	tests := map[string]struct {
+		svcComments    []common.Comment
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcComments:    comments,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"id":"0001","task_id":"00001","author_id":"000001","body":"who takes it?","created_at":"2024-05-01T10:00:00Z","updated_at":"2024-05-01T10:00:00Z"},{"id":"0002","task_id":"00001","author_id":"000002","body":"me","reply_to":"0001","created_at":"2024-05-01T10:00:00Z","updated_at":"2024-05-01T10:00:00Z"}]`,
+		},
+		"fail": {
+			svcError:       errListComments,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving comments"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/tasks/"+idTask+"/comments", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				ListTaskComments(idTask).
+				Return(test.svcComments, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
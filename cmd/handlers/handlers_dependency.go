@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+func (handler *Handler) AddTaskDependency(w http.ResponseWriter, r *http.Request) {
+	request := &common.TaskDependency{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.TaskId = r.Context().Value(idCtx).(string)
+
+	if err := handler.svc.AddTaskDependency(request); err != nil {
+		handler.Logger.Error("Unable add task dependency.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, request)
+}
+
+func (handler *Handler) DeleteTaskDependency(w http.ResponseWriter, r *http.Request) {
+	request := &common.TaskDependency{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.TaskId = r.Context().Value(idCtx).(string)
+
+	if err := handler.svc.DeleteTaskDependency(request); err != nil {
+		handler.Logger.Error("Unable to delete task dependency.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Task Dependency Deleted",
+	})
+}
+
+func (handler *Handler) ListTaskDependencies(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	tasks, err := handler.svc.ListTaskDependencies(id)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve task dependencies.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, tasks)
+}
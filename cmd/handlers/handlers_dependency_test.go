@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestAddTaskDependency() {
+	idTask := "0001"
+	dependency := &common.TaskDependency{
+		TaskId:      idTask,
+		DependsOnId: "0002",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddTaskDependency)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errAddDependency := errors.New("error inserting task dependency")
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"task_id":"0001","depends_on_id":"0002"}`,
+		},
+		"cycle": {
+			svcError:       fmt.Errorf("%w: task 0002 already depends on task 0001", service.ErrInvalidDependency),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid task dependency: task 0002 already depends on task 0001"`,
+		},
+		"fail": {
+			svcError:       errAddDependency,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error inserting task dependency"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/dependencies", strings.NewReader(`{"depends_on_id":"0002"}`)).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				AddTaskDependency(dependency).
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteTaskDependency() {
+	idTask := "0001"
+	dependency := &common.TaskDependency{
+		TaskId:      idTask,
+		DependsOnId: "0002",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteTaskDependency)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errDeleteDependency := errors.New("error deleting task dependency")
+	tests := map[string]struct {
+		svcError     error
+		expectedResp string
+	}{
+		"success": {
+			svcError:     nil,
+			expectedResp: `{"message":"Task Dependency Deleted"}`,
+		},
+		"fail": {
+			svcError:     errDeleteDependency,
+			expectedResp: `"error deleting task dependency"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/tasks/"+idTask+"/dependencies", strings.NewReader(`{"depends_on_id":"0002"}`)).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				DeleteTaskDependency(dependency).
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			if test.svcError == nil {
+				hdl.Assert().Equal(http.StatusOK, rr.Code)
+			} else {
+				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
+			}
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListTaskDependencies() {
+	idTask := "0001"
+	tasks := []common.Task{
+		{
+			Id:          "0002",
+			UserId:      "00001",
+			Description: "description 2",
+			State:       "to_do",
+		},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListTaskDependencies)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errListDependencies := errors.New("error retrieving task dependencies")
+	tests := map[string]struct {
+		svcTasks     []common.Task
+		svcError     error
+		expectedResp string
+	}{
+		"success": {
+			svcTasks:     tasks,
+			svcError:     nil,
+			expectedResp: `[{"id":"0002","user_id":"00001","description":"description 2","state":"to_do"}]`,
+		},
+		"fail": {
+			svcTasks:     nil,
+			svcError:     errListDependencies,
+			expectedResp: `"error retrieving task dependencies"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/tasks/"+idTask+"/dependencies", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				ListTaskDependencies(idTask).
+				Return(test.svcTasks, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			if test.svcError == nil {
+				hdl.Assert().Equal(http.StatusOK, rr.Code)
+			} else {
+				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
+			}
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
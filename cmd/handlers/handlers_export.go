@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// exportEncoder writes the tasks of an export in a given format, one task at a time.
+type exportEncoder interface {
+	begin() error
+	encode(task common.ExportedTask) error
+	end() error
+}
+
+// exportFormat is a format tasks are exported in.
+type exportFormat struct {
+	contentType string
+	extension   string
+	newEncoder  func(w io.Writer) exportEncoder
+}
+
+// exportFormats lists the formats of the format query parameter of an export.
+var exportFormats = map[string]exportFormat{
+	"json": {
+		contentType: "application/json",
+		extension:   ".json",
+		newEncoder:  func(w io.Writer) exportEncoder { return &jsonExportEncoder{w: w} },
+	},
+	"ndjson": {
+		contentType: "application/x-ndjson",
+		extension:   ".ndjson",
+		newEncoder:  func(w io.Writer) exportEncoder { return &ndjsonExportEncoder{enc: json.NewEncoder(w)} },
+	},
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		extension:   ".csv",
+		newEncoder:  func(w io.Writer) exportEncoder { return &csvExportEncoder{w: csv.NewWriter(w)} },
+	},
+}
+
+// ExportTasks sends every task of a user, with their labels, checklists, comments and revisions,
+// as a file in the format of the format query parameter: json (the default), ndjson or csv. The
+// tasks are written as they are read, so an error past the first task can only cut the file short.
+func (handler *Handler) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = "json"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		handler.Logger.Error("Invalid export format.", zap.String("format", name))
+		writeResponse(w, http.StatusBadRequest, "Invalid format parameter.")
+		return
+	}
+
+	export, err := handler.svc.ExportTasks(id)
+	if err != nil {
+		handler.Logger.Error("Unable to export tasks.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	var enc exportEncoder
+	// start sends the headers and the start of the file, once the first task is read.
+	start := func() error {
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "tasks" + format.extension}))
+		w.WriteHeader(http.StatusOK)
+
+		enc = format.newEncoder(w)
+		return enc.begin()
+	}
+
+	for task, err := range export {
+		if err != nil {
+			handler.Logger.Error("Unable to export tasks.", zap.Error(err))
+			if enc == nil {
+				writeResponse(w, errorStatus(err), err.Error())
+			}
+			return
+		}
+
+		if enc == nil {
+			if err := start(); err != nil {
+				handler.Logger.Error("Unable to send export.", zap.Error(err))
+				return
+			}
+		}
+		if err := enc.encode(task); err != nil {
+			handler.Logger.Error("Unable to send export.", zap.Error(err))
+			return
+		}
+	}
+
+	if enc == nil {
+		if err := start(); err != nil {
+			handler.Logger.Error("Unable to send export.", zap.Error(err))
+			return
+		}
+	}
+	if err := enc.end(); err != nil {
+		handler.Logger.Error("Unable to send export.", zap.Error(err))
+	}
+}
+
+// jsonExportEncoder writes the tasks as a JSON array, which can be imported back.
+type jsonExportEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonExportEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExportEncoder) encode(task common.ExportedTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "\n"
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportEncoder) end() error {
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+// ndjsonExportEncoder writes the tasks as newline delimited JSON, a task per line.
+type ndjsonExportEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonExportEncoder) begin() error {
+	return nil
+}
+
+func (e *ndjsonExportEncoder) encode(task common.ExportedTask) error {
+	return e.enc.Encode(task)
+}
+
+func (e *ndjsonExportEncoder) end() error {
+	return nil
+}
+
+// exportColumns is the header of a CSV export. The labels are joined with commas, as imports read
+// them, while the checklist, comments and revisions are JSON arrays.
+var exportColumns = []string{
+	"id", "user_id", "description", "state", "due_at", "remind_at", "completed_at", "parent_id",
+	"project_id", "archived_at", "position", "recurrence", "labels", "checklist", "comments", "revisions",
+}
+
+// csvExportEncoder writes the tasks as CSV, a task per row.
+type csvExportEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvExportEncoder) begin() error {
+	return e.w.Write(exportColumns)
+}
+
+func (e *csvExportEncoder) encode(task common.ExportedTask) error {
+	record := []string{
+		task.Id,
+		task.UserId,
+		task.Description,
+		string(task.State),
+		csvTime(task.DueAt),
+		csvTime(task.RemindAt),
+		csvTime(task.CompletedAt),
+		csvString(task.ParentId),
+		csvString(task.ProjectId),
+		csvTime(task.ArchivedAt),
+		task.Position,
+		task.Recurrence,
+		strings.Join(task.Labels, ","),
+	}
+
+	checklist, err := csvJSON(task.Checklist)
+	if err != nil {
+		return err
+	}
+	comments, err := csvJSON(task.Comments)
+	if err != nil {
+		return err
+	}
+	revisions, err := csvJSON(task.Revisions)
+	if err != nil {
+		return err
+	}
+
+	return e.w.Write(append(record, checklist, comments, revisions))
+}
+
+func (e *csvExportEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvTime formats an optional time of a CSV export, leaving the cell empty when unset.
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// csvString returns an optional string of a CSV export, leaving the cell empty when unset.
+func csvString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// csvJSON encodes a slice of a CSV export as a JSON array, leaving the cell empty when it is empty.
+func csvJSON[T any](value []T) (string, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+// exportIter returns a service.ExportIter yielding tasks, then err if any.
+func exportIter(tasks []common.ExportedTask, err error) service.ExportIter {
+	return func(yield func(common.ExportedTask, error) bool) {
+		for _, task := range tasks {
+			if !yield(task, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(common.ExportedTask{}, err)
+		}
+	}
+}
+
+func (hdl *handlerTestSuite) TestExportTasks() {
+	idUser := "00001"
+	errExport := errors.New("error reading tasks")
+	due := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	tasks := []common.ExportedTask{
+		{
+			Task:      common.Task{Id: "0001", UserId: idUser, Description: "buy milk", State: common.TaskStateToDo, DueAt: &due, Position: "a", Labels: []string{"home", "errands"}},
+			Revisions: []common.TaskRevision{{Id: "r001", TaskId: "0001", Revision: 1, CreatedAt: due, Changes: []common.FieldChange{}}},
+		},
+		{
+			Task:     common.Task{Id: "0002", UserId: idUser, Description: "walk, the dog", State: common.TaskStateDone, Position: "b"},
+			Comments: []common.Comment{{Id: "c001", TaskId: "0002", AuthorId: idUser, Body: "done", CreatedAt: due, UpdatedAt: due}},
+		},
+	}
+	firstJSON := `{"id":"0001","user_id":"00001","description":"buy milk","state":"to_do","due_at":"2026-01-31T09:00:00Z","position":"a","labels":["home","errands"],"revisions":[{"id":"r001","task_id":"0001","revision":1,"created_at":"2026-01-31T09:00:00Z","changes":[]}]}`
+	secondJSON := `{"id":"0002","user_id":"00001","description":"walk, the dog","state":"done","position":"b","comments":[{"id":"c001","task_id":"0002","author_id":"00001","body":"done","created_at":"2026-01-31T09:00:00Z","updated_at":"2026-01-31T09:00:00Z"}]}`
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ExportTasks)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	tests := map[string]struct {
+		query               string
+		svcTasks            []common.ExportedTask
+		svcIterError        error
+		svcError            error
+		skipSvc             bool
+		expectedStatus      int
+		expectedContentType string
+		expectedDisposition string
+		expectedResp        string
+	}{
+		"json": {
+			svcTasks:            tasks,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedDisposition: "attachment; filename=tasks.json",
+			expectedResp:        "[\n" + firstJSON + ",\n" + secondJSON + "\n]\n",
+		},
+		"empty json": {
+			query:               "?format=json",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedDisposition: "attachment; filename=tasks.json",
+			expectedResp:        "[\n]\n",
+		},
+		"ndjson": {
+			query:               "?format=ndjson",
+			svcTasks:            tasks,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedDisposition: "attachment; filename=tasks.ndjson",
+			expectedResp:        firstJSON + "\n" + secondJSON + "\n",
+		},
+		"csv": {
+			query:               "?format=csv",
+			svcTasks:            tasks,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedDisposition: "attachment; filename=tasks.csv",
+			expectedResp: "id,user_id,description,state,due_at,remind_at,completed_at,parent_id,project_id,archived_at,position,recurrence,labels,checklist,comments,revisions\n" +
+				`0001,00001,buy milk,to_do,2026-01-31T09:00:00Z,,,,,,a,,"home,errands",,,"[{""id"":""r001"",""task_id"":""0001"",""revision"":1,""created_at"":""2026-01-31T09:00:00Z"",""changes"":[]}]"` + "\n" +
+				`0002,00001,"walk, the dog",done,,,,,,,b,,,,"[{""id"":""c001"",""task_id"":""0002"",""author_id"":""00001"",""body"":""done"",""created_at"":""2026-01-31T09:00:00Z"",""updated_at"":""2026-01-31T09:00:00Z""}]",` + "\n",
+		},
+		"invalid format": {
+			query:          "?format=xml",
+			skipSvc:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   "\"Invalid format parameter.\"\n",
+		},
+		"user not found": {
+			svcError:       fmt.Errorf("user %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   "\"user not found\"\n",
+		},
+		"fail before the first task": {
+			svcIterError:   errExport,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   "\"error reading tasks\"\n",
+		},
+		"fail after the first task": {
+			query:               "?format=ndjson",
+			svcTasks:            tasks[:1],
+			svcIterError:        errExport,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedDisposition: "attachment; filename=tasks.ndjson",
+			expectedResp:        firstJSON + "\n",
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", fmt.Sprintf("/users/%s/export%s", idUser, test.query), nil).WithContext(ctx)
+
+			// set up service mock
+			if !test.skipSvc {
+				var export service.ExportIter
+				if test.svcError == nil {
+					export = exportIter(test.svcTasks, test.svcIterError)
+				}
+				hdl.getService().
+					ExportTasks(idUser).
+					Return(export, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			if test.expectedContentType != "" {
+				hdl.Assert().Equal(test.expectedContentType, rr.Header().Get("Content-Type"))
+				hdl.Assert().Equal(test.expectedDisposition, rr.Header().Get("Content-Disposition"))
+			}
+			hdl.Assert().Equal(test.expectedResp, rr.Body.String())
+		})
+	}
+}
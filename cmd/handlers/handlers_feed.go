@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/ical"
+	"go.uber.org/zap"
+)
+
+// feedProductId identifies the API as the producer of the calendar feeds.
+const feedProductId = "-//to-do-api//tasks//EN"
+
+// feedStatus maps the state of a task to the STATUS of its VTODO.
+var feedStatus = map[common.TaskState]string{
+	common.TaskStateToDo:       "NEEDS-ACTION",
+	common.TaskStateInProgress: "IN-PROCESS",
+	common.TaskStateBlocked:    "NEEDS-ACTION",
+	common.TaskStateDone:       "COMPLETED",
+	common.TaskStateCancelled:  "CANCELLED",
+}
+
+// CreateFeedToken creates the token a user subscribes to the calendar feed of their tasks with,
+// revoking the earlier one.
+func (handler *Handler) CreateFeedToken(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	token, err := handler.svc.CreateFeedToken(id, authenticatedUserId(r))
+	if err != nil {
+		handler.Logger.Error("Unable to create feed token.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, token)
+}
+
+// RevokeFeedToken revokes the feed token of a user, ending the calendar subscriptions made with it.
+func (handler *Handler) RevokeFeedToken(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	if err := handler.svc.RevokeFeedToken(id, authenticatedUserId(r)); err != nil {
+		handler.Logger.Error("Unable to revoke feed token.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Feed Token Revoked",
+	})
+}
+
+// GetTaskFeed serves the tasks of a user as an iCalendar feed, authenticated by the feed token
+// in the token query parameter rather than by an access token. With events=true, the tasks
+// with a due time also show up as events at that time.
+func (handler *Handler) GetTaskFeed(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	var events bool
+	if value := r.URL.Query().Get("events"); value != "" {
+		var err error
+		if events, err = strconv.ParseBool(value); err != nil {
+			handler.Logger.Error("Invalid events parameter.", zap.Error(err))
+			writeResponse(w, http.StatusBadRequest, "Invalid events parameter.")
+			return
+		}
+	}
+
+	tasks, err := handler.svc.TaskFeed(id, r.URL.Query().Get("token"))
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve task feed.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeTaskFeed(w, tasks, events, time.Now()); err != nil {
+		handler.Logger.Error("Unable to send task feed.", zap.Error(err))
+	}
+}
+
+// writeTaskFeed writes tasks as an iCalendar document: a VTODO per task and, with events set, a
+// VEVENT per task with a due time. Recurrence rules are left out, since the API creates the next
+// occurrence of a recurring task itself, as a task of its own.
+func writeTaskFeed(out io.Writer, tasks []common.Task, events bool, stamp time.Time) error {
+	w := ical.NewWriter(out)
+	w.Begin("VCALENDAR")
+	w.Property("VERSION", "2.0")
+	w.Property("PRODID", feedProductId)
+	w.Property("CALSCALE", "GREGORIAN")
+	w.Text("X-WR-CALNAME", "Tasks")
+
+	for _, task := range tasks {
+		w.Begin("VTODO")
+		w.Text("UID", task.Id)
+		w.Time("DTSTAMP", stamp)
+		w.Text("SUMMARY", task.Description)
+		w.Property("STATUS", feedStatus[task.State])
+		if task.DueAt != nil {
+			w.Time("DUE", *task.DueAt)
+		}
+		if task.CompletedAt != nil {
+			w.Time("COMPLETED", *task.CompletedAt)
+		}
+		if task.Progress != nil {
+			w.Property("PERCENT-COMPLETE", strconv.Itoa(*task.Progress))
+		}
+		if task.ParentId != nil {
+			w.Text("RELATED-TO", *task.ParentId)
+		}
+		if len(task.Labels) > 0 {
+			w.TextList("CATEGORIES", task.Labels)
+		}
+		if task.RemindAt != nil {
+			w.Begin("VALARM")
+			w.Property("ACTION", "DISPLAY")
+			w.Text("DESCRIPTION", task.Description)
+			w.Time("TRIGGER;VALUE=DATE-TIME", *task.RemindAt)
+			w.End("VALARM")
+		}
+		w.End("VTODO")
+
+		if events && task.DueAt != nil {
+			w.Begin("VEVENT")
+			// a VEVENT cannot share the UID of the VTODO of the same task
+			w.Text("UID", task.Id+"-due")
+			w.Time("DTSTAMP", stamp)
+			w.Text("SUMMARY", task.Description)
+			w.Time("DTSTART", *task.DueAt)
+			w.Property("TRANSP", "TRANSPARENT")
+			if task.State == common.TaskStateCancelled {
+				w.Property("STATUS", "CANCELLED")
+			}
+			w.Text("RELATED-TO", task.Id)
+			w.End("VEVENT")
+		}
+	}
+
+	w.End("VCALENDAR")
+	return w.Flush()
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestCreateFeedToken() {
+	idUser := "00001"
+	createdAt := time.Date(2026, time.January, 31, 9, 30, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: idUser})
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.CreateFeedToken)
+
+	tests := map[string]struct {
+		svcToken       *common.FeedToken
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcToken:       &common.FeedToken{UserId: idUser, Token: "secret", CreatedAt: createdAt},
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"user_id":"00001","token":"secret","created_at":"2026-01-31T09:30:00Z"}`,
+		},
+		"forbidden": {
+			svcError:       fmt.Errorf("%w: only the user can create their feed token", service.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedResp:   `"forbidden: only the user can create their feed token"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", fmt.Sprintf("/users/%s/feed_token", idUser), nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				CreateFeedToken(idUser, idUser).
+				Return(test.svcToken, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestRevokeFeedToken() {
+	idUser := "00001"
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: idUser})
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.RevokeFeedToken)
+
+	errDeleteToken := errors.New("error deleting feed token")
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Feed Token Revoked"}`,
+		},
+		"fail": {
+			svcError:       errDeleteToken,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error deleting feed token"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/users/%s/feed_token", idUser), nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				RevokeFeedToken(idUser, idUser).
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestGetTaskFeed() {
+	idUser := "00001"
+	due := time.Date(2026, time.January, 31, 9, 30, 0, 0, time.UTC)
+	tasks := []common.Task{
+		{Id: "0001", UserId: idUser, Description: "buy milk", State: common.TaskStateToDo, DueAt: &due},
+	}
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.GetTaskFeed)
+
+	tests := map[string]struct {
+		query          string
+		svcCalled      bool
+		svcError       error
+		expectedStatus int
+		expectedLines  []string
+		expectedResp   string
+	}{
+		"success": {
+			query:          "?token=secret",
+			svcCalled:      true,
+			expectedStatus: http.StatusOK,
+			expectedLines:  []string{"BEGIN:VTODO", "UID:0001", "SUMMARY:buy milk", "DUE:20260131T093000Z"},
+		},
+		"with events": {
+			query:          "?token=secret&events=true",
+			svcCalled:      true,
+			expectedStatus: http.StatusOK,
+			expectedLines:  []string{"BEGIN:VTODO", "BEGIN:VEVENT", "DTSTART:20260131T093000Z"},
+		},
+		"invalid events": {
+			query:          "?token=secret&events=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"Invalid events parameter."`,
+		},
+		"invalid token": {
+			query:          "?token=guess",
+			svcCalled:      true,
+			svcError:       service.ErrInvalidFeedToken,
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"invalid feed token"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", fmt.Sprintf("/users/%s/tasks.ics%s", idUser, test.query), nil).WithContext(ctx)
+
+			// set up service mock
+			if test.svcCalled {
+				hdl.getService().
+					TaskFeed(idUser, req.URL.Query().Get("token")).
+					Return(tasks, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			if test.expectedStatus != http.StatusOK {
+				hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+				return
+			}
+
+			hdl.Assert().Equal("text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+			lines := strings.Split(rr.Body.String(), "\r\n")
+			for _, line := range test.expectedLines {
+				hdl.Assert().Contains(lines, line)
+			}
+			hdl.Assert().Equal(strings.Contains(test.query, "events=true"), strings.Contains(rr.Body.String(), "BEGIN:VEVENT"))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestWriteTaskFeed() {
+	stamp := time.Date(2026, time.January, 1, 8, 0, 0, 0, time.UTC)
+	due := time.Date(2026, time.January, 31, 9, 30, 0, 0, time.UTC)
+	remind := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	completed := time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)
+	parentId := "0001"
+	progress := 50
+	tasks := []common.Task{
+		{Id: "0001", Description: "move house", State: common.TaskStateInProgress, DueAt: &due, RemindAt: &remind, Progress: &progress, Labels: []string{"home", "a,b"}},
+		{Id: "0002", Description: "pack; label boxes", State: common.TaskStateDone, ParentId: &parentId, CompletedAt: &completed},
+	}
+
+	var out strings.Builder
+	err := writeTaskFeed(&out, tasks, true, stamp)
+	hdl.Require().NoError(err)
+	hdl.Assert().Equal(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//to-do-api//tasks//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Tasks",
+		"BEGIN:VTODO",
+		"UID:0001",
+		"DTSTAMP:20260101T080000Z",
+		"SUMMARY:move house",
+		"STATUS:IN-PROCESS",
+		"DUE:20260131T093000Z",
+		"PERCENT-COMPLETE:50",
+		"CATEGORIES:home,a\\,b",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:move house",
+		"TRIGGER;VALUE=DATE-TIME:20260131T090000Z",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VEVENT",
+		"UID:0001-due",
+		"DTSTAMP:20260101T080000Z",
+		"SUMMARY:move house",
+		"DTSTART:20260131T093000Z",
+		"TRANSP:TRANSPARENT",
+		"RELATED-TO:0001",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:0002",
+		"DTSTAMP:20260101T080000Z",
+		"SUMMARY:pack\\; label boxes",
+		"STATUS:COMPLETED",
+		"COMPLETED:20260102T100000Z",
+		"RELATED-TO:0001",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")+"\r\n", out.String())
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+const importFormName = "file"
+
+// importExtensions tells the format of an imported file from its extension, when the request does not.
+var importExtensions = map[string]common.ImportFormat{
+	".csv":  common.ImportFormatCSV,
+	".json": common.ImportFormatJSON,
+	".txt":  common.ImportFormatTodoTxt,
+}
+
+// ImportTasks creates tasks for a user from an uploaded file. The format query parameter (csv,
+// json or todotxt) defaults to the one of the file extension, each map parameter maps a task
+// field to a CSV column, as in map=due_at:Deadline, and dry_run=true only validates the file.
+func (handler *Handler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	request, err := taskImportFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid import parameters.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		handler.Logger.Error("Unable to read multipart request.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			handler.Logger.Error("No file uploaded.")
+			writeResponse(w, http.StatusBadRequest, "missing "+importFormName+" field")
+			return
+		}
+		if err != nil {
+			handler.Logger.Error("Unable to read multipart request.", zap.Error(err))
+			writeResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if part.FormName() != importFormName {
+			continue
+		}
+
+		if request.Format == "" {
+			format, ok := importExtensions[strings.ToLower(path.Ext(part.FileName()))]
+			if !ok {
+				handler.Logger.Error("Unknown import format.", zap.String("file", part.FileName()))
+				writeResponse(w, http.StatusBadRequest, "missing format parameter")
+				return
+			}
+			request.Format = format
+		}
+
+		result, err := handler.svc.ImportTasks(request, part)
+		if err != nil {
+			handler.Logger.Error("Unable to import tasks.", zap.Error(err))
+			writeResponse(w, errorStatus(err), err.Error())
+			return
+		}
+
+		status := http.StatusCreated
+		if result.DryRun {
+			status = http.StatusOK
+		}
+		writeResponse(w, status, result)
+		return
+	}
+}
+
+// taskImportFromRequest reads the parameters of an import from the query parameters of the request.
+func taskImportFromRequest(r *http.Request) (common.TaskImport, error) {
+	query := r.URL.Query()
+	request := common.TaskImport{
+		UserId: r.Context().Value(idCtx).(string),
+		Format: common.ImportFormat(query.Get("format")),
+	}
+
+	if value := query.Get("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return request, fmt.Errorf("invalid dry_run parameter: %q", value)
+		}
+		request.DryRun = dryRun
+	}
+
+	for _, value := range query["map"] {
+		field, column, ok := strings.Cut(value, ":")
+		if !ok || field == "" || column == "" {
+			return request, fmt.Errorf("invalid map parameter: %q", value)
+		}
+		if request.Mapping == nil {
+			request.Mapping = make(map[string]string)
+		}
+		request.Mapping[field] = column
+	}
+
+	return request, nil
+}
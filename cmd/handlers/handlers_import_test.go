@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+	"github.com/golang/mock/gomock"
+)
+
+func (hdl *handlerTestSuite) TestImportTasks() {
+	idUser := "00001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ImportTasks)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	newBody := func(field string, fileName string) (io.Reader, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile(field, fileName)
+		part.Write([]byte("Title\nbuy milk\n"))
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	tests := map[string]struct {
+		query          string
+		field          string
+		fileName       string
+		request        *common.TaskImport
+		svcResult      *common.ImportResult
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			query:          "?map=description:Title",
+			fileName:       "tasks.csv",
+			request:        &common.TaskImport{UserId: idUser, Format: common.ImportFormatCSV, Mapping: map[string]string{"description": "Title"}},
+			svcResult:      &common.ImportResult{Tasks: []common.Task{{Id: "0001", UserId: idUser, Description: "buy milk", State: "to_do"}}, Errors: []common.ImportError{{Line: 3, Message: "description is required"}}},
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"dry_run":false,"tasks":[{"id":"0001","user_id":"00001","description":"buy milk","state":"to_do"}],"errors":[{"line":3,"message":"description is required"}]}`,
+		},
+		"dry run": {
+			query:          "?format=todotxt&dry_run=true",
+			fileName:       "tasks.csv",
+			request:        &common.TaskImport{UserId: idUser, Format: common.ImportFormatTodoTxt, DryRun: true},
+			svcResult:      &common.ImportResult{DryRun: true, Tasks: []common.Task{}, Errors: []common.ImportError{}},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"dry_run":true,"tasks":[],"errors":[]}`,
+		},
+		"invalid import": {
+			fileName:       "tasks.json",
+			request:        &common.TaskImport{UserId: idUser, Format: common.ImportFormatJSON},
+			svcError:       fmt.Errorf("%w: the file is not a JSON array of tasks", service.ErrInvalidImport),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid import: the file is not a JSON array of tasks"`,
+		},
+		"unknown extension": {
+			fileName:       "tasks.xlsx",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"missing format parameter"`,
+		},
+		"invalid dry run": {
+			query:          "?dry_run=maybe",
+			fileName:       "tasks.csv",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid dry_run parameter: \"maybe\""`,
+		},
+		"invalid map": {
+			query:          "?map=Title",
+			fileName:       "tasks.csv",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid map parameter: \"Title\""`,
+		},
+		"missing file": {
+			field:          "document",
+			fileName:       "tasks.csv",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"missing file field"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			field := "file"
+			if test.field != "" {
+				field = test.field
+			}
+			body, contentType := newBody(field, test.fileName)
+			req := httptest.NewRequest("POST", fmt.Sprintf("/users/%s/import%s", idUser, test.query), body).WithContext(ctx)
+			req.Header.Set("Content-Type", contentType)
+
+			// set up service mock
+			if test.request != nil {
+				hdl.getService().
+					ImportTasks(*test.request, gomock.Any()).
+					DoAndReturn(func(request common.TaskImport, content io.Reader) (*common.ImportResult, error) {
+						data, err := io.ReadAll(content)
+						hdl.Assert().NoError(err)
+						hdl.Assert().Equal("Title\nbuy milk\n", string(data))
+
+						return test.svcResult, test.svcError
+					})
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
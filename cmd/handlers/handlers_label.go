@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+func (handler *Handler) AddLabel(w http.ResponseWriter, r *http.Request) {
+	request := &common.Label{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.UserId = r.Context().Value(idCtx).(string)
+
+	label, err := handler.svc.AddLabel(request)
+	if err != nil {
+		handler.Logger.Error("Unable add label.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, label)
+}
+
+func (handler *Handler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	request := &common.Label{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.UserId = r.Context().Value(idCtx).(string)
+	request.Id = r.Context().Value(subIdCtx).(string)
+
+	label, err := handler.svc.UpdateLabel(request)
+	if err != nil {
+		handler.Logger.Error("Unable update label.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, label)
+}
+
+func (handler *Handler) GetLabel(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	label, err := handler.svc.GetLabel(userId, id)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve label.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, label)
+}
+
+func (handler *Handler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(idCtx).(string)
+	id := r.Context().Value(subIdCtx).(string)
+
+	if err := handler.svc.DeleteLabel(userId, id); err != nil {
+		handler.Logger.Error("Unable to delete label.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Label Deleted",
+	})
+}
+
+func (handler *Handler) ListUserLabels(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(idCtx).(string)
+
+	labels, err := handler.svc.ListUserLabels(userId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve user labels.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, labels)
+}
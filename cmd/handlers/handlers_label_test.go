@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestAddLabel() {
+	idUser := "00001"
+	label := &common.Label{
+		UserId: idUser,
+		Name:   "home",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddLabel)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	errAddLabel := errors.New("error inserting label")
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"home"}`,
+		},
+		"duplicated": {
+			svcError:       fmt.Errorf("%w: %q", service.ErrLabelExists, "home"),
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"label already exists: \"home\""`,
+		},
+		"fail": {
+			svcError:       errAddLabel,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error inserting label"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/users/"+idUser+"/labels", strings.NewReader(`{"name":"home"}`)).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				AddLabel(label).
+				Return(&common.Label{Id: "0001", UserId: idUser, Name: "home"}, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestUpdateLabel() {
+	idUser := "00001"
+	idLabel := "0001"
+	label := &common.Label{
+		Id:     idLabel,
+		UserId: idUser,
+		Name:   "house",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.UpdateLabel)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+	ctx = context.WithValue(ctx, subIdCtx, idLabel)
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"house"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("label %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"label not found"`,
+		},
+		"invalid": {
+			svcError:       fmt.Errorf("%w: name is required", service.ErrInvalidLabel),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid label: name is required"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("PUT", "/users/"+idUser+"/labels/"+idLabel, strings.NewReader(`{"name":"house"}`)).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				UpdateLabel(label).
+				Return(label, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestGetLabel() {
+	idUser := "00001"
+	idLabel := "0001"
+	label := &common.Label{
+		Id:     idLabel,
+		UserId: idUser,
+		Name:   "home",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.GetLabel)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+	ctx = context.WithValue(ctx, subIdCtx, idLabel)
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"home"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("label %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"label not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/users/"+idUser+"/labels/"+idLabel, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				GetLabel(idUser, idLabel).
+				Return(label, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteLabel() {
+	idUser := "00001"
+	idLabel := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteLabel)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+	ctx = context.WithValue(ctx, subIdCtx, idLabel)
+
+	errDeleteLabel := errors.New("error deleting label")
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Label Deleted"}`,
+		},
+		"fail": {
+			svcError:       errDeleteLabel,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error deleting label"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/users/"+idUser+"/labels/"+idLabel, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				DeleteLabel(idUser, idLabel).
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListUserLabels() {
+	idUser := "00001"
+	labels := []common.Label{
+		{Id: "0001", UserId: idUser, Name: "home"},
+		{Id: "0002", UserId: idUser, Name: "work"},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListUserLabels)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	errListLabels := errors.New("error retrieving labels")
+	tests := map[string]struct {
+		svcLabels      []common.Label
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcLabels:      labels,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"id":"0001","user_id":"00001","name":"home"},{"id":"0002","user_id":"00001","name":"work"}]`,
+		},
+		"fail": {
+			svcError:       errListLabels,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving labels"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/users/"+idUser+"/labels", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				ListUserLabels(idUser).
+				Return(test.svcLabels, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+func (handler *Handler) AddProject(w http.ResponseWriter, r *http.Request) {
+	request := &common.Project{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	project, err := handler.svc.AddProject(request)
+	if err != nil {
+		handler.Logger.Error("Unable add project.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, project)
+}
+
+func (handler *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	request := &common.Project{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.Id = r.Context().Value(idCtx).(string)
+
+	project, err := handler.svc.UpdateProject(request)
+	if err != nil {
+		handler.Logger.Error("Unable update project.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, project)
+}
+
+func (handler *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	project, err := handler.svc.GetProject(id)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve project.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, project)
+}
+
+// DeleteProject deletes a project. Its tasks are archived by default, or moved with
+// ?tasks=move to the project given by ?to=, or out of any project when it is omitted.
+func (handler *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	deletion, err := projectDeletionFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid project deletion.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.svc.DeleteProject(id, deletion); err != nil {
+		handler.Logger.Error("Unable to delete project.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Project Deleted",
+	})
+}
+
+func (handler *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := handler.svc.ListProjects()
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve projects.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, projects)
+}
+
+func (handler *Handler) ListUserProjects(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(idCtx).(string)
+
+	projects, err := handler.svc.ListUserProjects(userId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve user projects.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, projects)
+}
+
+func (handler *Handler) ListProjectTasks(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	filter, err := taskFilterFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid task filter.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := pageFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid page.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tasks, err := handler.svc.ListProjectTasks(id, filter, page)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve project tasks.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writePage(w, r, tasks, tasks.NextCursor)
+}
+
+// projectDeletionFromRequest reads what happens to the tasks of a deleted project from the query parameters.
+func projectDeletionFromRequest(r *http.Request) (common.ProjectDeletion, error) {
+	deletion := common.ProjectDeletion{}
+	query := r.URL.Query()
+
+	switch value := query.Get("tasks"); value {
+	case "", "archive":
+		deletion.Archive = true
+	case "move":
+		if to := query.Get("to"); to != "" {
+			deletion.MoveTo = &to
+		}
+	default:
+		return deletion, fmt.Errorf("invalid tasks parameter: %q", value)
+	}
+
+	return deletion, nil
+}
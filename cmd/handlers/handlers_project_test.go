@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestAddProject() {
+	project := &common.Project{
+		UserId: "00001",
+		Name:   "house",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddProject)
+
+	errAddProject := errors.New("error inserting project")
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"house"}`,
+		},
+		"invalid": {
+			svcError:       fmt.Errorf("%w: name is required", service.ErrInvalidProject),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid project: name is required"`,
+		},
+		"fail": {
+			svcError:       errAddProject,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error inserting project"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/projects", strings.NewReader(`{"user_id":"00001","name":"house"}`))
+
+			// set up service mock
+			hdl.getService().
+				AddProject(project).
+				Return(&common.Project{Id: "0001", UserId: "00001", Name: "house"}, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestGetProject() {
+	idProject := "0001"
+	project := &common.Project{
+		Id:     idProject,
+		UserId: "00001",
+		Name:   "house",
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.GetProject)
+
+	ctx := context.WithValue(context.Background(), idCtx, idProject)
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"house"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("project %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"project not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/projects/"+idProject, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				GetProject(idProject).
+				Return(project, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteProject() {
+	idProject := "0001"
+	moveTo := "0002"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteProject)
+
+	ctx := context.WithValue(context.Background(), idCtx, idProject)
+
+	errDeleteProject := errors.New("error deleting project")
+	tests := map[string]struct {
+		query          string
+		deletion       *common.ProjectDeletion
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"archive by default": {
+			deletion:       &common.ProjectDeletion{Archive: true},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Project Deleted"}`,
+		},
+		"move": {
+			query:          "?tasks=move&to=" + moveTo,
+			deletion:       &common.ProjectDeletion{MoveTo: &moveTo},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Project Deleted"}`,
+		},
+		"move to invalid project": {
+			query:          "?tasks=move&to=" + moveTo,
+			deletion:       &common.ProjectDeletion{MoveTo: &moveTo},
+			svcError:       fmt.Errorf("%w: project 0002 belongs to another user", service.ErrInvalidProject),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid project: project 0002 belongs to another user"`,
+		},
+		"invalid tasks parameter": {
+			query:          "?tasks=drop",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid tasks parameter: \"drop\""`,
+		},
+		"fail": {
+			query:          "?tasks=archive",
+			deletion:       &common.ProjectDeletion{Archive: true},
+			svcError:       errDeleteProject,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error deleting project"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/projects/"+idProject+test.query, nil).WithContext(ctx)
+
+			// set up service mock
+			if test.deletion != nil {
+				hdl.getService().
+					DeleteProject(idProject, *test.deletion).
+					Return(test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListProjectTasks() {
+	idProject := "0001"
+	tasks := []common.Task{
+		{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do", ProjectId: &idProject},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListProjectTasks)
+
+	ctx := context.WithValue(context.Background(), idCtx, idProject)
+
+	tests := map[string]struct {
+		query          string
+		filter         *common.TaskFilter
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			filter:         &common.TaskFilter{},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","project_id":"0001"}]}`,
+		},
+		"include archived": {
+			query:          "?include_archived=true",
+			filter:         &common.TaskFilter{IncludeArchived: true},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","project_id":"0001"}]}`,
+		},
+		"invalid include archived": {
+			query:          "?include_archived=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid include_archived parameter: \"maybe\""`,
+		},
+		"not found": {
+			filter:         &common.TaskFilter{},
+			svcError:       fmt.Errorf("project %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"project not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/projects/"+idProject+"/tasks"+test.query, nil).WithContext(ctx)
+
+			// set up service mock
+			if test.filter != nil {
+				hdl.getService().
+					ListProjectTasks(idProject, *test.filter, common.PageRequest{Limit: defaultPageSize}).
+					Return(&common.TaskPage{Items: tasks}, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
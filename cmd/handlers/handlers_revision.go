@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// ListTaskRevisions returns the revision history of a task.
+func (handler *Handler) ListTaskRevisions(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	revisions, err := handler.svc.ListTaskRevisions(id)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve task revisions.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, revisions)
+}
+
+// RevertTask restores a task to how it was at the revision given by {SubId}.
+func (handler *Handler) RevertTask(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+	value := r.Context().Value(subIdCtx).(string)
+
+	revision, err := strconv.Atoi(value)
+	if err != nil {
+		handler.Logger.Error("Invalid revision.", zap.String("revision", value))
+		writeResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid revision: %q", value))
+		return
+	}
+
+	task, err := handler.svc.RevertTask(id, revision, authenticatedUserId(r))
+	if err != nil {
+		handler.Logger.Error("Unable to revert task.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, task)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestListTaskRevisions() {
+	idTask := "00001"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	revisions := []common.TaskRevision{
+		{
+			Id:        "0001",
+			TaskId:    idTask,
+			Revision:  1,
+			ActorId:   "000001",
+			CreatedAt: createdAt,
+			Changes:   []common.FieldChange{{Field: "state", Old: json.RawMessage(`"to_do"`), New: json.RawMessage(`"done"`)}},
+		},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListTaskRevisions)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errListRevisions := errors.New("error retrieving revisions")
+	tests := map[string]struct {
+		svcRevisions   []common.TaskRevision
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcRevisions:   revisions,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"id":"0001","task_id":"00001","revision":1,"actor_id":"000001","created_at":"2024-05-01T10:00:00Z","changes":[{"field":"state","old":"to_do","new":"done"}]}]`,
+		},
+		"task not found": {
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+		"fail": {
+			svcError:       errListRevisions,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving revisions"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/tasks/"+idTask+"/history", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				ListTaskRevisions(idTask).
+				Return(test.svcRevisions, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestRevertTask() {
+	idTask := "00001"
+	task := &common.Task{Id: idTask, UserId: "000001", Description: "buy milk", State: "to_do"}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.RevertTask)
+
+	tests := map[string]struct {
+		revision       string
+		callSvc        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			revision:       "1",
+			callSvc:        true,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"00001","user_id":"000001","description":"buy milk","state":"to_do"}`,
+		},
+		"revision not found": {
+			revision:       "1",
+			callSvc:        true,
+			svcError:       fmt.Errorf("revision %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"revision not found"`,
+		},
+		"invalid transition": {
+			revision:       "1",
+			callSvc:        true,
+			svcError:       fmt.Errorf("%w: from %q to %q", service.ErrInvalidTransition, "cancelled", "to_do"),
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"invalid task state transition: from \"cancelled\" to \"to_do\""`,
+		},
+		"invalid revision": {
+			revision:       "first",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid revision: \"first\""`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			ctx := context.WithValue(context.Background(), idCtx, idTask)
+			ctx = context.WithValue(ctx, subIdCtx, test.revision)
+			ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: "000001"})
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/revert/"+test.revision, nil).WithContext(ctx)
+
+			// set up service mock
+			if test.callSvc {
+				hdl.getService().
+					RevertTask(idTask, 1, "000001").
+					Return(task, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
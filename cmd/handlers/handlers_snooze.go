@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// SnoozeTask hides a task from the lists until the until time of the request body, or for its
+// duration, such as "2h30m".
+func (handler *Handler) SnoozeTask(w http.ResponseWriter, r *http.Request) {
+	request := common.Snooze{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.Context().Value(idCtx).(string)
+
+	task, err := handler.svc.SnoozeTask(id, request)
+	if err != nil {
+		handler.Logger.Error("Unable to snooze task.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, task)
+}
+
+// UnsnoozeTask shows a snoozed task in the lists again.
+func (handler *Handler) UnsnoozeTask(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	task, err := handler.svc.UnsnoozeTask(id)
+	if err != nil {
+		handler.Logger.Error("Unable to unsnooze task.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, task)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestSnoozeTask() {
+	idTask := "0001"
+	until := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	task := &common.Task{Id: idTask, UserId: "00001", Description: "description 1", State: "to_do", SnoozedUntil: &until}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.SnoozeTask)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	tests := map[string]struct {
+		body           string
+		svcSnooze      *common.Snooze
+		svcTask        *common.Task
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"until": {
+			body:           `{"until":"2026-01-31T09:00:00Z"}`,
+			svcSnooze:      &common.Snooze{Until: &until},
+			svcTask:        task,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","snoozed_until":"2026-01-31T09:00:00Z"}`,
+		},
+		"duration": {
+			body:           `{"duration":"2h"}`,
+			svcSnooze:      &common.Snooze{Duration: "2h"},
+			svcTask:        task,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","snoozed_until":"2026-01-31T09:00:00Z"}`,
+		},
+		"invalid snooze": {
+			body:           `{}`,
+			svcSnooze:      &common.Snooze{},
+			svcError:       fmt.Errorf("%w: exactly one of until and duration is required", service.ErrInvalidSnooze),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid snooze: exactly one of until and duration is required"`,
+		},
+		"not found": {
+			body:           `{"duration":"2h"}`,
+			svcSnooze:      &common.Snooze{Duration: "2h"},
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+		"invalid body": {
+			body:           `{"until":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/snooze", strings.NewReader(test.body)).WithContext(ctx)
+
+			// set up service mock
+			if test.svcSnooze != nil {
+				hdl.getService().
+					SnoozeTask(idTask, *test.svcSnooze).
+					Return(test.svcTask, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestUnsnoozeTask() {
+	idTask := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.UnsnoozeTask)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	tests := map[string]struct {
+		svcTask        *common.Task
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcTask:        &common.Task{Id: idTask, UserId: "00001", Description: "description 1", State: "to_do"},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/tasks/"+idTask+"/snooze", nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				UnsnoozeTask(idTask).
+				Return(test.svcTask, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
 	"go.uber.org/zap"
@@ -19,7 +21,7 @@ func (handler *Handler) AddTask(w http.ResponseWriter, r *http.Request) {
 	task, err := handler.svc.AddTask(request)
 	if err != nil {
 		handler.Logger.Error("Unable add Task.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
@@ -35,13 +37,34 @@ func (handler *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 
 	request.Id = r.Context().Value(idCtx).(string)
 
-	task, err := handler.svc.UpdateTask(request)
+	// the task is only updated from the version of the If-Match header, whatever the body says
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		handler.Logger.Error("Invalid If-Match header.", zap.Error(err))
+		writeResponse(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	request.Version = version
+
+	// a task moved into a full board column is rejected unless force is requested
+	force := false
+	if value := r.URL.Query().Get("force"); value != "" {
+		var err error
+		if force, err = strconv.ParseBool(value); err != nil {
+			handler.Logger.Error("Invalid force parameter.", zap.Error(err))
+			writeResponse(w, http.StatusBadRequest, "Invalid force parameter.")
+			return
+		}
+	}
+
+	task, err := handler.svc.UpdateTask(request, authenticatedUserId(r), force)
 	if err != nil {
 		handler.Logger.Error("Unable add Task.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
+	w.Header().Set("ETag", etag(task.Version))
 	writeResponse(w, http.StatusOK, task)
 }
 
@@ -51,16 +74,29 @@ func (handler *Handler) GetTask(w http.ResponseWriter, r *http.Request) {
 	task, err := handler.svc.GetTask(id)
 	if err != nil {
 		handler.Logger.Error("Unable to retrieve task.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
+	w.Header().Set("ETag", etag(task.Version))
 	writeResponse(w, http.StatusOK, task)
 }
 
 func (handler *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(idCtx).(string)
-	err := handler.svc.DeleteTask(id)
+
+	// subtasks are moved up to the parent of the deleted task unless cascade is requested
+	cascade := false
+	if value := r.URL.Query().Get("cascade"); value != "" {
+		var err error
+		if cascade, err = strconv.ParseBool(value); err != nil {
+			handler.Logger.Error("Invalid cascade parameter.", zap.Error(err))
+			writeResponse(w, http.StatusBadRequest, "Invalid cascade parameter.")
+			return
+		}
+	}
+
+	err := handler.svc.DeleteTask(id, cascade)
 	if err != nil {
 		handler.Logger.Error("Unable to delete tasks.", zap.Error(err))
 		writeResponse(w, http.StatusInternalServerError, err.Error())
@@ -72,11 +108,88 @@ func (handler *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// MoveTask places a task right before or right after another task of the same user.
+func (handler *Handler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	request := common.Move{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.Context().Value(idCtx).(string)
+
+	task, err := handler.svc.MoveTask(id, request)
+	if err != nil {
+		handler.Logger.Error("Unable to move task.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, task)
+}
+
 func (handler *Handler) ListTasks(w http.ResponseWriter, r *http.Request) {
-	tasks, err := handler.svc.ListTasks()
+	filter, err := taskFilterFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid task filter.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := pageFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid page.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tasks, err := handler.svc.ListTasks(filter, page)
 	if err != nil {
 		handler.Logger.Error("Unable to retrieve tasks.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writePage(w, r, tasks, tasks.NextCursor)
+}
+
+// SearchTasks runs a full-text search given by the q parameter, optionally restricted
+// to the tasks of user_id or in state, and returning at most limit tasks.
+func (handler *Handler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	search := common.TaskSearch{
+		Query:  query.Get("q"),
+		UserId: query.Get("user_id"),
+		State:  common.TaskState(query.Get("state")),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			handler.Logger.Error("Invalid search limit.", zap.String("limit", value))
+			writeResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid limit parameter: %q", value))
+			return
+		}
+		search.Limit = limit
+	}
+
+	results, err := handler.svc.SearchTasks(search)
+	if err != nil {
+		handler.Logger.Error("Unable to search tasks.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, results)
+}
+
+func (handler *Handler) ListSubtasks(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+
+	tasks, err := handler.svc.ListSubtasks(id)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve subtasks.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
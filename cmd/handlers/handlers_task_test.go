@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
 	"github.com/google/uuid"
 )
 
@@ -94,21 +96,101 @@ func (hdl *handlerTestSuite) TestUpdateTask() {
 
 	ctx := context.WithValue(context.Background(), idCtx, idTask)
 
+	// the version of an update comes from the If-Match header
+	versionedTask := &common.Task{Id: idTask, UserId: "00001", Description: "description 1", State: "to_do", Version: 2}
+	updatedTask := &common.Task{Id: idTask, UserId: "00001", Description: "description 1", State: "to_do", Version: 3}
+
 	errUpdateTask := errors.New("error updating task")
+	errTransition := fmt.Errorf("%w: from %q to %q", service.ErrInvalidTransition, "done", "blocked")
 	tests := map[string]struct {
-		task         *common.Task
-		svcError     error
-		expectedResp string
+		task           *common.Task
+		svcTask        *common.Task
+		query          string
+		ifMatch        string
+		force          bool
+		svcError       error
+		expectedStatus int
+		expectedETag   string
+		expectedResp   string
 	}{
 		"success": {
-			task:         task,
-			svcError:     nil,
-			expectedResp: `{"message":"Task Updated"}`,
+			task:           task,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Task Updated"}`,
 		},
 		"fail": {
-			task:         task,
-			svcError:     errUpdateTask,
-			expectedResp: `"error updating task"`,
+			task:           task,
+			svcError:       errUpdateTask,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error updating task"`,
+		},
+		"invalid transition": {
+			task:           task,
+			svcError:       errTransition,
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"invalid task state transition: from \"done\" to \"blocked\""`,
+		},
+		"not found": {
+			task:           task,
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+		"wip limit reached": {
+			task:           task,
+			svcError:       fmt.Errorf("%w: 3 of 3 tasks are in_progress", service.ErrWIPLimitReached),
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"wip limit reached: 3 of 3 tasks are in_progress"`,
+		},
+		"forced": {
+			task:           task,
+			query:          "?force=true",
+			force:          true,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Task Updated"}`,
+		},
+		"invalid force": {
+			query:          "?force=sure",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"Invalid force parameter."`,
+		},
+		"if match": {
+			task:           versionedTask,
+			svcTask:        updatedTask,
+			ifMatch:        `"2"`,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		"any version": {
+			task:           task,
+			svcTask:        updatedTask,
+			ifMatch:        "*",
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		"version mismatch": {
+			task:           versionedTask,
+			ifMatch:        `"2"`,
+			svcError:       fmt.Errorf("%w: the task is at version 3", service.ErrVersionMismatch),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResp:   `"version mismatch: the task is at version 3"`,
+		},
+		"concurrent update": {
+			task:           task,
+			svcError:       fmt.Errorf("%w: version conflict", service.ErrConcurrentUpdate),
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"concurrent update: version conflict"`,
+		},
+		"weak if match": {
+			ifMatch:        `W/"2"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResp:   `"If-Match header W/\"2\" matches no version"`,
+		},
+		"invalid if match": {
+			ifMatch:        `"two"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResp:   `"If-Match header \"two\" matches no version"`,
 		},
 	}
 
@@ -120,20 +202,32 @@ func (hdl *handlerTestSuite) TestUpdateTask() {
 			hdl.Assert().NoError(err)
 
 			// Create a request to pass to our handler.
-			req := httptest.NewRequest("PUT", "/tasks/"+idTask, io.NopCloser(&buf)).WithContext(ctx)
+			req := httptest.NewRequest("PUT", "/tasks/"+idTask+test.query, io.NopCloser(&buf)).WithContext(ctx)
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
 
 			// set up service mock
-			hdl.getService().
-				UpdateTask(test.task).
-				Return(test.task, test.svcError)
+			if test.task != nil {
+				svcTask := test.task
+				if test.svcTask != nil {
+					svcTask = test.svcTask
+				}
+				hdl.getService().
+					UpdateTask(test.task, "", test.force).
+					Return(svcTask, test.svcError)
+			}
 
 			handler.ServeHTTP(rr, req)
-			if test.svcError == nil {
-				hdl.Assert().Equal(http.StatusOK, rr.Code)
-				err = json.NewDecoder(rr.Body).Decode(&test.task)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			if test.expectedETag != "" {
+				hdl.Assert().Equal(test.expectedETag, rr.Header().Get("ETag"))
+			}
+			if test.expectedStatus == http.StatusOK {
+				resp := &common.Task{}
+				err = json.NewDecoder(rr.Body).Decode(resp)
 				hdl.Assert().NoError(err)
 			} else {
-				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
 				hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
 			}
 		})
@@ -148,6 +242,7 @@ func (hdl *handlerTestSuite) TestGetTask() {
 		UserId:      "00001",
 		Description: "description 1",
 		State:       "to_do",
+		Version:     2,
 	}
 
 	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
@@ -157,16 +252,26 @@ func (hdl *handlerTestSuite) TestGetTask() {
 
 	errGetTask := errors.New("error retrieving task")
 	tests := map[string]struct {
-		svcError     error
-		expectedResp string
+		svcError       error
+		expectedStatus int
+		expectedETag   string
+		expectedResp   string
 	}{
 		"success": {
-			svcError:     nil,
-			expectedResp: `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}`,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedResp:   `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","version":2}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
 		},
 		"fail": {
-			svcError:     errGetTask,
-			expectedResp: `"error retrieving task"`,
+			svcError:       errGetTask,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving task"`,
 		},
 	}
 
@@ -186,11 +291,8 @@ func (hdl *handlerTestSuite) TestGetTask() {
 				Return(task, test.svcError)
 
 			handler.ServeHTTP(rr, req)
-			if test.svcError == nil {
-				hdl.Assert().Equal(http.StatusOK, rr.Code)
-			} else {
-				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
-			}
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedETag, rr.Header().Get("ETag"))
 			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
 		})
 	}
@@ -206,16 +308,157 @@ func (hdl *handlerTestSuite) TestDeleteTask() {
 
 	errDeleteTask := errors.New("error deleting task")
 	tests := map[string]struct {
+		query          string
+		cascade        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Task Deleted"}`,
+		},
+		"cascade": {
+			query:          "?cascade=true",
+			cascade:        true,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Task Deleted"}`,
+		},
+		"invalid cascade": {
+			query:          "?cascade=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"Invalid cascade parameter."`,
+		},
+		"fail": {
+			svcError:       errDeleteTask,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error deleting task"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/tasks/"+idTask+test.query, nil).WithContext(ctx)
+
+			// set up service mock
+			if test.expectedStatus != http.StatusBadRequest {
+				hdl.getService().
+					DeleteTask(idTask, test.cascade).
+					Return(test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+
+	}
+}
+
+func (hdl *handlerTestSuite) TestMoveTask() {
+	idTask := "0001"
+	task := &common.Task{Id: idTask, UserId: "00001", Description: "description 1", State: "to_do", Position: "b"}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.MoveTask)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	tests := map[string]struct {
+		body           string
+		svcMove        *common.Move
+		svcTask        *common.Task
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			body:           `{"before":"0002"}`,
+			svcMove:        &common.Move{Before: "0002"},
+			svcTask:        task,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","position":"b"}`,
+		},
+		"invalid move": {
+			body:           `{}`,
+			svcMove:        &common.Move{},
+			svcError:       fmt.Errorf("%w: exactly one of before and after is required", service.ErrInvalidMove),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid move: exactly one of before and after is required"`,
+		},
+		"not found": {
+			body:           `{"after":"0002"}`,
+			svcMove:        &common.Move{After: "0002"},
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+		"invalid body": {
+			body:           `{"before":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/move", strings.NewReader(test.body)).WithContext(ctx)
+
+			// set up service mock
+			if test.svcMove != nil {
+				hdl.getService().
+					MoveTask(idTask, *test.svcMove).
+					Return(test.svcTask, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListSubtasks() {
+	idTask := "0001"
+	parentId := idTask
+	tasks := []common.Task{
+		{
+			Id:          "0002",
+			UserId:      "00001",
+			Description: "description 2",
+			State:       "to_do",
+			ParentId:    &parentId,
+		},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListSubtasks)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	errListSubtasks := errors.New("error retrieving subtasks")
+	tests := map[string]struct {
+		svcTasks     []common.Task
 		svcError     error
 		expectedResp string
 	}{
 		"success": {
+			svcTasks:     tasks,
 			svcError:     nil,
-			expectedResp: `{"message":"Task Deleted"}`,
+			expectedResp: `[{"id":"0002","user_id":"00001","description":"description 2","state":"to_do","parent_id":"0001"}]`,
 		},
 		"fail": {
-			svcError:     errDeleteTask,
-			expectedResp: `"error deleting task"`,
+			svcTasks:     nil,
+			svcError:     errListSubtasks,
+			expectedResp: `"error retrieving subtasks"`,
 		},
 	}
 
@@ -224,12 +467,12 @@ func (hdl *handlerTestSuite) TestDeleteTask() {
 			rr := httptest.NewRecorder()
 
 			// Create a request to pass to our handler.
-			req := httptest.NewRequest("DELETE", "/tasks/"+idTask, nil).WithContext(ctx)
+			req := httptest.NewRequest("GET", "/tasks/"+idTask+"/subtasks", nil).WithContext(ctx)
 
 			// set up service mock
 			hdl.getService().
-				DeleteTask(idTask).
-				Return(test.svcError)
+				ListSubtasks(idTask).
+				Return(test.svcTasks, test.svcError)
 
 			handler.ServeHTTP(rr, req)
 			if test.svcError == nil {
@@ -239,7 +482,6 @@ func (hdl *handlerTestSuite) TestDeleteTask() {
 			}
 			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
 		})
-
 	}
 }
 
@@ -250,6 +492,7 @@ func (hdl *handlerTestSuite) TestListTasks() {
 			UserId:      "00001",
 			Description: "description 1",
 			State:       "to_do",
+			Labels:      []string{"home"},
 		},
 		{
 			Id:          "0002",
@@ -264,18 +507,68 @@ func (hdl *handlerTestSuite) TestListTasks() {
 
 	errGetTasks := errors.New("error retrieving tasks")
 	tests := map[string]struct {
-		tasks        []common.Task
-		svcError     error
-		expectedResp string
+		query          string
+		filter         common.TaskFilter
+		page           common.PageRequest
+		tasks          []common.Task
+		nextCursor     string
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+		expectedLink   string
 	}{
 		"success": {
-			tasks:        tasks,
-			svcError:     nil,
-			expectedResp: `[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]`,
+			tasks:          tasks,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","labels":["home"]},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]}`,
+		},
+		"label": {
+			query:          "?label=home",
+			filter:         common.TaskFilter{Labels: []string{"home"}},
+			tasks:          tasks[:1],
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","labels":["home"]}]}`,
+		},
+		"page": {
+			query:          "?label=home&limit=1",
+			filter:         common.TaskFilter{Labels: []string{"home"}},
+			page:           common.PageRequest{Limit: 1},
+			tasks:          tasks[:1],
+			nextCursor:     "WyIwMDAxIl0",
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","labels":["home"]}],"next_cursor":"WyIwMDAxIl0"}`,
+			expectedLink:   `</tasks/?cursor=WyIwMDAxIl0&label=home&limit=1>; rel="next"`,
+		},
+		"limit above the maximum": {
+			query:          "?limit=100000",
+			page:           common.PageRequest{Limit: maxPageSize},
+			tasks:          tasks,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","labels":["home"]},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]}`,
+		},
+		"invalid limit": {
+			query:          "?limit=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid limit parameter: \"0\""`,
+		},
+		"invalid cursor": {
+			query:          "?cursor=abc",
+			page:           common.PageRequest{Limit: defaultPageSize, Cursor: "abc"},
+			svcError:       fmt.Errorf("%w: invalid cursor", service.ErrInvalidPage),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid page: invalid cursor"`,
+		},
+		"invalid filter": {
+			query:          "?ready=soon",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid ready parameter: \"soon\""`,
 		},
 		"fail": {
-			svcError:     errGetTasks,
-			expectedResp: `"error retrieving tasks"`,
+			svcError:       errGetTasks,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving tasks"`,
 		},
 	}
 
@@ -284,19 +577,90 @@ func (hdl *handlerTestSuite) TestListTasks() {
 			rr := httptest.NewRecorder()
 
 			// Create a request to pass to our handler.
-			req := httptest.NewRequest("GET", "/tasks/", nil)
+			req := httptest.NewRequest("GET", "/tasks/"+test.query, nil)
 
 			// set up service mock
-			hdl.getService().
-				ListTasks().
-				Return(tasks, test.svcError)
+			page := test.page
+			if page == (common.PageRequest{}) {
+				page.Limit = defaultPageSize
+			}
+			if test.tasks != nil || test.svcError != nil {
+				hdl.getService().
+					ListTasks(test.filter, page).
+					Return(&common.TaskPage{Items: test.tasks, NextCursor: test.nextCursor}, test.svcError)
+			}
 
 			handler.ServeHTTP(rr, req)
-			if test.svcError == nil {
-				hdl.Assert().Equal(http.StatusOK, rr.Code)
-			} else {
-				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+			hdl.Assert().Equal(test.expectedLink, rr.Header().Get("Link"))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestSearchTasks() {
+	results := []common.TaskSearchResult{
+		{
+			Task:    common.Task{Id: "0001", UserId: "00001", Description: "buy milk", State: "to_do"},
+			Rank:    0.06,
+			Snippet: "buy <b>milk</b>",
+		},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.SearchTasks)
+
+	tests := map[string]struct {
+		query          string
+		search         common.TaskSearch
+		results        []common.TaskSearchResult
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			query:          "?q=milk",
+			search:         common.TaskSearch{Query: "milk"},
+			results:        results,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"id":"0001","user_id":"00001","description":"buy milk","state":"to_do","rank":0.06,"snippet":"buy \u003cb\u003emilk\u003c/b\u003e"}]`,
+		},
+		"filtered": {
+			query:          "?q=milk&user_id=00001&state=to_do&limit=5",
+			search:         common.TaskSearch{Query: "milk", UserId: "00001", State: "to_do", Limit: 5},
+			results:        []common.TaskSearchResult{},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[]`,
+		},
+		"invalid limit": {
+			query:          "?q=milk&limit=many",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid limit parameter: \"many\""`,
+		},
+		"empty query": {
+			search:         common.TaskSearch{},
+			svcError:       fmt.Errorf("%w: query is required", service.ErrInvalidSearch),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid search: query is required"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/tasks/search"+test.query, nil)
+
+			// set up service mock
+			if test.results != nil || test.svcError != nil {
+				hdl.getService().
+					SearchTasks(test.search).
+					Return(test.results, test.svcError)
 			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
 			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
 		})
 	}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+func (handler *Handler) AddTemplate(w http.ResponseWriter, r *http.Request) {
+	request := &common.Template{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request.UserId = authenticatedUserId(r)
+	if request.UserId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	template, err := handler.svc.AddTemplate(request)
+	if err != nil {
+		handler.Logger.Error("Unable add template.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, template)
+}
+
+func (handler *Handler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	template, err := handler.svc.GetTemplate(id, userId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve template.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, template)
+}
+
+func (handler *Handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	if err := handler.svc.DeleteTemplate(id, userId); err != nil {
+		handler.Logger.Error("Unable to delete template.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, map[string]string{
+		"message": "Template Deleted",
+	})
+}
+
+// ListTemplates returns the templates of the user of the access token.
+func (handler *Handler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	templates, err := handler.svc.ListTemplates(userId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve templates.", zap.Error(err))
+		writeResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, templates)
+}
+
+// InstantiateTemplate creates the tasks of a template for the user of the access token, who must
+// own the template. The body is optional; without it the due offsets count from now.
+func (handler *Handler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	request := common.TemplateInstantiation{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		handler.Logger.Error("Unable to decode request body.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	tasks, err := handler.svc.InstantiateTemplate(id, request, userId)
+	if err != nil {
+		handler.Logger.Error("Unable to instantiate template.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, tasks)
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestAddTemplate() {
+	template := &common.Template{UserId: "00001", Name: "onboarding", Tasks: []common.TemplateTask{
+		{Description: "get a laptop", DueOffset: "24h", Checklist: []string{"charger"}},
+	}}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.AddTemplate)
+
+	tests := map[string]struct {
+		body           string
+		claims         *common.Claims
+		svcTemplate    *common.Template
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			body:           `{"name":"onboarding","tasks":[{"description":"get a laptop","due_offset":"24h","checklist":["charger"]}]}`,
+			claims:         &common.Claims{UserID: "00001"},
+			svcTemplate:    template,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"onboarding","tasks":[{"description":"get a laptop","due_offset":"24h","checklist":["charger"]}]}`,
+		},
+		"invalid": {
+			body:           `{"name":"onboarding"}`,
+			claims:         &common.Claims{UserID: "00001"},
+			svcTemplate:    &common.Template{UserId: "00001", Name: "onboarding"},
+			svcError:       fmt.Errorf("%w: no tasks", service.ErrInvalidTemplate),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid template: no tasks"`,
+		},
+		"unauthenticated": {
+			body:           `{"name":"onboarding","tasks":[{"description":"get a laptop"}]}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+		"invalid body": {
+			body:           `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/templates", strings.NewReader(test.body))
+			if test.claims != nil {
+				req = req.WithContext(context.WithValue(req.Context(), claimsCtx, test.claims))
+			}
+
+			// set up service mock
+			if test.svcTemplate != nil {
+				hdl.getService().
+					AddTemplate(test.svcTemplate).
+					Return(&common.Template{Id: "0001", UserId: "00001", Name: template.Name, Tasks: template.Tasks}, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestGetTemplate() {
+	idTemplate := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.GetTemplate)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTemplate)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: "00001"})
+
+	tests := map[string]struct {
+		svcTemplate    *common.Template
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcTemplate:    &common.Template{Id: idTemplate, UserId: "00001", Name: "onboarding", Tasks: []common.TemplateTask{{Description: "get a laptop"}}},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","name":"onboarding","tasks":[{"description":"get a laptop"}]}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("template %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"template not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/templates/"+idTemplate, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				GetTemplate(idTemplate, "00001").
+				Return(test.svcTemplate, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestDeleteTemplate() {
+	idTemplate := "0001"
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.DeleteTemplate)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTemplate)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: "00001"})
+
+	tests := map[string]struct {
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"message":"Template Deleted"}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("template %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"template not found"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("DELETE", "/templates/"+idTemplate, nil).WithContext(ctx)
+
+			// set up service mock
+			hdl.getService().
+				DeleteTemplate(idTemplate, "00001").
+				Return(test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestListTemplates() {
+	templates := []common.Template{
+		{Id: "0001", UserId: "00001", Name: "onboarding", Tasks: []common.TemplateTask{{Description: "get a laptop"}}},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListTemplates)
+
+	errListTemplates := errors.New("error retrieving templates")
+	tests := map[string]struct {
+		svcTemplates   []common.Template
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			svcTemplates:   templates,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `[{"id":"0001","user_id":"00001","name":"onboarding","tasks":[{"description":"get a laptop"}]}]`,
+		},
+		"fail": {
+			svcError:       errListTemplates,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving templates"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/templates", nil).
+				WithContext(context.WithValue(context.Background(), claimsCtx, &common.Claims{UserID: "00001"}))
+
+			// set up service mock
+			hdl.getService().
+				ListTemplates("00001").
+				Return(test.svcTemplates, test.svcError)
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestInstantiateTemplate() {
+	idTemplate := "0001"
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	dueAt := start.Add(24 * time.Hour)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.InstantiateTemplate)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTemplate)
+	ctx = context.WithValue(ctx, claimsCtx, &common.Claims{UserID: "00001"})
+
+	tests := map[string]struct {
+		body             string
+		svcInstantiation *common.TemplateInstantiation
+		svcTasks         []common.Task
+		svcError         error
+		expectedStatus   int
+		expectedResp     string
+	}{
+		"success": {
+			body:             `{"start":"2024-05-01T09:00:00Z"}`,
+			svcInstantiation: &common.TemplateInstantiation{Start: &start},
+			svcTasks:         []common.Task{{Id: "1001", UserId: "00001", Description: "get a laptop", State: "to_do", DueAt: &dueAt, Position: "i"}},
+			expectedStatus:   http.StatusCreated,
+			expectedResp:     `[{"id":"1001","user_id":"00001","description":"get a laptop","state":"to_do","due_at":"2024-05-02T09:00:00Z","position":"i"}]`,
+		},
+		"empty body": {
+			svcInstantiation: &common.TemplateInstantiation{},
+			svcTasks:         []common.Task{{Id: "1001", UserId: "00001", Description: "get a laptop", State: "to_do", Position: "i"}},
+			expectedStatus:   http.StatusCreated,
+			expectedResp:     `[{"id":"1001","user_id":"00001","description":"get a laptop","state":"to_do","position":"i"}]`,
+		},
+		"template not found": {
+			body:             `{}`,
+			svcInstantiation: &common.TemplateInstantiation{},
+			svcError:         fmt.Errorf("template %w", service.ErrNotFound),
+			expectedStatus:   http.StatusNotFound,
+			expectedResp:     `"template not found"`,
+		},
+		"invalid body": {
+			body:           `{"start":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"unexpected EOF"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/templates/"+idTemplate+"/instantiate", strings.NewReader(test.body)).WithContext(ctx)
+
+			// set up service mock
+			if test.svcInstantiation != nil {
+				hdl.getService().
+					InstantiateTemplate(idTemplate, *test.svcInstantiation, "00001").
+					Return(test.svcTasks, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StartTimer starts a timer on a task for the user of the access token.
+func (handler *Handler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	entry, err := handler.svc.StartTimer(taskId, userId)
+	if err != nil {
+		handler.Logger.Error("Unable to start timer.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, entry)
+}
+
+// StopTimer stops the timer the user of the access token is running on a task.
+func (handler *Handler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	taskId := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	entry, err := handler.svc.StopTimer(taskId, userId)
+	if err != nil {
+		handler.Logger.Error("Unable to stop timer.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, entry)
+}
+
+// GetTimesheet returns the time a user logged per task and per day, from the from date to the
+// to date, both included, given as 2006-01-02 and read as UTC days.
+func (handler *Handler) GetTimesheet(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(idCtx).(string)
+
+	var dates [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		value := r.URL.Query().Get(name)
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			handler.Logger.Error("Invalid timesheet date.", zap.String(name, value))
+			writeResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid %s parameter: %q", name, value))
+			return
+		}
+		dates[i] = date
+	}
+
+	// the to day is included
+	timesheet, err := handler.svc.Timesheet(userId, dates[0], dates[1].AddDate(0, 0, 1))
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve timesheet.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, timesheet)
+}
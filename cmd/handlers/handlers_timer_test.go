@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestStartTimer() {
+	idTask := "0001"
+	idUser := "00001"
+	startedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.StartTimer)
+
+	tests := map[string]struct {
+		claims         *common.Claims
+		callSvc        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			claims:         &common.Claims{UserID: idUser},
+			callSvc:        true,
+			expectedStatus: http.StatusCreated,
+			expectedResp:   `{"id":"000001","task_id":"0001","user_id":"00001","started_at":"2024-05-01T10:00:00Z"}`,
+		},
+		"already running": {
+			claims:         &common.Claims{UserID: idUser},
+			callSvc:        true,
+			svcError:       fmt.Errorf("%w on task 0002", service.ErrTimerRunning),
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"timer already running on task 0002"`,
+		},
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			ctx := context.WithValue(context.Background(), idCtx, idTask)
+			if test.claims != nil {
+				ctx = context.WithValue(ctx, claimsCtx, test.claims)
+			}
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/timer/start", nil).WithContext(ctx)
+
+			// set up service mock
+			if test.callSvc {
+				hdl.getService().
+					StartTimer(idTask, idUser).
+					Return(&common.TimeEntry{Id: "000001", TaskId: idTask, UserId: idUser, StartedAt: startedAt}, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestStopTimer() {
+	idTask := "0001"
+	idUser := "00001"
+	startedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	stoppedAt := startedAt.Add(90 * time.Minute)
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.StopTimer)
+
+	tests := map[string]struct {
+		claims         *common.Claims
+		callSvc        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			claims:         &common.Claims{UserID: idUser},
+			callSvc:        true,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"000001","task_id":"0001","user_id":"00001","started_at":"2024-05-01T10:00:00Z","stopped_at":"2024-05-01T11:30:00Z"}`,
+		},
+		"not running": {
+			claims:         &common.Claims{UserID: idUser},
+			callSvc:        true,
+			svcError:       fmt.Errorf("running timer %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"running timer not found"`,
+		},
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			ctx := context.WithValue(context.Background(), idCtx, idTask)
+			if test.claims != nil {
+				ctx = context.WithValue(ctx, claimsCtx, test.claims)
+			}
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/timer/stop", nil).WithContext(ctx)
+
+			// set up service mock
+			if test.callSvc {
+				hdl.getService().
+					StopTimer(idTask, idUser).
+					Return(&common.TimeEntry{Id: "000001", TaskId: idTask, UserId: idUser, StartedAt: startedAt, StoppedAt: &stoppedAt}, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestGetTimesheet() {
+	idUser := "00001"
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	timesheet := &common.Timesheet{
+		UserId:       idUser,
+		From:         from,
+		To:           to,
+		TotalSeconds: 5400,
+		Tasks:        []common.TaskTime{{TaskId: "0001", Seconds: 5400}},
+		Days:         []common.DayTime{{Date: "2024-05-01", Seconds: 5400}},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.GetTimesheet)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	errTimesheet := errors.New("error retrieving time entries")
+	tests := map[string]struct {
+		query          string
+		callSvc        bool
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			query:          "?from=2024-05-01&to=2024-05-02",
+			callSvc:        true,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"user_id":"00001","from":"2024-05-01T00:00:00Z","to":"2024-05-03T00:00:00Z","total_seconds":5400,"tasks":[{"task_id":"0001","seconds":5400}],"days":[{"date":"2024-05-01","seconds":5400}]}`,
+		},
+		"invalid from": {
+			query:          "?from=yesterday&to=2024-05-02",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid from parameter: \"yesterday\""`,
+		},
+		"missing to": {
+			query:          "?from=2024-05-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid to parameter: \"\""`,
+		},
+		"fail": {
+			query:          "?from=2024-05-01&to=2024-05-02",
+			callSvc:        true,
+			svcError:       errTimesheet,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving time entries"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/users/"+idUser+"/timesheet"+test.query, nil).WithContext(ctx)
+
+			// set up service mock
+			if test.callSvc {
+				hdl.getService().
+					Timesheet(idUser, from, to).
+					Return(timesheet, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
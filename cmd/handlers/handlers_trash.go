@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// ListTrash returns the deleted tasks of the user of the access token, and the user when deleted,
+// that have not been purged yet.
+func (handler *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	trash, err := handler.svc.ListTrash(userId)
+	if err != nil {
+		handler.Logger.Error("Unable to retrieve the trash.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, trash)
+}
+
+// RestoreTask takes a task of the user of the access token out of the trash.
+func (handler *Handler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	task, err := handler.svc.RestoreTask(id, userId)
+	if err != nil {
+		handler.Logger.Error("Unable to restore task.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, task)
+}
+
+// RestoreUser takes the user of the access token out of the trash.
+func (handler *Handler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(idCtx).(string)
+	userId := authenticatedUserId(r)
+	if userId == "" {
+		handler.Logger.Error("No authenticated user.")
+		writeResponse(w, http.StatusUnauthorized, "no authenticated user")
+		return
+	}
+
+	user, err := handler.svc.RestoreUser(id, userId)
+	if err != nil {
+		handler.Logger.Error("Unable to restore user.", zap.Error(err))
+		writeResponse(w, errorStatus(err), err.Error())
+		return
+	}
+
+	writeResponse(w, http.StatusOK, user)
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
+)
+
+func (hdl *handlerTestSuite) TestListTrash() {
+	deletedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	trash := &common.Trash{
+		Tasks: []common.Task{{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do", DeletedAt: &deletedAt}},
+		Users: []common.User{},
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.ListTrash)
+
+	errListTrash := errors.New("error retrieving deleted tasks")
+	tests := map[string]struct {
+		claims         *common.Claims
+		svcTrash       *common.Trash
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			claims:         &common.Claims{UserID: "00001"},
+			svcTrash:       trash,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"tasks":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do","deleted_at":"2024-05-01T10:00:00Z"}],"users":[]}`,
+		},
+		"fail": {
+			claims:         &common.Claims{UserID: "00001"},
+			svcError:       errListTrash,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving deleted tasks"`,
+		},
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			ctx := context.Background()
+			if test.claims != nil {
+				ctx = context.WithValue(ctx, claimsCtx, test.claims)
+			}
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("GET", "/trash", nil).WithContext(ctx)
+
+			// set up service mock
+			if test.claims != nil {
+				hdl.getService().
+					ListTrash(test.claims.UserID).
+					Return(test.svcTrash, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestRestoreTask() {
+	idTask := "0001"
+	task := &common.Task{Id: idTask, UserId: "00001", Description: "description 1", State: "to_do"}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.RestoreTask)
+
+	ctx := context.WithValue(context.Background(), idCtx, idTask)
+
+	tests := map[string]struct {
+		claims         *common.Claims
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			claims:         &common.Claims{UserID: "00001"},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}`,
+		},
+		"not in the trash": {
+			claims:         &common.Claims{UserID: "00001"},
+			svcError:       fmt.Errorf("task %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"task not found"`,
+		},
+		"parent deleted": {
+			claims:         &common.Claims{UserID: "00001"},
+			svcError:       fmt.Errorf("%w: the parent task is deleted", service.ErrInvalidRestore),
+			expectedStatus: http.StatusConflict,
+			expectedResp:   `"invalid restore: the parent task is deleted"`,
+		},
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/tasks/"+idTask+"/restore", nil).WithContext(ctx)
+			if test.claims != nil {
+				req = req.WithContext(context.WithValue(ctx, claimsCtx, test.claims))
+			}
+
+			// set up service mock
+			if test.claims != nil {
+				hdl.getService().
+					RestoreTask(idTask, test.claims.UserID).
+					Return(task, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
+
+func (hdl *handlerTestSuite) TestRestoreUser() {
+	idUser := "00001"
+	user := &common.User{Id: idUser, Username: "username1", Name: "User Name 1"}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	handler := http.HandlerFunc(hdl.handler.RestoreUser)
+
+	ctx := context.WithValue(context.Background(), idCtx, idUser)
+
+	tests := map[string]struct {
+		claims         *common.Claims
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+	}{
+		"success": {
+			claims:         &common.Claims{UserID: "00001"},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"00001","username":"username1","name":"User Name 1"}`,
+		},
+		"not in the trash": {
+			claims:         &common.Claims{UserID: "00001"},
+			svcError:       fmt.Errorf("user %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"user not found"`,
+		},
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+			expectedResp:   `"no authenticated user"`,
+		},
+	}
+
+	for index, test := range tests {
+		hdl.Run(index, func() {
+			rr := httptest.NewRecorder()
+
+			// Create a request to pass to our handler.
+			req := httptest.NewRequest("POST", "/users/"+idUser+"/restore", nil).WithContext(ctx)
+			if test.claims != nil {
+				req = req.WithContext(context.WithValue(ctx, claimsCtx, test.claims))
+			}
+
+			// set up service mock
+			if test.claims != nil {
+				hdl.getService().
+					RestoreUser(idUser, test.claims.UserID).
+					Return(user, test.svcError)
+			}
+
+			handler.ServeHTTP(rr, req)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+		})
+	}
+}
@@ -48,13 +48,23 @@ func (handler *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	request.Id = r.Context().Value(idCtx).(string)
 
+	// the user is only updated from the version of the If-Match header, whatever the body says
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		handler.Logger.Error("Invalid If-Match header.", zap.Error(err))
+		writeResponse(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	request.Version = version
+
 	user, err := handler.svc.UpdateUser(request)
 	if err != nil {
 		handler.Logger.Error("Unable update user.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
+	w.Header().Set("ETag", etag(user.Version))
 	writeResponse(w, http.StatusOK, user)
 }
 
@@ -64,10 +74,11 @@ func (handler *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	user, err := handler.svc.GetUser(id)
 	if err != nil {
 		handler.Logger.Error("Unable to retrieve user.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
+	w.Header().Set("ETag", etag(user.Version))
 	writeResponse(w, http.StatusOK, user)
 }
 
@@ -87,30 +98,50 @@ func (handler *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (handler *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
-	users, err := handler.svc.ListUsers()
+	page, err := pageFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid page.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users, err := handler.svc.ListUsers(page)
 	if err != nil {
 		handler.Logger.Error("Unable to retrieve users.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
 	handler.Logger.Info("Users listed.",
 		zap.Bool("success", true))
 
-	writeResponse(w, http.StatusOK, users)
+	writePage(w, r, users, users.NextCursor)
 }
 
 func (handler *Handler) ListUserTasks(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(idCtx).(string)
 
-	users, err := handler.svc.ListUserTasks(id)
+	filter, err := taskFilterFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid task filter.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := pageFromRequest(r)
+	if err != nil {
+		handler.Logger.Error("Invalid page.", zap.Error(err))
+		writeResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users, err := handler.svc.ListUserTasks(id, filter, page)
 	if err != nil {
 		handler.Logger.Error("Unable to retrieve user tasks.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
-	writeResponse(w, http.StatusOK, users)
+	writePage(w, r, users, users.NextCursor)
 }
 
 func (handler *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
@@ -119,7 +150,7 @@ func (handler *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	user, err := handler.svc.GetUser(id)
 	if err != nil {
 		handler.Logger.Error("Unable to retrieve user.", zap.Error(err))
-		writeResponse(w, http.StatusInternalServerError, err.Error())
+		writeResponse(w, errorStatus(err), err.Error())
 		return
 	}
 
@@ -9,9 +9,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	taskfilter "github.com/aborgesrodrigues/to-do-api/internal/filter"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
 	"github.com/google/uuid"
 )
 
@@ -94,21 +97,50 @@ func (hdl *handlerTestSuite) TestUpdateUser() {
 
 	ctx := context.WithValue(context.Background(), idCtx, idUser)
 
+	// the version of an update comes from the If-Match header
+	versionedUser := &common.User{Id: idUser, Username: "username1", Name: "User Name 1", Version: 2}
+	updatedUser := &common.User{Id: idUser, Username: "username1", Name: "User Name 1", Version: 3}
+
 	errUpdateUser := errors.New("error updating user")
 	tests := map[string]struct {
-		user         *common.User
-		svcError     error
-		expectedResp string
+		user           *common.User
+		svcUser        *common.User
+		ifMatch        string
+		svcError       error
+		expectedStatus int
+		expectedETag   string
+		expectedResp   string
 	}{
 		"success": {
-			user:         user,
-			svcError:     nil,
-			expectedResp: `{"id":"","username":"username1","name":"User Name 1"}`,
+			user:           user,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"id":"","username":"username1","name":"User Name 1"}`,
 		},
 		"fail": {
-			user:         user,
-			svcError:     errUpdateUser,
-			expectedResp: `"error updating user"`,
+			user:           user,
+			svcError:       errUpdateUser,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error updating user"`,
+		},
+		"if match": {
+			user:           versionedUser,
+			svcUser:        updatedUser,
+			ifMatch:        `"2"`,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		"version mismatch": {
+			user:           versionedUser,
+			ifMatch:        `"2"`,
+			svcError:       fmt.Errorf("%w: %w", service.ErrVersionMismatch, errors.New("version conflict")),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResp:   `"version mismatch: version conflict"`,
+		},
+		"invalid if match": {
+			ifMatch:        `"2", "3"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResp:   `"If-Match header \"2\", \"3\" matches no version"`,
 		},
 	}
 
@@ -121,20 +153,32 @@ func (hdl *handlerTestSuite) TestUpdateUser() {
 
 			// Create a request to pass to our handler.
 			req := httptest.NewRequest("PUT", "/users/"+idUser, io.NopCloser(&buf)).WithContext(ctx)
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
 
 			// set up service mock
-			hdl.getService().
-				UpdateUser(test.user).
-				Return(test.user, test.svcError)
+			if test.user != nil {
+				svcUser := test.user
+				if test.svcUser != nil {
+					svcUser = test.svcUser
+				}
+				hdl.getService().
+					UpdateUser(test.user).
+					Return(svcUser, test.svcError)
+			}
 
 			handler.ServeHTTP(rr, req)
 
-			if test.svcError == nil {
-				hdl.Assert().Equal(http.StatusOK, rr.Code)
-				err = json.NewDecoder(rr.Body).Decode(&test.user)
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			if test.expectedETag != "" {
+				hdl.Assert().Equal(test.expectedETag, rr.Header().Get("ETag"))
+			}
+			if test.expectedStatus == http.StatusOK {
+				resp := &common.User{}
+				err = json.NewDecoder(rr.Body).Decode(resp)
 				hdl.Assert().NoError(err)
 			} else {
-				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
 				hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
 			}
 
@@ -149,6 +193,7 @@ func (hdl *handlerTestSuite) TestGetUser() {
 		Id:       idUser,
 		Username: "username1",
 		Name:     "User Name 1",
+		Version:  2,
 	}
 
 	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
@@ -158,16 +203,26 @@ func (hdl *handlerTestSuite) TestGetUser() {
 
 	errGetUser := errors.New("error retrieving user")
 	tests := map[string]struct {
-		svcError     error
-		expectedResp string
+		svcError       error
+		expectedStatus int
+		expectedETag   string
+		expectedResp   string
 	}{
 		"success": {
-			svcError:     nil,
-			expectedResp: `{"id":"0001","username":"username1","name":"User Name 1"}`,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedResp:   `{"id":"0001","username":"username1","name":"User Name 1","version":2}`,
+		},
+		"not found": {
+			svcError:       fmt.Errorf("user %w", service.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedResp:   `"user not found"`,
 		},
 		"fail": {
-			svcError:     errGetUser,
-			expectedResp: `"error retrieving user"`,
+			svcError:       errGetUser,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving user"`,
 		},
 	}
 
@@ -187,11 +242,8 @@ func (hdl *handlerTestSuite) TestGetUser() {
 				Return(user, test.svcError)
 
 			handler.ServeHTTP(rr, req)
-			if test.svcError == nil {
-				hdl.Assert().Equal(http.StatusOK, rr.Code)
-			} else {
-				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
-			}
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
+			hdl.Assert().Equal(test.expectedETag, rr.Header().Get("ETag"))
 			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
 		})
 	}
@@ -264,13 +316,21 @@ func (hdl *handlerTestSuite) TestListUsers() {
 	errGetUsers := errors.New("error retrieving users")
 	tests := map[string]struct {
 		users        []common.User
+		nextCursor   string
 		svcError     error
 		expectedResp string
+		expectedLink string
 	}{
 		"success": {
 			users:        users,
 			svcError:     nil,
-			expectedResp: `[{"id":"0001","username":"username1","name":"User Name 1"},{"id":"0002","username":"username2","name":"User Name 2"}]`,
+			expectedResp: `{"items":[{"id":"0001","username":"username1","name":"User Name 1"},{"id":"0002","username":"username2","name":"User Name 2"}]}`,
+		},
+		"next page": {
+			users:        users,
+			nextCursor:   "WyIwMDAyIl0",
+			expectedResp: `{"items":[{"id":"0001","username":"username1","name":"User Name 1"},{"id":"0002","username":"username2","name":"User Name 2"}],"next_cursor":"WyIwMDAyIl0"}`,
+			expectedLink: `</users/?cursor=WyIwMDAyIl0>; rel="next"`,
 		},
 		"fail": {
 			svcError:     errGetUsers,
@@ -286,8 +346,8 @@ func (hdl *handlerTestSuite) TestListUsers() {
 			req := httptest.NewRequest("GET", "/users/", nil)
 
 			hdl.getService().
-				ListUsers().
-				Return(users, test.svcError)
+				ListUsers(common.PageRequest{Limit: defaultPageSize}).
+				Return(&common.UserPage{Items: users, NextCursor: test.nextCursor}, test.svcError)
 
 			handler.ServeHTTP(rr, req)
 			if test.svcError == nil {
@@ -296,6 +356,7 @@ func (hdl *handlerTestSuite) TestListUsers() {
 				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
 			}
 			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+			hdl.Assert().Equal(test.expectedLink, rr.Header().Get("Link"))
 		})
 	}
 }
@@ -322,19 +383,104 @@ func (hdl *handlerTestSuite) TestListUserTasks() {
 	handler := http.HandlerFunc(hdl.handler.ListUserTasks)
 
 	errGetUsers := errors.New("error retrieving users")
+	filterExpr, err := taskfilter.Parse(`state in (to_do, in_progress) and description ~ "description"`)
+	hdl.Require().NoError(err)
+	unknownFieldExpr, err := taskfilter.Parse("priority = high")
+	hdl.Require().NoError(err)
+
 	tests := map[string]struct {
-		tasks        []common.Task
-		svcError     error
-		expectedResp string
+		query          string
+		filter         common.TaskFilter
+		page           common.PageRequest
+		tasks          []common.Task
+		nextCursor     string
+		svcError       error
+		expectedStatus int
+		expectedResp   string
+		expectedLink   string
 	}{
 		"success": {
-			tasks:        tasks,
-			svcError:     nil,
-			expectedResp: `[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]`,
+			tasks:          tasks,
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]}`,
+		},
+		"ready": {
+			query:          "?ready=true",
+			filter:         common.TaskFilter{Ready: true},
+			tasks:          tasks[:1],
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}]}`,
+		},
+		"invalid ready": {
+			query:          "?ready=soon",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid ready parameter: \"soon\""`,
+		},
+		"labels": {
+			query:          "?label=home&label=work&label_match=all",
+			filter:         common.TaskFilter{Labels: []string{"home", "work"}, LabelMatch: common.LabelMatchAll},
+			tasks:          tasks[:1],
+			svcError:       nil,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}]}`,
+		},
+		"invalid label match": {
+			query:          "?label=home&label_match=some",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid label_match parameter: \"some\""`,
+		},
+		"include snoozed": {
+			query:          "?include_snoozed=true",
+			filter:         common.TaskFilter{IncludeSnoozed: true},
+			tasks:          tasks,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]}`,
+		},
+		"invalid include snoozed": {
+			query:          "?include_snoozed=later",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid include_snoozed parameter: \"later\""`,
+		},
+		"filter": {
+			query:          "?filter=" + url.QueryEscape(`state in (to_do, in_progress) and description ~ "description"`),
+			filter:         common.TaskFilter{Expr: filterExpr},
+			tasks:          tasks,
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"},{"id":"0002","user_id":"00002","description":"description 2","state":"to_do"}]}`,
+		},
+		"invalid filter syntax": {
+			query:          "?filter=" + url.QueryEscape("state in (to_do"),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid filter parameter: invalid filter expression: expected ',' or ')', found end of expression at position 16"`,
+		},
+		"invalid filter field": {
+			query:          "?filter=" + url.QueryEscape("priority = high"),
+			filter:         common.TaskFilter{Expr: unknownFieldExpr},
+			svcError:       fmt.Errorf("%w: unknown field \"priority\"", service.ErrInvalidFilter),
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid filter: unknown field \"priority\""`,
+		},
+		"page": {
+			query:          "?ready=true&limit=1&cursor=WyJhIiwiMDAwMCJd",
+			filter:         common.TaskFilter{Ready: true},
+			page:           common.PageRequest{Limit: 1, Cursor: "WyJhIiwiMDAwMCJd"},
+			tasks:          tasks[:1],
+			nextCursor:     "WyJiIiwiMDAwMSJd",
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"items":[{"id":"0001","user_id":"00001","description":"description 1","state":"to_do"}],"next_cursor":"WyJiIiwiMDAwMSJd"}`,
+			expectedLink:   `</users/0001/tasks?cursor=WyJiIiwiMDAwMSJd&limit=1&ready=true>; rel="next"`,
+		},
+		"invalid limit": {
+			query:          "?limit=ten",
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `"invalid limit parameter: \"ten\""`,
 		},
 		"fail": {
-			svcError:     errGetUsers,
-			expectedResp: `"error retrieving users"`,
+			svcError:       errGetUsers,
+			expectedStatus: http.StatusInternalServerError,
+			expectedResp:   `"error retrieving users"`,
 		},
 	}
 
@@ -343,19 +489,22 @@ func (hdl *handlerTestSuite) TestListUserTasks() {
 			rr := httptest.NewRecorder()
 
 			// Create a request to pass to our handler.
-			req := httptest.NewRequest("GET", fmt.Sprintf("/users/%s/tasks", idUser), nil).WithContext(ctx)
+			req := httptest.NewRequest("GET", fmt.Sprintf("/users/%s/tasks%s", idUser, test.query), nil).WithContext(ctx)
 
-			hdl.getService().
-				ListUserTasks(idUser).
-				Return(tasks, test.svcError)
+			page := test.page
+			if page == (common.PageRequest{}) {
+				page.Limit = defaultPageSize
+			}
+			if test.tasks != nil || test.svcError != nil {
+				hdl.getService().
+					ListUserTasks(idUser, test.filter, page).
+					Return(&common.TaskPage{Items: test.tasks, NextCursor: test.nextCursor}, test.svcError)
+			}
 
 			handler.ServeHTTP(rr, req)
-			if test.svcError == nil {
-				hdl.Assert().Equal(http.StatusOK, rr.Code)
-			} else {
-				hdl.Assert().Equal(http.StatusInternalServerError, rr.Code)
-			}
+			hdl.Assert().Equal(test.expectedStatus, rr.Code)
 			hdl.Assert().Equal(test.expectedResp, strings.TrimSpace(rr.Body.String()))
+			hdl.Assert().Equal(test.expectedLink, rr.Header().Get("Link"))
 		})
 	}
 }
@@ -25,13 +25,26 @@ type ctxKey string
 
 const (
 	idCtx = ctxKey("Id")
+	// subIdCtx holds the id of a resource nested under another one, such as a label of a user.
+	subIdCtx = ctxKey("SubId")
+	// claimsCtx holds the claims of the access token validated by VerifyJWT.
+	claimsCtx = ctxKey("Claims")
 )
 
 func (handler *Handler) IdMiddleware(next http.Handler) http.Handler {
+	return handler.urlParamMiddleware(idCtx, next)
+}
+
+// SubIdMiddleware reads the id of a nested resource from the {SubId} URL parameter.
+func (handler *Handler) SubIdMiddleware(next http.Handler) http.Handler {
+	return handler.urlParamMiddleware(subIdCtx, next)
+}
+
+func (handler *Handler) urlParamMiddleware(key ctxKey, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		id := chi.URLParam(r, string(idCtx))
+		id := chi.URLParam(r, string(key))
 		if id != "" {
-			r = r.WithContext(context.WithValue(r.Context(), idCtx, id))
+			r = r.WithContext(context.WithValue(r.Context(), key, id))
 		}
 
 		handler.Logger.Debug(id)
@@ -175,7 +188,7 @@ func (handler *Handler) VerifyJWT(next http.Handler) http.Handler {
 			return
 		}
 
-		next.ServeHTTP(rw, r)
+		next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), claimsCtx, claims)))
 	})
 }
 
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
 	"github.com/aborgesrodrigues/to-do-api/internal/logging"
 	"github.com/aborgesrodrigues/to-do-api/internal/service"
 	"go.uber.org/zap"
@@ -19,3 +20,11 @@ type AccessLoggerOptions struct {
 	// request context.
 	DefaultLogger *zap.Logger
 }
+
+// batchResult is the outcome of an operation of a task batch as sent to the client, with the
+// status the operation would have had as a request of its own.
+type batchResult struct {
+	Status int          `json:"status"`
+	Task   *common.Task `json:"task,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
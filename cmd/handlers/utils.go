@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	taskfilter "github.com/aborgesrodrigues/to-do-api/internal/filter"
 	"github.com/aborgesrodrigues/to-do-api/internal/logging"
 	"github.com/aborgesrodrigues/to-do-api/internal/service"
 	"github.com/golang-jwt/jwt/v5"
@@ -15,8 +20,15 @@ import (
 
 const envJWTSecretKey = "JWT_SECRET_KEY"
 
-func New(logger *zap.Logger, auditLogger *logging.HTTPAuditLogger) *Handler {
-	svc, err := service.New(service.Config{Logger: logger})
+const (
+	// defaultPageSize is the size of the pages of a list requested without a limit parameter.
+	defaultPageSize = 50
+	// maxPageSize is the largest page of a list, whatever its limit parameter asks for.
+	maxPageSize = 500
+)
+
+func New(logger *zap.Logger, auditLogger *logging.HTTPAuditLogger, attachments service.AttachmentConfig) *Handler {
+	svc, err := service.New(service.Config{Logger: logger, Attachments: attachments})
 	if err != nil {
 		panic(err)
 	}
@@ -28,12 +40,175 @@ func New(logger *zap.Logger, auditLogger *logging.HTTPAuditLogger) *Handler {
 	}
 }
 
+// Service returns the service used by the handler, so background jobs can share it.
+func (handler *Handler) Service() service.SVCInterface {
+	return handler.svc
+}
+
 func writeResponse(w http.ResponseWriter, status int, message interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(message)
 }
 
+// errorStatus maps an error returned by the service to the HTTP status sent to the client.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidState),
+		errors.Is(err, service.ErrInvalidParent),
+		errors.Is(err, service.ErrInvalidDependency),
+		errors.Is(err, service.ErrInvalidRecurrence),
+		errors.Is(err, service.ErrInvalidProject),
+		errors.Is(err, service.ErrInvalidLabel),
+		errors.Is(err, service.ErrInvalidComment),
+		errors.Is(err, service.ErrInvalidAttachment),
+		errors.Is(err, service.ErrInvalidSearch),
+		errors.Is(err, service.ErrInvalidBatch),
+		errors.Is(err, service.ErrInvalidMove),
+		errors.Is(err, service.ErrInvalidTimesheet),
+		errors.Is(err, service.ErrInvalidChecklist),
+		errors.Is(err, service.ErrInvalidTemplate),
+		errors.Is(err, service.ErrInvalidWIPLimit),
+		errors.Is(err, service.ErrInvalidImport),
+		errors.Is(err, service.ErrInvalidSnooze),
+		errors.Is(err, service.ErrInvalidFilter),
+		errors.Is(err, service.ErrInvalidPage):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInvalidFeedToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrBatchAborted):
+		return http.StatusFailedDependency
+	case errors.Is(err, service.ErrInvalidTransition),
+		errors.Is(err, service.ErrTaskBlocked),
+		errors.Is(err, service.ErrLabelExists),
+		errors.Is(err, service.ErrInvalidRestore),
+		errors.Is(err, service.ErrTimerRunning),
+		errors.Is(err, service.ErrWIPLimitReached),
+		errors.Is(err, service.ErrConcurrentUpdate):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// authenticatedUserId returns the id of the user of the access token validated by VerifyJWT,
+// or an empty string when the request went through no such validation.
+func authenticatedUserId(r *http.Request) string {
+	if claims, ok := r.Context().Value(claimsCtx).(*common.Claims); ok {
+		return claims.UserID
+	}
+	return ""
+}
+
+// taskFilterFromRequest reads the task filter from the query parameters of a list request.
+func taskFilterFromRequest(r *http.Request) (common.TaskFilter, error) {
+	filter := common.TaskFilter{}
+	query := r.URL.Query()
+
+	if value := query.Get("ready"); value != "" {
+		ready, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid ready parameter: %q", value)
+		}
+		filter.Ready = ready
+	}
+
+	if value := query.Get("include_archived"); value != "" {
+		includeArchived, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include_archived parameter: %q", value)
+		}
+		filter.IncludeArchived = includeArchived
+	}
+
+	if value := query.Get("include_snoozed"); value != "" {
+		includeSnoozed, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include_snoozed parameter: %q", value)
+		}
+		filter.IncludeSnoozed = includeSnoozed
+	}
+
+	filter.Labels = query["label"]
+	filter.LabelMatch = common.LabelMatch(query.Get("label_match"))
+	switch filter.LabelMatch {
+	case "", common.LabelMatchAny, common.LabelMatchAll:
+	default:
+		return filter, fmt.Errorf("invalid label_match parameter: %q", filter.LabelMatch)
+	}
+
+	if value := query.Get("filter"); value != "" {
+		expr, err := taskfilter.Parse(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid filter parameter: %w", err)
+		}
+		filter.Expr = expr
+	}
+
+	return filter, nil
+}
+
+// pageFromRequest reads the page of a list from the limit and cursor query parameters of a request.
+// The limit defaults to defaultPageSize, and is lowered to maxPageSize.
+func pageFromRequest(r *http.Request) (common.PageRequest, error) {
+	query := r.URL.Query()
+	page := common.PageRequest{Limit: defaultPageSize, Cursor: query.Get("cursor")}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return page, fmt.Errorf("invalid limit parameter: %q", value)
+		}
+		page.Limit = min(limit, maxPageSize)
+	}
+
+	return page, nil
+}
+
+// writePage sends a page of a list, with a Link header to the next page when there is one, which
+// repeats the query parameters of the request with the cursor of the next page.
+func writePage(w http.ResponseWriter, r *http.Request, page any, nextCursor string) {
+	if nextCursor != "" {
+		next := *r.URL
+		query := next.Query()
+		query.Set("cursor", nextCursor)
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
+
+	writeResponse(w, http.StatusOK, page)
+}
+
+// etag returns the entity tag of a version of a task or a user, sent in the ETag header of its reads.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion reads the version an update applies to from the If-Match header of a request, which
+// carries the ETag header of a read. The version is 0, matching any version, when the header is
+// missing or *. Weak and malformed entity tags never match.
+func ifMatchVersion(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	if len(value) > 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		if version, err := strconv.Atoi(value[1 : len(value)-1]); err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("If-Match header %s matches no version", value)
+}
+
 func generateJWT(user *common.User) (string, string, error) {
 	jwtSecretKey := viper.GetString(envJWTSecretKey)
 
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"net/http/pprof"
 	_ "net/http/pprof"
+	"time"
 
 	"github.com/aborgesrodrigues/to-do-api/cmd/handlers"
 	"github.com/aborgesrodrigues/to-do-api/internal/audit"
+	"github.com/aborgesrodrigues/to-do-api/internal/blob"
 	"github.com/aborgesrodrigues/to-do-api/internal/logging"
+	"github.com/aborgesrodrigues/to-do-api/internal/scheduler"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
@@ -21,6 +26,22 @@ const (
 	envVarAuditLogS3Directory = "AUDITLOG_S3_DIRECTORY"
 	envVarAuditLogS3Endpoint  = "AUDITLOG_S3_ENDPOINT"
 	envVarAuditLogS3Region    = "AUDITLOG_S3_REGION"
+
+	// Attachment env vars. Attachments go to S3 when a bucket is set and to ATTACHMENT_DIR otherwise.
+	envVarAttachmentDir         = "ATTACHMENT_DIR"
+	envVarAttachmentMaxSize     = "ATTACHMENT_MAX_SIZE"
+	envVarAttachmentURLExpiry   = "ATTACHMENT_URL_EXPIRY"
+	envVarAttachmentS3Bucket    = "ATTACHMENT_S3_BUCKET"
+	envVarAttachmentS3Directory = "ATTACHMENT_S3_DIRECTORY"
+	envVarAttachmentS3Endpoint  = "ATTACHMENT_S3_ENDPOINT"
+	envVarAttachmentS3Region    = "ATTACHMENT_S3_REGION"
+
+	// Scheduler env vars
+	envVarReminderInterval   = "REMINDER_INTERVAL"
+	envVarSnoozeWakeInterval = "SNOOZE_WAKE_INTERVAL"
+	// Deleted tasks and users are purged from the trash once TRASH_RETENTION has passed.
+	envVarTrashRetention     = "TRASH_RETENTION"
+	envVarTrashPurgeInterval = "TRASH_PURGE_INTERVAL"
 )
 
 func main() {
@@ -55,7 +76,11 @@ func main() {
 	}
 	defer auditLogger.Close()
 
-	hdl := handlers.New(logger, auditLogger)
+	hdl := handlers.New(logger, auditLogger, getAttachmentConfig(logger))
+
+	sched := getScheduler(hdl, logger)
+	sched.Start(context.Background())
+	defer sched.Stop()
 
 	logger.Info("Server listening.", zap.String("addr", "8080"))
 	if err := http.ListenAndServe(":8080", getRouter(hdl)); err != nil {
@@ -99,6 +124,12 @@ func getRouter(hdl *handlers.Handler) *chi.Mux {
 			r.Get("/", hdl.RefreshToken)
 		})
 
+		// calendar feed, with its feed token instead of a JWT
+		r.Route("/users/{Id}/tasks.ics", func(r chi.Router) {
+			r.Use(hdl.IdMiddleware)
+			r.Get("/", hdl.GetTaskFeed)
+		})
+
 		// with JWT
 		r.Route("/", func(r chi.Router) {
 			r.Use(logging.RequestLogger(hdl.Logger))
@@ -116,18 +147,117 @@ func getRouter(hdl *handlers.Handler) *chi.Mux {
 					r.Get("/", hdl.GetUser)
 					r.Put("/", hdl.UpdateUser)
 					r.Delete("/", hdl.DeleteUser)
+					r.Post("/restore", hdl.RestoreUser)
 					r.Get("/tasks", hdl.ListUserTasks)
+					r.Get("/projects", hdl.ListUserProjects)
+					r.Get("/timesheet", hdl.GetTimesheet)
+					r.Post("/import", hdl.ImportTasks)
+					r.Get("/export", hdl.ExportTasks)
+					r.Route("/board", func(r chi.Router) {
+						r.Get("/", hdl.GetBoard)
+						r.Put("/limits", hdl.SetWIPLimits)
+					})
+					r.Route("/feed_token", func(r chi.Router) {
+						r.Post("/", hdl.CreateFeedToken)
+						r.Delete("/", hdl.RevokeFeedToken)
+					})
+					r.Route("/labels", func(r chi.Router) {
+						r.Get("/", hdl.ListUserLabels)
+						r.Post("/", hdl.AddLabel)
+						r.Route("/{SubId}", func(r chi.Router) {
+							r.Use(hdl.SubIdMiddleware)
+							r.Get("/", hdl.GetLabel)
+							r.Put("/", hdl.UpdateLabel)
+							r.Delete("/", hdl.DeleteLabel)
+						})
+					})
+				})
+			})
+
+			r.Route("/projects", func(r chi.Router) {
+				r.Get("/", hdl.ListProjects)
+				r.Post("/", hdl.AddProject)
+				r.Route("/{Id}", func(r chi.Router) {
+					r.Use(hdl.IdMiddleware)
+					r.Get("/", hdl.GetProject)
+					r.Put("/", hdl.UpdateProject)
+					r.Delete("/", hdl.DeleteProject)
+					r.Get("/tasks", hdl.ListProjectTasks)
 				})
 			})
 
+			r.Route("/templates", func(r chi.Router) {
+				r.Get("/", hdl.ListTemplates)
+				r.Post("/", hdl.AddTemplate)
+				r.Route("/{Id}", func(r chi.Router) {
+					r.Use(hdl.IdMiddleware)
+					r.Get("/", hdl.GetTemplate)
+					r.Delete("/", hdl.DeleteTemplate)
+					r.Post("/instantiate", hdl.InstantiateTemplate)
+				})
+			})
+
+			r.Get("/trash", hdl.ListTrash)
+			r.Post("/tasks:batch", hdl.BatchTasks)
+
 			r.Route("/tasks", func(r chi.Router) {
 				r.Get("/", hdl.ListTasks)
 				r.Post("/", hdl.AddTask)
+				r.Get("/search", hdl.SearchTasks)
 				r.Route("/{Id}", func(r chi.Router) {
 					r.Use(hdl.IdMiddleware)
 					r.Get("/", hdl.GetTask)
 					r.Put("/", hdl.UpdateTask)
 					r.Delete("/", hdl.DeleteTask)
+					r.Post("/restore", hdl.RestoreTask)
+					r.Post("/move", hdl.MoveTask)
+					r.Route("/snooze", func(r chi.Router) {
+						r.Post("/", hdl.SnoozeTask)
+						r.Delete("/", hdl.UnsnoozeTask)
+					})
+					r.Get("/subtasks", hdl.ListSubtasks)
+					r.Get("/history", hdl.ListTaskRevisions)
+					r.Route("/revert/{SubId}", func(r chi.Router) {
+						r.Use(hdl.SubIdMiddleware)
+						r.Post("/", hdl.RevertTask)
+					})
+					r.Route("/comments", func(r chi.Router) {
+						r.Get("/", hdl.ListTaskComments)
+						r.Post("/", hdl.AddComment)
+						r.Route("/{SubId}", func(r chi.Router) {
+							r.Use(hdl.SubIdMiddleware)
+							r.Get("/", hdl.GetComment)
+							r.Put("/", hdl.UpdateComment)
+							r.Delete("/", hdl.DeleteComment)
+						})
+					})
+					r.Route("/attachments", func(r chi.Router) {
+						r.Get("/", hdl.ListTaskAttachments)
+						r.Post("/", hdl.AddAttachment)
+						r.Route("/{SubId}", func(r chi.Router) {
+							r.Use(hdl.SubIdMiddleware)
+							r.Get("/", hdl.DownloadAttachment)
+							r.Delete("/", hdl.DeleteAttachment)
+						})
+					})
+					r.Route("/dependencies", func(r chi.Router) {
+						r.Get("/", hdl.ListTaskDependencies)
+						r.Post("/", hdl.AddTaskDependency)
+						r.Delete("/", hdl.DeleteTaskDependency)
+					})
+					r.Route("/timer", func(r chi.Router) {
+						r.Post("/start", hdl.StartTimer)
+						r.Post("/stop", hdl.StopTimer)
+					})
+					r.Route("/checklist", func(r chi.Router) {
+						r.Post("/", hdl.AddChecklistItem)
+						r.Route("/{SubId}", func(r chi.Router) {
+							r.Use(hdl.SubIdMiddleware)
+							r.Post("/toggle", hdl.ToggleChecklistItem)
+							r.Post("/move", hdl.MoveChecklistItem)
+							r.Delete("/", hdl.DeleteChecklistItem)
+						})
+					})
 				})
 			})
 		})
@@ -137,6 +267,103 @@ func getRouter(hdl *handlers.Handler) *chi.Mux {
 	return r
 }
 
+func getAttachmentConfig(logger *zap.Logger) service.AttachmentConfig {
+	viper.SetDefault(envVarAttachmentDir, "attachments")
+
+	config := service.AttachmentConfig{
+		Store:     blob.NewFileStore(viper.GetString(envVarAttachmentDir)),
+		MaxSize:   viper.GetInt64(envVarAttachmentMaxSize),
+		URLExpiry: viper.GetDuration(envVarAttachmentURLExpiry),
+	}
+
+	if bucket := viper.GetString(envVarAttachmentS3Bucket); bucket != "" {
+		var s3Endpoint *string
+		if s3EndpointVal := viper.GetString(envVarAttachmentS3Endpoint); s3EndpointVal != "" {
+			s3Endpoint = &s3EndpointVal
+		}
+
+		store, err := blob.NewS3Store(blob.S3Config{
+			Bucket:    bucket,
+			Directory: viper.GetString(envVarAttachmentS3Directory),
+			Endpoint:  s3Endpoint,
+			Region:    requireENV(envVarAttachmentS3Region),
+		})
+		if err != nil {
+			logger.Fatal("Unable to instantiate S3 attachment store.", zap.Error(err))
+		}
+		config.Store = store
+	}
+
+	return config
+}
+
+func getScheduler(hdl *handlers.Handler, logger *zap.Logger) *scheduler.Scheduler {
+	reminderInterval := getDuration(logger, envVarReminderInterval, time.Minute)
+	snoozeWakeInterval := getDuration(logger, envVarSnoozeWakeInterval, time.Minute)
+	trashRetention := getDuration(logger, envVarTrashRetention, 30*24*time.Hour)
+	trashPurgeInterval := getDuration(logger, envVarTrashPurgeInterval, time.Hour)
+
+	sched := scheduler.New(scheduler.Config{Logger: logger})
+	jobs := []scheduler.Job{
+		{
+			Name:     "reminders",
+			Interval: reminderInterval,
+			Run: func(ctx context.Context, now time.Time) error {
+				sent, err := hdl.Service().SendDueReminders(now)
+				if sent > 0 {
+					logger.Info("Reminders sent.", zap.Int("sent", sent))
+				}
+				return err
+			},
+		},
+		{
+			Name:     "snooze wake",
+			Interval: snoozeWakeInterval,
+			Run: func(ctx context.Context, now time.Time) error {
+				woken, err := hdl.Service().WakeSnoozedTasks(now)
+				if woken > 0 {
+					logger.Info("Snoozed tasks woken.", zap.Int("woken", woken))
+				}
+				return err
+			},
+		},
+		{
+			Name:     "trash purge",
+			Interval: trashPurgeInterval,
+			Run: func(ctx context.Context, now time.Time) error {
+				purged, err := hdl.Service().PurgeTrash(now.Add(-trashRetention))
+				if purged > 0 {
+					logger.Info("Trash purged.", zap.Int("purged", purged))
+				}
+				return err
+			},
+		},
+	}
+	for _, job := range jobs {
+		if err := sched.Add(job); err != nil {
+			logger.Fatal("Unable to schedule job.", zap.Error(err))
+		}
+	}
+
+	return sched
+}
+
+// getDuration reads a positive duration from the environment, falling back to a default when the
+// variable is missing, not a duration or not positive.
+func getDuration(logger *zap.Logger, key string, fallback time.Duration) time.Duration {
+	value := viper.GetString(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logger.Warn("Invalid duration, using the default.", zap.String("key", key), zap.String("value", value), zap.Duration("default", fallback))
+		return fallback
+	}
+	return duration
+}
+
 func getLogger() *zap.Logger {
 	logger, err := zap.NewProduction()
 	if err != nil {
@@ -2,17 +2,228 @@
       username varchar NOT NULL,
       "name" varchar NOT NULL,
       id uuid NOT NULL,
+      deleted_at timestamptz NULL,
+      -- bumped on every update, the entity tag of the user
+      version integer NOT NULL DEFAULT 1,
       CONSTRAINT user_pk PRIMARY KEY (id)
     );
 
+    CREATE INDEX user_deleted_at_idx ON public."user" (deleted_at) WHERE deleted_at IS NOT NULL;
+
     CREATE TABLE public.task (
       description varchar NOT NULL,
       state varchar NOT NULL,
       id uuid NOT NULL,
-      user_id uuid NOT NULL
+      user_id uuid NOT NULL,
+      due_at timestamptz NULL,
+      remind_at timestamptz NULL,
+      reminded_at timestamptz NULL,
+      completed_at timestamptz NULL,
+      parent_id uuid NULL,
+      recurrence varchar NOT NULL DEFAULT '',
+      project_id uuid NULL,
+      archived_at timestamptz NULL,
+      deleted_at timestamptz NULL,
+      -- the task is hidden from the lists until this time
+      snoozed_until timestamptz NULL,
+      created_at timestamptz NOT NULL DEFAULT now(),
+      -- bumped on every change to the task but its position, the entity tag of the task
+      version integer NOT NULL DEFAULT 1,
+      -- rank key of the task in the custom order of its user, compared byte by byte
+      position varchar COLLATE "C" NOT NULL DEFAULT '',
+      search_vector tsvector GENERATED ALWAYS AS (to_tsvector('english', description)) STORED,
+      CONSTRAINT task_pk PRIMARY KEY (id),
+      CONSTRAINT task_state_check CHECK (state IN ('to_do', 'in_progress', 'blocked', 'done', 'cancelled'))
+    );
+
+    -- pending reminders are polled by the reminder scheduler
+    CREATE INDEX task_remind_at_idx ON public.task (remind_at) WHERE reminded_at IS NULL;
+    CREATE INDEX task_parent_id_idx ON public.task (parent_id);
+    CREATE INDEX task_project_id_idx ON public.task (project_id);
+    CREATE INDEX task_user_id_position_idx ON public.task (user_id, position);
+    -- full-text search over the descriptions
+    CREATE INDEX task_search_vector_idx ON public.task USING GIN (search_vector);
+    -- the trash is listed and purged by deletion time
+    CREATE INDEX task_deleted_at_idx ON public.task (deleted_at) WHERE deleted_at IS NOT NULL;
+    -- snoozed tasks are polled by the wake scheduler
+    CREATE INDEX task_snoozed_until_idx ON public.task (snoozed_until) WHERE snoozed_until IS NOT NULL;
+
+    CREATE TABLE public.project (
+      id uuid NOT NULL,
+      user_id uuid NOT NULL,
+      "name" varchar NOT NULL,
+      CONSTRAINT project_pk PRIMARY KEY (id)
+    );
+
+    CREATE INDEX project_user_id_idx ON public.project (user_id);
+
+    CREATE TABLE public.task_dependency (
+      task_id uuid NOT NULL,
+      depends_on_id uuid NOT NULL,
+      CONSTRAINT task_dependency_pk PRIMARY KEY (task_id, depends_on_id),
+      CONSTRAINT task_dependency_self_check CHECK (task_id <> depends_on_id)
+    );
+
+    CREATE INDEX task_dependency_depends_on_id_idx ON public.task_dependency (depends_on_id);
+
+    CREATE TABLE public.label (
+      id uuid NOT NULL,
+      user_id uuid NOT NULL,
+      "name" varchar NOT NULL,
+      CONSTRAINT label_pk PRIMARY KEY (id),
+      CONSTRAINT label_user_name_key UNIQUE (user_id, "name")
+    );
+
+    CREATE TABLE public.task_label (
+      task_id uuid NOT NULL,
+      label_id uuid NOT NULL,
+      CONSTRAINT task_label_pk PRIMARY KEY (task_id, label_id)
+    );
+
+    CREATE INDEX task_label_label_id_idx ON public.task_label (label_id);
+
+    CREATE TABLE public."comment" (
+      id uuid NOT NULL,
+      task_id uuid NOT NULL,
+      author_id uuid NOT NULL,
+      body varchar NOT NULL,
+      reply_to uuid NULL,
+      created_at timestamptz NOT NULL,
+      updated_at timestamptz NOT NULL,
+      CONSTRAINT comment_pk PRIMARY KEY (id)
+    );
+
+    CREATE INDEX comment_task_id_idx ON public."comment" (task_id, created_at);
+
+    CREATE TABLE public.attachment (
+      id uuid NOT NULL,
+      task_id uuid NOT NULL,
+      "name" varchar NOT NULL,
+      content_type varchar NOT NULL,
+      "size" int8 NOT NULL,
+      checksum varchar NOT NULL,
+      created_at timestamptz NOT NULL,
+      CONSTRAINT attachment_pk PRIMARY KEY (id)
+    );
+
+    CREATE INDEX attachment_task_id_idx ON public.attachment (task_id);
+
+    CREATE TABLE public.task_revision (
+      id uuid NOT NULL,
+      task_id uuid NOT NULL,
+      revision int4 NOT NULL,
+      actor_id uuid NULL,
+      created_at timestamptz NOT NULL,
+      changes jsonb NOT NULL,
+      CONSTRAINT task_revision_pk PRIMARY KEY (id),
+      CONSTRAINT task_revision_un UNIQUE (task_id, revision)
+    );
+
+    CREATE TABLE public.time_entry (
+      id uuid NOT NULL,
+      task_id uuid NOT NULL,
+      user_id uuid NOT NULL,
+      started_at timestamptz NOT NULL,
+      stopped_at timestamptz NULL,
+      CONSTRAINT time_entry_pk PRIMARY KEY (id),
+      CONSTRAINT time_entry_stopped_at_check CHECK (stopped_at >= started_at)
+    );
+
+    -- a user has at most one running timer
+    CREATE UNIQUE INDEX time_entry_running_idx ON public.time_entry (user_id) WHERE stopped_at IS NULL;
+    CREATE INDEX time_entry_user_id_started_at_idx ON public.time_entry (user_id, started_at);
+
+    CREATE TABLE public.checklist_item (
+      id uuid NOT NULL,
+      task_id uuid NOT NULL,
+      "text" varchar NOT NULL,
+      checked bool NOT NULL DEFAULT false,
+      -- rank key ordering the items of a task, compared byte by byte
+      position varchar COLLATE "C" NOT NULL,
+      CONSTRAINT checklist_item_pk PRIMARY KEY (id)
+    );
+
+    CREATE INDEX checklist_item_task_id_position_idx ON public.checklist_item (task_id, position);
+
+    CREATE TABLE public.template (
+      id uuid NOT NULL,
+      -- the owner of the template, the only user who sees and instantiates it
+      user_id uuid NOT NULL,
+      "name" varchar NOT NULL,
+      -- the tasks of the template, never queried on their own
+      tasks jsonb NOT NULL,
+      CONSTRAINT template_pk PRIMARY KEY (id)
+    );
+    CREATE INDEX template_user_id_idx ON public.template (user_id);
+
+    CREATE TABLE public.wip_limit (
+      user_id uuid NOT NULL,
+      state varchar NOT NULL,
+      max_tasks int4 NOT NULL,
+      CONSTRAINT wip_limit_pk PRIMARY KEY (user_id, state),
+      CONSTRAINT wip_limit_max_tasks_check CHECK (max_tasks > 0)
+    );
+
+    CREATE TABLE public.feed_token (
+      user_id uuid NOT NULL,
+      token_hash varchar NOT NULL,
+      created_at timestamptz NOT NULL,
+      CONSTRAINT feed_token_pk PRIMARY KEY (user_id)
     );
 
 
     -- public.task foreign keys
 
-    ALTER TABLE public.task ADD CONSTRAINT task_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
\ No newline at end of file
+    ALTER TABLE public.task ADD CONSTRAINT task_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+    ALTER TABLE public.task ADD CONSTRAINT task_parent_fk FOREIGN KEY (parent_id) REFERENCES public.task(id) ON DELETE NO ACTION ON UPDATE NO ACTION;
+    ALTER TABLE public.task ADD CONSTRAINT task_project_fk FOREIGN KEY (project_id) REFERENCES public.project(id) ON DELETE SET NULL ON UPDATE RESTRICT;
+
+    -- public.project foreign keys
+
+    ALTER TABLE public.project ADD CONSTRAINT project_user_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.task_dependency foreign keys
+
+    ALTER TABLE public.task_dependency ADD CONSTRAINT task_dependency_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+    ALTER TABLE public.task_dependency ADD CONSTRAINT task_dependency_depends_on_fk FOREIGN KEY (depends_on_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.label foreign keys
+
+    ALTER TABLE public.label ADD CONSTRAINT label_user_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.task_label foreign keys
+
+    ALTER TABLE public.task_label ADD CONSTRAINT task_label_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+    ALTER TABLE public.task_label ADD CONSTRAINT task_label_label_fk FOREIGN KEY (label_id) REFERENCES public.label(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public."comment" foreign keys
+
+    ALTER TABLE public."comment" ADD CONSTRAINT comment_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+    ALTER TABLE public."comment" ADD CONSTRAINT comment_author_fk FOREIGN KEY (author_id) REFERENCES public."user"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+    ALTER TABLE public."comment" ADD CONSTRAINT comment_reply_to_fk FOREIGN KEY (reply_to) REFERENCES public."comment"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.attachment foreign keys
+
+    ALTER TABLE public.attachment ADD CONSTRAINT attachment_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.task_revision foreign keys
+
+    ALTER TABLE public.task_revision ADD CONSTRAINT task_revision_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+    ALTER TABLE public.task_revision ADD CONSTRAINT task_revision_actor_fk FOREIGN KEY (actor_id) REFERENCES public."user"(id) ON DELETE SET NULL ON UPDATE RESTRICT;
+
+    -- public.time_entry foreign keys
+
+    ALTER TABLE public.time_entry ADD CONSTRAINT time_entry_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+    ALTER TABLE public.time_entry ADD CONSTRAINT time_entry_user_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.checklist_item foreign keys
+
+    ALTER TABLE public.checklist_item ADD CONSTRAINT checklist_item_task_fk FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.wip_limit foreign keys
+
+    ALTER TABLE public.wip_limit ADD CONSTRAINT wip_limit_user_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
+
+    -- public.feed_token foreign keys
+
+    ALTER TABLE public.feed_token ADD CONSTRAINT feed_token_user_fk FOREIGN KEY (user_id) REFERENCES public."user"(id) ON DELETE CASCADE ON UPDATE RESTRICT;
\ No newline at end of file
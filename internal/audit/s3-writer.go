@@ -52,15 +52,29 @@ func NewS3Writer(config S3Config) (EventWriter, error) {
 		config.Directory = config.Directory + "/"
 	}
 
+	s3client, err := NewS3Client(config.Endpoint, config.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Writer{
+		config:   config,
+		s3Client: s3client,
+	}, nil
+}
+
+// NewS3Client creates an S3 client for the given region, talking to endpoint instead of AWS when it is not nil.
+// It is shared by every component of the application storing data in S3.
+func NewS3Client(endpoint *string, region string) (*s3.S3, error) {
 	// Force S3 path style when AWS endpoint is overridden as providers like
 	// localstack (e.g. for local development) may not support the default
 	// host prefix pattern.
-	s3ForcePathStyle := config.Endpoint != nil
+	s3ForcePathStyle := endpoint != nil
 
 	awsConfig := aws.Config{
 		S3ForcePathStyle: aws.Bool(s3ForcePathStyle),
-		Endpoint:         config.Endpoint,
-		Region:           aws.String(config.Region),
+		Endpoint:         endpoint,
+		Region:           aws.String(region),
 	}
 
 	// Initiate new aws session. Based on aws docs, a session should be cached and
@@ -70,13 +84,7 @@ func NewS3Writer(config S3Config) (EventWriter, error) {
 		return nil, err
 	}
 
-	// Create new S3 Client and store it on s3Writer
-	s3client := s3.New(session)
-
-	return &s3Writer{
-		config:   config,
-		s3Client: s3client,
-	}, nil
+	return s3.New(session), nil
 }
 
 func (w *s3Writer) uploadToS3(ctx context.Context, identifier string, buf *bytes.Buffer, timestamp time.Time) (map[string]interface{}, error) {
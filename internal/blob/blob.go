@@ -0,0 +1,35 @@
+// Package blob provides an interface for storing the content of files, such as task attachments.
+// The service layer is given a Store at creation to control where the content goes.
+// Two Stores are provided:
+//
+//	fileStore keeps the content in a directory of the local filesystem.
+//	s3Store keeps the content in an S3 bucket and can hand out presigned download URLs.
+//
+// A custom Store can be provided by the caller if desired.
+package blob
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when no content is stored under the requested key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store controls where the content of files is kept. Keys are slash separated paths.
+type Store interface {
+	// Put stores the content read from r under key, replacing any previous content.
+	Put(key string, r io.Reader, contentType string) error
+	// Get opens the content stored under key. The caller must close it.
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. Deleting a missing key is not an error.
+	Delete(key string) error
+}
+
+// Presigner is implemented by the stores able to hand out temporary URLs giving direct
+// access to their content, so downloads do not have to go through the API.
+type Presigner interface {
+	// PresignGet returns a URL allowing anyone to download the content stored under key until expiry.
+	PresignGet(key string, expiry time.Duration) (string, error)
+}
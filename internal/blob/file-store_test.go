@@ -0,0 +1,46 @@
+package blob
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	err := store.Put("tasks/0001/0002", strings.NewReader("content"), "text/plain")
+	assert.NoError(t, err)
+
+	// replacing the content
+	err = store.Put("tasks/0001/0002", strings.NewReader("new content"), "text/plain")
+	assert.NoError(t, err)
+
+	r, err := store.Get("tasks/0001/0002")
+	assert.NoError(t, err)
+	content, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, "new content", string(content))
+
+	assert.NoError(t, store.Delete("tasks/0001/0002"))
+	assert.NoError(t, store.Delete("tasks/0001/0002"))
+
+	_, err = store.Get("tasks/0001/0002")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileStoreInvalidKeys(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	for _, key := range []string{"", ".", "../outside", "tasks/../../outside"} {
+		err := store.Put(key, strings.NewReader("content"), "text/plain")
+		assert.Error(t, err, key)
+
+		_, err = store.Get(key)
+		assert.Error(t, err, key)
+		assert.NotErrorIs(t, err, ErrNotFound, key)
+	}
+}
@@ -0,0 +1,99 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/audit"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+type s3Store struct {
+	config   S3Config
+	s3Client *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// S3Config contains configuration details for creating an S3 store.
+type S3Config struct {
+	// An optional endpoint URL overriding the default AWS endpoint, such as a localstack one.
+	// Note: You must still provide a `Region` value when specifying an endpoint.
+	Endpoint  *string
+	Region    string
+	Bucket    string
+	Directory string
+}
+
+// NewS3Store provides a Store keeping the content in an S3 bucket, under Directory when it is set.
+func NewS3Store(config S3Config) (Store, error) {
+	if config.Region == "" {
+		return nil, fmt.Errorf("missing required config value: %s", "Region")
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("missing required config value: %s", "Bucket")
+	}
+	if len(config.Directory) > 0 && !strings.HasSuffix(config.Directory, "/") {
+		config.Directory = config.Directory + "/"
+	}
+
+	s3Client, err := audit.NewS3Client(config.Endpoint, config.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Store{
+		config:   config,
+		s3Client: s3Client,
+		// the uploader streams the content in parts, so its size does not have to be known beforehand
+		uploader: s3manager.NewUploaderWithClient(s3Client),
+	}, nil
+}
+
+func (s *s3Store) Put(key string, r io.Reader, contentType string) error {
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket:               aws.String(s.config.Bucket),
+		Key:                  aws.String(s.config.Directory + key),
+		Body:                 r,
+		ContentType:          aws.String(contentType),
+		ServerSideEncryption: aws.String("AES256"),
+	})
+	return err
+}
+
+func (s *s3Store) Get(key string) (io.ReadCloser, error) {
+	out, err := s.s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.config.Directory + key),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return out.Body, nil
+}
+
+func (s *s3Store) Delete(key string) error {
+	_, err := s.s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.config.Directory + key),
+	})
+	return err
+}
+
+func (s *s3Store) PresignGet(key string, expiry time.Duration) (string, error) {
+	req, _ := s.s3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.config.Bucket),
+		Key:    aws.String(s.config.Directory + key),
+	})
+	return req.Presign(expiry)
+}
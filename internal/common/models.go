@@ -1,9 +1,43 @@
 package common
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/filter"
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type TaskState string
 
+const (
+	TaskStateToDo       = TaskState("to_do")
+	TaskStateInProgress = TaskState("in_progress")
+	TaskStateBlocked    = TaskState("blocked")
+	TaskStateDone       = TaskState("done")
+	TaskStateCancelled  = TaskState("cancelled")
+)
+
+// TaskStates lists every state a task can be in.
+var TaskStates = []TaskState{
+	TaskStateToDo,
+	TaskStateInProgress,
+	TaskStateBlocked,
+	TaskStateDone,
+	TaskStateCancelled,
+}
+
+// Valid reports whether s is one of the known task states.
+func (s TaskState) Valid() bool {
+	for _, state := range TaskStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
 type tokenType string
 
 const (
@@ -21,14 +55,405 @@ type User struct {
 	Id       string `json:"id"`
 	Username string `json:"username"`
 	Name     string `json:"name"`
+	// DeletedAt is set on the users listed in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version is bumped on every update of the user. An update carrying a version only applies to
+	// that version.
+	Version int `json:"version,omitempty"`
 }
 
 type Task struct {
-	Id          string    `json:"id"`
-	UserId      string    `json:"user_id"`
-	Description string    `json:"description"`
-	State       TaskState `json:"state"`
-	User        *User     `json:"user,omitempty"`
+	Id          string     `json:"id"`
+	UserId      string     `json:"user_id"`
+	Description string     `json:"description"`
+	State       TaskState  `json:"state"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	RemindAt    *time.Time `json:"remind_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ParentId    *string    `json:"parent_id,omitempty"`
+	ProjectId   *string    `json:"project_id,omitempty"`
+	// ArchivedAt is set when the project holding the task is deleted with its tasks archived.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	// DeletedAt is set on the tasks listed in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// CreatedAt is set by the service, when the task is added.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// SnoozedUntil hides the task from the lists until that time, when the task wakes up. It is
+	// set through the snooze routes, not task updates.
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+	// Version goes up by one with every change to the task: an update, a snooze or a wake-up, or a
+	// new parent or project. Moving the task, which only changes its position, keeps the version.
+	// An update carrying a version only applies to that version.
+	Version int `json:"version,omitempty"`
+	// Position is the rank key of the task in the custom order of its user's tasks. It is set
+	// by the service, when the task is added or moved.
+	Position string `json:"position,omitempty"`
+	// Recurrence is an RFC 5545 RRULE, such as "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR".
+	// Completing a recurring task creates its next occurrence.
+	Recurrence string `json:"recurrence,omitempty"`
+	// Labels are the names of the labels attached to the task. On updates, a nil slice keeps
+	// the current labels and an empty one removes them all.
+	Labels   []string `json:"labels,omitempty"`
+	User     *User    `json:"user,omitempty"`
+	Subtasks []Task   `json:"subtasks,omitempty"`
+	// Progress is the percentage of done direct subtasks, ignoring cancelled ones.
+	Progress *int `json:"progress,omitempty"`
+	// Checklist holds the checklist items of the task, in order, and ChecklistProgress how many of
+	// them are checked. Both are managed through the checklist routes, not task updates.
+	Checklist         []ChecklistItem    `json:"checklist,omitempty"`
+	ChecklistProgress *ChecklistProgress `json:"checklist_progress,omitempty"`
+}
+
+// TaskDependency states that a task cannot be done before the task it depends on.
+type TaskDependency struct {
+	TaskId      string `json:"task_id"`
+	DependsOnId string `json:"depends_on_id"`
+}
+
+// Project is a list grouping the tasks of a user, such as work, home or errands.
+type Project struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// ProjectDeletion tells what happens to the tasks of a project being deleted.
+type ProjectDeletion struct {
+	// Archive archives the tasks. Otherwise they are moved to the MoveTo project,
+	// or out of any project when MoveTo is nil.
+	Archive bool
+	MoveTo  *string
+}
+
+// Label is a free-form tag a user attaches to their tasks.
+type Label struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// Comment is a message left on a task. A comment replying to another one keeps its id in
+// ReplyTo; replies cannot be replied to, so threads are one level deep.
+type Comment struct {
+	Id        string    `json:"id"`
+	TaskId    string    `json:"task_id"`
+	AuthorId  string    `json:"author_id"`
+	Body      string    `json:"body"`
+	ReplyTo   *string   `json:"reply_to,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Attachment describes a file uploaded to a task. The content itself is kept in a blob store.
+type Attachment struct {
+	Id          string `json:"id"`
+	TaskId      string `json:"task_id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Checksum is the hex encoded SHA-256 of the content.
+	Checksum  string    `json:"checksum"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AttachmentDownload gives access to the content of an attachment, either through URL, a
+// temporary link to the blob store, or through Content, which the caller must close.
+type AttachmentDownload struct {
+	Attachment *Attachment
+	URL        string
+	Content    io.ReadCloser
+}
+
+// TaskRevision records a change made to a task: who made it, when and, for each changed
+// field, its old and new JSON values. Revisions are numbered from 1 for each task.
+type TaskRevision struct {
+	Id        string        `json:"id"`
+	TaskId    string        `json:"task_id"`
+	Revision  int           `json:"revision"`
+	ActorId   string        `json:"actor_id,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// FieldChange is the change of a single field of a task, named after its JSON name.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// Snooze tells how long a task is snoozed for: until a time or for a duration, such as "2h30m",
+// from now. Exactly one of them is set.
+type Snooze struct {
+	Until    *time.Time `json:"until,omitempty"`
+	Duration string     `json:"duration,omitempty"`
+}
+
+// Move places an item of an ordered list, such as the tasks of a user or the items of a checklist,
+// right before or right after another item of the same list.
+type Move struct {
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Position is the rank key of an item of an ordered list.
+type Position struct {
+	Id       string
+	Position string
+}
+
+// ChecklistItem is a line of the checklist of a task, ordered by Position.
+type ChecklistItem struct {
+	Id       string `json:"id"`
+	TaskId   string `json:"task_id"`
+	Text     string `json:"text"`
+	Checked  bool   `json:"checked"`
+	Position string `json:"position"`
+}
+
+// ChecklistProgress counts the checked items of a checklist, as in "3/5 done".
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// TimeEntry is a span of time a user spent on a task. StoppedAt is nil while the timer runs.
+type TimeEntry struct {
+	Id        string     `json:"id"`
+	TaskId    string     `json:"task_id"`
+	UserId    string     `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+}
+
+// Timesheet sums up the time a user logged from From, included, to To, excluded, per task and per
+// UTC day. A running timer counts up to the time the timesheet is made. Durations are in seconds.
+type Timesheet struct {
+	UserId       string     `json:"user_id"`
+	From         time.Time  `json:"from"`
+	To           time.Time  `json:"to"`
+	TotalSeconds int64      `json:"total_seconds"`
+	Tasks        []TaskTime `json:"tasks"`
+	Days         []DayTime  `json:"days"`
+}
+
+// TaskTime is the time logged on a task within a timesheet.
+type TaskTime struct {
+	TaskId  string `json:"task_id"`
+	Seconds int64  `json:"seconds"`
+}
+
+// DayTime is the time logged on a day, formatted as 2006-01-02, within a timesheet.
+type DayTime struct {
+	Date    string `json:"date"`
+	Seconds int64  `json:"seconds"`
+}
+
+// Trash holds the deleted tasks and users that have not been purged yet.
+type Trash struct {
+	Tasks []Task `json:"tasks"`
+	Users []User `json:"users"`
+}
+
+type BatchMode string
+
+const (
+	// BatchModeAtomic applies all the operations of a batch or none of them.
+	BatchModeAtomic = BatchMode("atomic")
+	// BatchModeBestEffort applies the operations that succeed and leaves out the failing ones.
+	BatchModeBestEffort = BatchMode("best_effort")
+)
+
+type BatchOp string
+
+const (
+	BatchOpCreate = BatchOp("create")
+	BatchOpUpdate = BatchOp("update")
+	BatchOpDelete = BatchOp("delete")
+)
+
+// TaskBatch is a list of task operations run in a single transaction, as told by Mode,
+// which defaults to BatchModeAtomic.
+type TaskBatch struct {
+	Mode       BatchMode        `json:"mode,omitempty"`
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation creates Task, updates the task Id with Task, ignoring the work-in-progress
+// limits when Force is set, or deletes the task Id along with its subtasks when Cascade is set.
+type BatchOperation struct {
+	Op      BatchOp `json:"op"`
+	Id      string  `json:"id,omitempty"`
+	Task    *Task   `json:"task,omitempty"`
+	Force   bool    `json:"force,omitempty"`
+	Cascade bool    `json:"cascade,omitempty"`
+}
+
+// BatchResult is the outcome of an operation of a batch: the task it created or updated, or the
+// error it failed with.
+type BatchResult struct {
+	Task *Task
+	Err  error
+}
+
+// Board shows the tasks of a user as a kanban board: a column per task state, in the order of
+// TaskStates, each holding its tasks in their custom order.
+type Board struct {
+	UserId  string        `json:"user_id"`
+	Columns []BoardColumn `json:"columns"`
+}
+
+// BoardColumn is the column of a board holding the tasks in State. Limit is its work-in-progress
+// limit, nil when it has none.
+type BoardColumn struct {
+	State TaskState `json:"state"`
+	Count int       `json:"count"`
+	Limit *int      `json:"limit,omitempty"`
+	Tasks []Task    `json:"tasks"`
+}
+
+// WIPLimits are the work-in-progress limits of a user: the most tasks the user may have in a state.
+// Snoozed tasks, hidden from the board, do not count. States without a limit are left out.
+type WIPLimits map[TaskState]int
+
+// Template is a named set of tasks, such as an onboarding checklist or a release procedure, that
+// its user can instantiate as real tasks.
+type Template struct {
+	Id     string         `json:"id"`
+	UserId string         `json:"user_id"`
+	Name   string         `json:"name"`
+	Tasks  []TemplateTask `json:"tasks"`
+}
+
+// TemplateTask is a task of a template. DueOffset, a duration such as "48h", sets the due time of
+// the instantiated task relative to the start of the instantiation; without it the task has no
+// due time. Checklist holds the text of the checklist items, in order.
+type TemplateTask struct {
+	Description string   `json:"description"`
+	DueOffset   string   `json:"due_offset,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Checklist   []string `json:"checklist,omitempty"`
+}
+
+// TemplateInstantiation tells from when the due offsets of the tasks of a template count. Start
+// defaults to the time of the instantiation. The tasks are always created for the user of the
+// template.
+type TemplateInstantiation struct {
+	Start *time.Time `json:"start,omitempty"`
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV     = ImportFormat("csv")
+	ImportFormatJSON    = ImportFormat("json")
+	ImportFormatTodoTxt = ImportFormat("todotxt")
+)
+
+// TaskImport tells how a file of tasks is imported for a user. Mapping maps the task fields read
+// from a CSV file to the columns holding them; a field without a mapping is read from the column
+// named after it, if any. With DryRun set, the tasks are validated but not created.
+type TaskImport struct {
+	UserId  string
+	Format  ImportFormat
+	Mapping map[string]string
+	DryRun  bool
+}
+
+// ImportResult is the outcome of an import: the tasks created, or that would have been created
+// in a dry run, and the errors of the rows left out.
+type ImportResult struct {
+	DryRun bool          `json:"dry_run"`
+	Tasks  []Task        `json:"tasks"`
+	Errors []ImportError `json:"errors"`
+}
+
+// ImportError is why the row starting at Line of an imported file was left out.
+type ImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ExportedTask is a task as exported for its user, along with its comments and its revisions.
+type ExportedTask struct {
+	Task
+	Comments  []Comment      `json:"comments,omitempty"`
+	Revisions []TaskRevision `json:"revisions,omitempty"`
+}
+
+// FeedToken grants read access to the calendar feed of a user's tasks to calendar apps, which
+// cannot send an access token. Token is only shown when it is created: just its hash is stored.
+type FeedToken struct {
+	UserId    string    `json:"user_id"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LabelMatch string
+
+const (
+	// LabelMatchAny keeps the tasks having at least one of the requested labels.
+	LabelMatchAny = LabelMatch("any")
+	// LabelMatchAll keeps the tasks having every requested label.
+	LabelMatchAll = LabelMatch("all")
+)
+
+// TaskFilter narrows down the tasks returned by the list endpoints.
+type TaskFilter struct {
+	// Ready keeps only the open tasks whose dependencies are all done or cancelled.
+	Ready bool
+	// Labels keeps only the tasks carrying the given label names, as told by LabelMatch,
+	// which defaults to LabelMatchAny.
+	Labels     []string
+	LabelMatch LabelMatch
+	// ProjectId keeps only the tasks of the given project.
+	ProjectId string
+	// IncludeArchived also returns the archived tasks, which are left out by default.
+	IncludeArchived bool
+	// IncludeSnoozed also returns the snoozed tasks, which are left out by default.
+	IncludeSnoozed bool
+	// Expr keeps only the tasks matching a filter expression, such as
+	// state in (to_do, in_progress) and created_at > 2026-01-01.
+	Expr filter.Expr
+}
+
+// PageRequest asks for a page of a list of at most Limit items, following the last item of the
+// page Cursor was returned with. A zero Limit asks for the whole list and an empty Cursor for the
+// first page.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// TaskPage is a page of a task list. NextCursor asks for the next page, and is empty on the last one.
+type TaskPage struct {
+	Items      []Task `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// UserPage is a page of a user list. NextCursor asks for the next page, and is empty on the last one.
+type UserPage struct {
+	Items      []User `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TaskSearch is a full-text search over the descriptions of the tasks.
+type TaskSearch struct {
+	// Query uses the web search syntax: quoted phrases, "or" and -excluded words.
+	Query string
+	// UserId and State, when set, keep only the tasks of a user or in a state.
+	UserId string
+	State  TaskState
+	// Limit is the maximum number of results.
+	Limit int
+}
+
+// TaskSearchResult is a task matching a search, with its relevance and the matched
+// fragments of its description highlighted with <b> tags.
+type TaskSearchResult struct {
+	Task
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
 
 type Metadata struct {
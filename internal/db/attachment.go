@@ -0,0 +1,97 @@
+package db
+
+import (
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+const attachmentColumns = `id, task_id, name, content_type, size, checksum, created_at`
+
+func (db *DB) AddAttachment(attachment *common.Attachment) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.attachment(id, task_id, name, content_type, size, checksum, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)
+	`, attachment.Id, attachment.TaskId, attachment.Name, attachment.ContentType, attachment.Size, attachment.Checksum, attachment.CreatedAt)
+	if err != nil {
+		db.logger.Error("Error inserting attachment.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) GetAttachment(id string) (*common.Attachment, error) {
+	results, err := db.conn().Query(`
+		SELECT `+attachmentColumns+`
+		FROM public.attachment
+		WHERE id = $1`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving attachment.")
+		return nil, err
+	}
+	defer results.Close()
+
+	attachment := common.Attachment{}
+	for results.Next() {
+		err = results.Scan(
+			&attachment.Id,
+			&attachment.TaskId,
+			&attachment.Name,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.CreatedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &attachment, nil
+}
+
+func (db *DB) DeleteAttachment(id string) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public.attachment WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error deleting attachment.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTaskAttachments returns the attachments of a task, oldest first.
+func (db *DB) ListTaskAttachments(taskId string) ([]common.Attachment, error) {
+	results, err := db.conn().Query(`
+		SELECT `+attachmentColumns+`
+		FROM public.attachment
+		WHERE task_id = $1
+		ORDER BY created_at, id`, taskId)
+	if err != nil {
+		db.logger.Error("Error retrieving task attachments.")
+		return nil, err
+	}
+	defer results.Close()
+
+	attachments := make([]common.Attachment, 0)
+	for results.Next() {
+		attachment := common.Attachment{}
+		err = results.Scan(
+			&attachment.Id,
+			&attachment.TaskId,
+			&attachment.Name,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.CreatedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+var attachmentColumnNames = []string{"id", "task_id", "name", "content_type", "size", "checksum", "created_at"}
+
+func (d *dbTestSuite) TestAddAttachment() {
+	errAddAttachment := errors.New("error inserting attachment")
+	now := time.Now()
+	attachment := &common.Attachment{
+		Id:          "0001",
+		TaskId:      "00001",
+		Name:        "receipt.pdf",
+		ContentType: "application/pdf",
+		Size:        1024,
+		Checksum:    "abcdef",
+		CreatedAt:   now,
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errAddAttachment,
+			expectedResp: errAddAttachment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.attachment").
+				WithArgs(attachment.Id, attachment.TaskId, attachment.Name, attachment.ContentType, attachment.Size, attachment.Checksum, now)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddAttachment(attachment)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetAttachment() {
+	errGetAttachment := errors.New("any error")
+	now := time.Now()
+	attachment := &common.Attachment{
+		Id:          "0001",
+		TaskId:      "00001",
+		Name:        "receipt.pdf",
+		ContentType: "application/pdf",
+		Size:        1024,
+		Checksum:    "abcdef",
+		CreatedAt:   now,
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp *common.Attachment
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(attachmentColumnNames).
+				AddRow(attachment.Id, attachment.TaskId, attachment.Name, attachment.ContentType, attachment.Size, attachment.Checksum, now),
+			expectedResp: attachment,
+		},
+		"not found": {
+			dbRows:       sqlmock.NewRows(attachmentColumnNames),
+			expectedResp: &common.Attachment{},
+		},
+		"fail": {
+			dbError:     errGetAttachment,
+			expectedErr: errGetAttachment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.attachment WHERE id = (.+)").WithArgs(attachment.Id)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetAttachment(attachment.Id)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteAttachment() {
+	errDeleteAttachment := errors.New("error deleting attachment")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errDeleteAttachment,
+			expectedResp: errDeleteAttachment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.attachment").WithArgs("0001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteAttachment("0001")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTaskAttachments() {
+	errListAttachments := errors.New("any error")
+	now := time.Now()
+	attachments := []common.Attachment{
+		{Id: "0001", TaskId: "00001", Name: "receipt.pdf", ContentType: "application/pdf", Size: 1024, Checksum: "abcdef", CreatedAt: now},
+		{Id: "0002", TaskId: "00001", Name: "photo.png", ContentType: "image/png", Size: 2048, Checksum: "fedcba", CreatedAt: now},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []common.Attachment
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(attachmentColumnNames).
+				AddRow(attachments[0].Id, attachments[0].TaskId, attachments[0].Name, attachments[0].ContentType, attachments[0].Size, attachments[0].Checksum, now).
+				AddRow(attachments[1].Id, attachments[1].TaskId, attachments[1].Name, attachments[1].ContentType, attachments[1].Size, attachments[1].Checksum, now),
+			expectedResp: attachments,
+		},
+		"fail": {
+			dbError:     errListAttachments,
+			expectedErr: errListAttachments,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.attachment WHERE task_id = (.+) ORDER BY created_at").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTaskAttachments("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+// ListWIPLimits returns the work-in-progress limits of a user.
+func (db *DB) ListWIPLimits(userId string) (common.WIPLimits, error) {
+	results, err := db.conn().Query(`
+		SELECT state, max_tasks
+		FROM public.wip_limit
+		WHERE user_id = $1`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving wip limits.")
+		return nil, err
+	}
+	defer results.Close()
+
+	limits := make(common.WIPLimits)
+	for results.Next() {
+		var state common.TaskState
+		var limit int
+		if err := results.Scan(&state, &limit); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		limits[state] = limit
+	}
+
+	return limits, nil
+}
+
+// SetWIPLimits replaces the work-in-progress limits of a user.
+func (db *DB) SetWIPLimits(userId string, limits common.WIPLimits) error {
+	states := make([]string, 0, len(limits))
+	maxTasks := make([]int64, 0, len(limits))
+	for _, state := range slices.Sorted(maps.Keys(limits)) {
+		states = append(states, string(state))
+		maxTasks = append(maxTasks, int64(limits[state]))
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM public.wip_limit WHERE user_id = $1
+	`, userId)
+	if err != nil {
+		db.logger.Error("Error deleting wip limits.")
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO public.wip_limit(user_id, state, max_tasks)
+		SELECT $1, unnest($2::varchar[]), unnest($3::int4[])
+	`, userId, pq.Array(states), pq.Array(maxTasks))
+	if err != nil {
+		db.logger.Error("Error inserting wip limits.")
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CountStateTasks counts the tasks of a user in a state, leaving out the archived and deleted ones
+// and, as the board hides them, the snoozed ones.
+func (db *DB) CountStateTasks(userId string, state common.TaskState) (int, error) {
+	var count int
+	err := db.conn().QueryRow(`
+		SELECT COUNT(*)
+		FROM public.task
+		WHERE user_id = $1 AND state = $2 AND archived_at IS NULL AND deleted_at IS NULL
+		AND (snoozed_until IS NULL OR snoozed_until <= now())`, userId, state).Scan(&count)
+	if err != nil {
+		db.logger.Error("Error counting tasks.")
+		return 0, err
+	}
+
+	return count, nil
+}
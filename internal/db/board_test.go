@@ -0,0 +1,118 @@
+package db
+
+import (
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (d *dbTestSuite) TestListWIPLimits() {
+	errListLimits := errors.New("any error")
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp common.WIPLimits
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows([]string{"state", "max_tasks"}).
+				AddRow("in_progress", 3).
+				AddRow("blocked", 1),
+			expectedResp: common.WIPLimits{common.TaskStateInProgress: 3, common.TaskStateBlocked: 1},
+		},
+		"no limits": {
+			dbRows:       sqlmock.NewRows([]string{"state", "max_tasks"}),
+			expectedResp: common.WIPLimits{},
+		},
+		"fail": {
+			dbError:     errListLimits,
+			expectedErr: errListLimits,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT state, max_tasks FROM public.wip_limit WHERE user_id = \\$1").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListWIPLimits("00001")
+			d.Assert().Equal(test.expectedErr, err)
+			d.Assert().Equal(test.expectedResp, resp)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestSetWIPLimits() {
+	errSetLimits := errors.New("error inserting wip limits")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errSetLimits,
+			expectedResp: errSetLimits,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			d.mock.ExpectExec("DELETE FROM public.wip_limit").WithArgs("00001").WillReturnResult(sqlmock.NewResult(0, 1))
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.wip_limit").WithArgs("00001", `{"blocked","in_progress"}`, `{1,3}`)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(0, 2))
+				d.mock.ExpectCommit()
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+				d.mock.ExpectRollback()
+			}
+
+			err := d.db.SetWIPLimits("00001", common.WIPLimits{common.TaskStateInProgress: 3, common.TaskStateBlocked: 1})
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestCountStateTasks() {
+	errCountTasks := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp int
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: 2,
+		},
+		"fail": {
+			dbError:     errCountTasks,
+			expectedErr: errCountTasks,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			// the snoozed tasks the board hides do not count towards the limit either
+			mockCount := d.mock.ExpectQuery("SELECT COUNT(.+) FROM public.task WHERE user_id = \\$1 AND state = \\$2 AND archived_at IS NULL AND deleted_at IS NULL "+
+				"AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\)$").
+				WithArgs("00001", common.TaskStateInProgress)
+			if test.dbError == nil {
+				mockCount.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			} else {
+				mockCount.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.CountStateTasks("00001", common.TaskStateInProgress)
+			d.Assert().Equal(test.expectedErr, err)
+			d.Assert().Equal(test.expectedResp, resp)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+func (db *DB) AddChecklistItem(item *common.ChecklistItem) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.checklist_item(id, task_id, text, checked, position)
+		VALUES($1, $2, $3, $4, $5)
+	`, item.Id, item.TaskId, item.Text, item.Checked, item.Position)
+	if err != nil {
+		db.logger.Error("Error inserting checklist item.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) GetChecklistItem(id string) (*common.ChecklistItem, error) {
+	results, err := db.conn().Query(`
+		SELECT id, task_id, text, checked, position
+		FROM public.checklist_item
+		WHERE id = $1`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving checklist item.")
+		return nil, err
+	}
+	defer results.Close()
+
+	item := common.ChecklistItem{}
+	for results.Next() {
+		err = results.Scan(
+			&item.Id,
+			&item.TaskId,
+			&item.Text,
+			&item.Checked,
+			&item.Position)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &item, nil
+}
+
+// ToggleChecklistItem checks an unchecked item of the checklist of a task, or unchecks a checked
+// one, and returns the item as updated. The flip happens in the statement itself, so concurrent
+// toggles all count. The item is empty when the task has no such item.
+func (db *DB) ToggleChecklistItem(taskId string, id string) (*common.ChecklistItem, error) {
+	results, err := db.conn().Query(`
+		UPDATE public.checklist_item
+		SET checked = NOT checked
+		WHERE id = $1 AND task_id = $2
+		RETURNING id, task_id, text, checked, position`, id, taskId)
+	if err != nil {
+		db.logger.Error("Error updating checklist item.")
+		return nil, err
+	}
+	defer results.Close()
+
+	item := common.ChecklistItem{}
+	for results.Next() {
+		err = results.Scan(
+			&item.Id,
+			&item.TaskId,
+			&item.Text,
+			&item.Checked,
+			&item.Position)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &item, nil
+}
+
+func (db *DB) DeleteChecklistItem(id string) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public.checklist_item WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error deleting checklist item.")
+		return err
+	}
+
+	return nil
+}
+
+// ListChecklistItems returns the checklist items of the given tasks, in order and keyed by task id.
+func (db *DB) ListChecklistItems(taskIds []string) (map[string][]common.ChecklistItem, error) {
+	results, err := db.conn().Query(`
+		SELECT id, task_id, text, checked, position
+		FROM public.checklist_item
+		WHERE task_id = ANY($1::uuid[])
+		ORDER BY position, id`, pq.Array(taskIds))
+	if err != nil {
+		db.logger.Error("Error retrieving checklist items.")
+		return nil, err
+	}
+	defer results.Close()
+
+	items := make(map[string][]common.ChecklistItem)
+	for results.Next() {
+		item := common.ChecklistItem{}
+		err = results.Scan(
+			&item.Id,
+			&item.TaskId,
+			&item.Text,
+			&item.Checked,
+			&item.Position)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		items[item.TaskId] = append(items[item.TaskId], item)
+	}
+
+	return items, nil
+}
+
+// ListChecklistPositions returns the positions of the checklist items of a task, in order.
+func (db *DB) ListChecklistPositions(taskId string) ([]common.Position, error) {
+	results, err := db.conn().Query(`
+		SELECT id, position
+		FROM public.checklist_item
+		WHERE task_id = $1
+		ORDER BY position, id`, taskId)
+	if err != nil {
+		db.logger.Error("Error retrieving checklist positions.")
+		return nil, err
+	}
+	defer results.Close()
+
+	positions := make([]common.Position, 0)
+	for results.Next() {
+		position := common.Position{}
+		if err := results.Scan(&position.Id, &position.Position); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
+
+// LastChecklistPosition returns the position of the last checklist item of a task, empty when the
+// checklist is empty.
+func (db *DB) LastChecklistPosition(taskId string) (string, error) {
+	var position string
+	err := db.conn().QueryRow(`
+		SELECT position
+		FROM public.checklist_item
+		WHERE task_id = $1
+		ORDER BY position DESC
+		LIMIT 1`, taskId).Scan(&position)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		db.logger.Error("Error retrieving last checklist position.")
+		return "", err
+	}
+
+	return position, nil
+}
+
+// SetChecklistPositions updates the positions of a set of checklist items in a single statement.
+func (db *DB) SetChecklistPositions(positions []common.Position) error {
+	ids := make([]string, len(positions))
+	keys := make([]string, len(positions))
+	for i, position := range positions {
+		ids[i] = position.Id
+		keys[i] = position.Position
+	}
+
+	_, err := db.conn().Exec(`
+		UPDATE public.checklist_item c
+		SET position = p.position
+		FROM unnest($1::uuid[], $2::varchar[]) AS p(id, position)
+		WHERE c.id = p.id
+	`, pq.Array(ids), pq.Array(keys))
+	if err != nil {
+		db.logger.Error("Error updating checklist positions.")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package db
+
+import (
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+var checklistItemColumns = []string{"id", "task_id", "text", "checked", "position"}
+
+func (d *dbTestSuite) TestAddChecklistItem() {
+	errAddItem := errors.New("error inserting checklist item")
+	item := &common.ChecklistItem{Id: "1001", TaskId: "0001", Text: "toothbrush", Position: "i"}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errAddItem,
+			expectedResp: errAddItem,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.checklist_item").
+				WithArgs(item.Id, item.TaskId, item.Text, item.Checked, item.Position)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddChecklistItem(item)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetChecklistItem() {
+	errGetItem := errors.New("any error")
+	item := &common.ChecklistItem{Id: "1001", TaskId: "0001", Text: "toothbrush", Checked: true, Position: "i"}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp *common.ChecklistItem
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: item,
+		},
+		"fail": {
+			dbError:     errGetItem,
+			expectedErr: errGetItem,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.checklist_item WHERE id = \\$1").WithArgs(item.Id)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(sqlmock.NewRows(checklistItemColumns).
+					AddRow(item.Id, item.TaskId, item.Text, item.Checked, item.Position))
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetChecklistItem(item.Id)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestToggleChecklistItem() {
+	errUpdateItem := errors.New("error updating checklist item")
+	columns := []string{"id", "task_id", "text", "checked", "position"}
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp *common.ChecklistItem
+		expectedErr  error
+	}{
+		"success": {
+			dbRows:       sqlmock.NewRows(columns).AddRow("1001", "0001", "toothbrush", true, "i"),
+			expectedResp: &common.ChecklistItem{Id: "1001", TaskId: "0001", Text: "toothbrush", Checked: true, Position: "i"},
+		},
+		"not found": {
+			dbRows:       sqlmock.NewRows(columns),
+			expectedResp: &common.ChecklistItem{},
+		},
+		"fail": {
+			dbError:     errUpdateItem,
+			expectedErr: errUpdateItem,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectQuery("UPDATE public.checklist_item SET checked = NOT checked WHERE id = \\$1 AND task_id = \\$2 RETURNING").
+				WithArgs("1001", "0001")
+			if test.dbError == nil {
+				mockUpdate.WillReturnRows(test.dbRows)
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ToggleChecklistItem("0001", "1001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteChecklistItem() {
+	errDeleteItem := errors.New("error deleting checklist item")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errDeleteItem,
+			expectedResp: errDeleteItem,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.checklist_item").WithArgs("1001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteChecklistItem("1001")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListChecklistItems() {
+	errListItems := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp map[string][]common.ChecklistItem
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: map[string][]common.ChecklistItem{
+				"0001": {
+					{Id: "1001", TaskId: "0001", Text: "toothbrush", Checked: true, Position: "a"},
+					{Id: "1003", TaskId: "0001", Text: "socks", Position: "b"},
+				},
+				"0002": {
+					{Id: "1002", TaskId: "0002", Text: "milk", Position: "a"},
+				},
+			},
+		},
+		"fail": {
+			dbError:     errListItems,
+			expectedErr: errListItems,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.checklist_item WHERE task_id = ANY\\(\\$1::uuid\\[\\]\\) ORDER BY position, id$").
+				WithArgs(`{"0001","0002"}`)
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows(checklistItemColumns).
+					AddRow("1001", "0001", "toothbrush", true, "a").
+					AddRow("1002", "0002", "milk", false, "a").
+					AddRow("1003", "0001", "socks", false, "b"))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListChecklistItems([]string{"0001", "0002"})
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListChecklistPositions() {
+	errList := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.Position
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: []common.Position{{Id: "1001", Position: "a"}, {Id: "1002", Position: "b"}},
+		},
+		"fail": {
+			dbError:     errList,
+			expectedErr: errList,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT id, position FROM public.checklist_item WHERE task_id = \\$1 ORDER BY position, id$").WithArgs("0001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).
+					AddRow("1001", "a").
+					AddRow("1002", "b"))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListChecklistPositions("0001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestLastChecklistPosition() {
+	errLast := errors.New("any error")
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp string
+		expectedErr  error
+	}{
+		"success": {
+			dbRows:       sqlmock.NewRows([]string{"position"}).AddRow("b"),
+			expectedResp: "b",
+		},
+		"empty checklist": {
+			dbRows: sqlmock.NewRows([]string{"position"}),
+		},
+		"fail": {
+			dbError:     errLast,
+			expectedErr: errLast,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockLast := d.mock.ExpectQuery("SELECT position FROM public.checklist_item WHERE task_id = \\$1 ORDER BY position DESC LIMIT 1").WithArgs("0001")
+			if test.dbError == nil {
+				mockLast.WillReturnRows(test.dbRows)
+			} else {
+				mockLast.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.LastChecklistPosition("0001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestSetChecklistPositions() {
+	errSet := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError     error
+		expectedErr error
+	}{
+		"success": {},
+		"fail": {
+			dbError:     errSet,
+			expectedErr: errSet,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockSet := d.mock.ExpectExec("UPDATE public.checklist_item c SET position = p.position FROM unnest\\(\\$1::uuid\\[\\], \\$2::varchar\\[\\]\\)").
+				WithArgs(`{"1001","1002"}`, `{"a","b"}`)
+			if test.dbError == nil {
+				mockSet.WillReturnResult(sqlmock.NewResult(0, 2))
+			} else {
+				mockSet.WillReturnError(test.dbError)
+			}
+
+			err := d.db.SetChecklistPositions([]common.Position{{Id: "1001", Position: "a"}, {Id: "1002", Position: "b"}})
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
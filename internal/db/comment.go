@@ -0,0 +1,146 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+const commentColumns = `id, task_id, author_id, body, reply_to, created_at, updated_at`
+
+func (db *DB) AddComment(comment *common.Comment) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public."comment"(id, task_id, author_id, body, reply_to, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)
+	`, comment.Id, comment.TaskId, comment.AuthorId, comment.Body, comment.ReplyTo, comment.CreatedAt, comment.UpdatedAt)
+	if err != nil {
+		db.logger.Error("Error inserting comment.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) UpdateComment(comment *common.Comment) error {
+	_, err := db.conn().Exec(`
+		UPDATE public."comment"
+		SET body = $1, updated_at = $2
+		WHERE id = $3
+	`, comment.Body, comment.UpdatedAt, comment.Id)
+	if err != nil {
+		db.logger.Error("Error updating comment.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) GetComment(id string) (*common.Comment, error) {
+	results, err := db.conn().Query(`
+		SELECT `+commentColumns+`
+		FROM public."comment"
+		WHERE id = $1`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving comment.")
+		return nil, err
+	}
+	defer results.Close()
+
+	comment := common.Comment{}
+	for results.Next() {
+		err = results.Scan(
+			&comment.Id,
+			&comment.TaskId,
+			&comment.AuthorId,
+			&comment.Body,
+			&comment.ReplyTo,
+			&comment.CreatedAt,
+			&comment.UpdatedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment deletes a comment along with its replies.
+func (db *DB) DeleteComment(id string) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public."comment" WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error deleting comment.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTaskComments returns the comments of a task, oldest first.
+func (db *DB) ListTaskComments(taskId string) ([]common.Comment, error) {
+	results, err := db.conn().Query(`
+		SELECT `+commentColumns+`
+		FROM public."comment"
+		WHERE task_id = $1
+		ORDER BY created_at, id`, taskId)
+	if err != nil {
+		db.logger.Error("Error retrieving task comments.")
+		return nil, err
+	}
+	defer results.Close()
+
+	comments := make([]common.Comment, 0)
+	for results.Next() {
+		comment := common.Comment{}
+		if err := scanComment(results, &comment); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// ListCommentsOfTasks returns the comments of the given tasks by task id, oldest first.
+func (db *DB) ListCommentsOfTasks(taskIds []string) (map[string][]common.Comment, error) {
+	results, err := db.conn().Query(`
+		SELECT `+commentColumns+`
+		FROM public."comment"
+		WHERE task_id = ANY($1::uuid[])
+		ORDER BY created_at, id`, pq.Array(taskIds))
+	if err != nil {
+		db.logger.Error("Error retrieving task comments.")
+		return nil, err
+	}
+	defer results.Close()
+
+	comments := make(map[string][]common.Comment)
+	for results.Next() {
+		comment := common.Comment{}
+		if err := scanComment(results, &comment); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		comments[comment.TaskId] = append(comments[comment.TaskId], comment)
+	}
+
+	return comments, nil
+}
+
+// scanComment scans a row selecting commentColumns.
+func scanComment(results *sql.Rows, comment *common.Comment) error {
+	return results.Scan(
+		&comment.Id,
+		&comment.TaskId,
+		&comment.AuthorId,
+		&comment.Body,
+		&comment.ReplyTo,
+		&comment.CreatedAt,
+		&comment.UpdatedAt)
+}
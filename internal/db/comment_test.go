@@ -0,0 +1,264 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+var commentColumnNames = []string{"id", "task_id", "author_id", "body", "reply_to", "created_at", "updated_at"}
+
+func (d *dbTestSuite) TestAddComment() {
+	errAddComment := errors.New("error inserting comment")
+	now := time.Now()
+	replyTo := "0002"
+	comment := &common.Comment{
+		Id:        "0001",
+		TaskId:    "00001",
+		AuthorId:  "000001",
+		Body:      "on it",
+		ReplyTo:   &replyTo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errAddComment,
+			expectedResp: errAddComment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec(`INSERT INTO public."comment"`).
+				WithArgs(comment.Id, comment.TaskId, comment.AuthorId, comment.Body, replyTo, now, now)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddComment(comment)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestUpdateComment() {
+	errUpdateComment := errors.New("error updating comment")
+	now := time.Now()
+	comment := &common.Comment{
+		Id:        "0001",
+		Body:      "done",
+		UpdatedAt: now,
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errUpdateComment,
+			expectedResp: errUpdateComment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec(`UPDATE public."comment"`).WithArgs(comment.Body, now, comment.Id)
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			err := d.db.UpdateComment(comment)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetComment() {
+	errGetComment := errors.New("any error")
+	now := time.Now()
+	comment := &common.Comment{
+		Id:        "0001",
+		TaskId:    "00001",
+		AuthorId:  "000001",
+		Body:      "on it",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp *common.Comment
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(commentColumnNames).
+				AddRow(comment.Id, comment.TaskId, comment.AuthorId, comment.Body, nil, now, now),
+			expectedResp: comment,
+		},
+		"not found": {
+			dbRows:       sqlmock.NewRows(commentColumnNames),
+			expectedResp: &common.Comment{},
+		},
+		"fail": {
+			dbError:     errGetComment,
+			expectedErr: errGetComment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery(`SELECT (.+) FROM public."comment" WHERE id = (.+)`).WithArgs(comment.Id)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetComment(comment.Id)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteComment() {
+	errDeleteComment := errors.New("error deleting comment")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errDeleteComment,
+			expectedResp: errDeleteComment,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec(`DELETE FROM public."comment"`).WithArgs("0001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteComment("0001")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTaskComments() {
+	errListComments := errors.New("any error")
+	now := time.Now()
+	replyTo := "0001"
+	comments := []common.Comment{
+		{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "who takes it?", CreatedAt: now, UpdatedAt: now},
+		{Id: "0002", TaskId: "00001", AuthorId: "000002", Body: "me", ReplyTo: &replyTo, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []common.Comment
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(commentColumnNames).
+				AddRow(comments[0].Id, comments[0].TaskId, comments[0].AuthorId, comments[0].Body, nil, now, now).
+				AddRow(comments[1].Id, comments[1].TaskId, comments[1].AuthorId, comments[1].Body, replyTo, now, now),
+			expectedResp: comments,
+		},
+		"fail": {
+			dbError:     errListComments,
+			expectedErr: errListComments,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery(`SELECT (.+) FROM public."comment" WHERE task_id = (.+) ORDER BY created_at`).WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTaskComments("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListCommentsOfTasks() {
+	errListComments := errors.New("any error")
+	now := time.Now()
+	comments := []common.Comment{
+		{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "who takes it?", CreatedAt: now, UpdatedAt: now},
+		{Id: "0002", TaskId: "00002", AuthorId: "000002", Body: "me", CreatedAt: now, UpdatedAt: now},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp map[string][]common.Comment
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(commentColumnNames).
+				AddRow(comments[0].Id, comments[0].TaskId, comments[0].AuthorId, comments[0].Body, nil, now, now).
+				AddRow(comments[1].Id, comments[1].TaskId, comments[1].AuthorId, comments[1].Body, nil, now, now),
+			expectedResp: map[string][]common.Comment{"00001": comments[:1], "00002": comments[1:]},
+		},
+		"no comments": {
+			dbRows:       sqlmock.NewRows(commentColumnNames),
+			expectedResp: map[string][]common.Comment{},
+		},
+		"fail": {
+			dbError:     errListComments,
+			expectedErr: errListComments,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery(`SELECT (.+) FROM public."comment" WHERE task_id = ANY(.+) ORDER BY created_at, id`).
+				WithArgs(`{"00001","00002"}`)
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListCommentsOfTasks([]string{"00001", "00002"})
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (db *DB) AddTaskDependency(dependency *common.TaskDependency) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.task_dependency(task_id, depends_on_id)
+		VALUES($1, $2)
+		ON CONFLICT DO NOTHING
+	`, dependency.TaskId, dependency.DependsOnId)
+	if err != nil {
+		db.logger.Error("Error inserting task dependency.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) DeleteTaskDependency(dependency *common.TaskDependency) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public.task_dependency WHERE task_id = $1 AND depends_on_id = $2
+	`, dependency.TaskId, dependency.DependsOnId)
+	if err != nil {
+		db.logger.Error("Error deleting task dependency.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTaskDependencies returns the tasks a task directly depends on.
+func (db *DB) ListTaskDependencies(id string) ([]common.Task, error) {
+	results, err := db.conn().Query(`
+		SELECT `+prefixedTaskColumns("t")+`
+		FROM public.task t
+		JOIN public.task_dependency d ON d.depends_on_id = t.id
+		WHERE d.task_id = $1 AND t.deleted_at IS NULL`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving task dependencies.")
+		return nil, err
+	}
+	defer results.Close()
+
+	return db.scanTasks(results)
+}
+
+// ListTransitiveDependencies returns the ids of every task a task depends on, directly or not.
+func (db *DB) ListTransitiveDependencies(id string) ([]string, error) {
+	results, err := db.conn().Query(`
+		WITH RECURSIVE deps AS (
+			SELECT depends_on_id FROM public.task_dependency WHERE task_id = $1
+			UNION
+			SELECT d.depends_on_id FROM public.task_dependency d JOIN deps ON d.task_id = deps.depends_on_id
+		)
+		SELECT depends_on_id FROM deps`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving transitive task dependencies.")
+		return nil, err
+	}
+	defer results.Close()
+
+	ids := make([]string, 0)
+	for results.Next() {
+		var dependencyId string
+		if err := results.Scan(&dependencyId); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		ids = append(ids, dependencyId)
+	}
+
+	return ids, nil
+}
+
+// CountOpenDependencies counts the tasks a task directly depends on that are neither done nor cancelled.
+// Deleted tasks do not count.
+func (db *DB) CountOpenDependencies(id string) (int, error) {
+	var count int
+	err := db.conn().QueryRow(`
+		SELECT COUNT(*)
+		FROM public.task_dependency d
+		JOIN public.task t ON t.id = d.depends_on_id
+		WHERE d.task_id = $1 AND t.deleted_at IS NULL AND t.state NOT IN ($2, $3)`, id, common.TaskStateDone, common.TaskStateCancelled).Scan(&count)
+	if err != nil {
+		db.logger.Error("Error counting open task dependencies.")
+		return 0, err
+	}
+
+	return count, nil
+}
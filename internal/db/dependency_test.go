@@ -0,0 +1,208 @@
+package db
+
+import (
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (d *dbTestSuite) TestAddTaskDependency() {
+	errAddDependency := errors.New("error inserting task dependency")
+	dependency := &common.TaskDependency{
+		TaskId:      "0001",
+		DependsOnId: "0002",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errAddDependency,
+			expectedResp: errAddDependency,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.task_dependency").WithArgs(dependency.TaskId, dependency.DependsOnId)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddTaskDependency(dependency)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteTaskDependency() {
+	errDeleteDependency := errors.New("error deleting task dependency")
+	dependency := &common.TaskDependency{
+		TaskId:      "0001",
+		DependsOnId: "0002",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errDeleteDependency,
+			expectedResp: errDeleteDependency,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.task_dependency").WithArgs(dependency.TaskId, dependency.DependsOnId)
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteTaskDependency(dependency)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTaskDependencies() {
+	errGetTask := errors.New("any error")
+	listTasks := []common.Task{
+		{
+			Id:          "0002",
+			UserId:      "0001",
+			Description: "description 2",
+			State:       "to_do",
+		},
+	}
+	rowTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow(listTasks[0].Id, listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	tests := map[string]struct {
+		dbError      error
+		dbRowTask    *sqlmock.Rows
+		expectedResp []common.Task
+		expectedErr  error
+	}{
+		"success": {
+			dbError:      nil,
+			dbRowTask:    rowTasks,
+			expectedResp: listTasks,
+			expectedErr:  nil,
+		},
+		"fail": {
+			dbError:      errGetTask,
+			dbRowTask:    nil,
+			expectedResp: nil,
+			expectedErr:  errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT t.id, (.+) FROM public.task t JOIN public.task_dependency d").WithArgs("0001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRowTask)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			tasks, err := d.db.ListTaskDependencies("0001")
+			d.Assert().Equal(test.expectedResp, tasks)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTransitiveDependencies() {
+	errGetTask := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []string
+		expectedErr  error
+	}{
+		"success": {
+			dbError:      nil,
+			dbRows:       sqlmock.NewRows([]string{"depends_on_id"}).AddRow("0002").AddRow("0003"),
+			expectedResp: []string{"0002", "0003"},
+			expectedErr:  nil,
+		},
+		"fail": {
+			dbError:      errGetTask,
+			dbRows:       nil,
+			expectedResp: nil,
+			expectedErr:  errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("WITH RECURSIVE deps AS (.+) SELECT depends_on_id FROM deps").WithArgs("0001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			ids, err := d.db.ListTransitiveDependencies("0001")
+			d.Assert().Equal(test.expectedResp, ids)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestCountOpenDependencies() {
+	errGetTask := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp int
+		expectedErr  error
+	}{
+		"success": {
+			dbError:      nil,
+			dbRows:       sqlmock.NewRows([]string{"count"}).AddRow(2),
+			expectedResp: 2,
+			expectedErr:  nil,
+		},
+		"fail": {
+			dbError:      errGetTask,
+			dbRows:       nil,
+			expectedResp: 0,
+			expectedErr:  errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT COUNT(.+) FROM public.task_dependency d").
+				WithArgs("0001", common.TaskStateDone, common.TaskStateCancelled)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			count, err := d.db.CountOpenDependencies("0001")
+			d.Assert().Equal(test.expectedResp, count)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
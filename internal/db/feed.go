@@ -0,0 +1,54 @@
+package db
+
+import "time"
+
+// SetFeedToken stores the hash of the calendar feed token of a user, replacing any earlier one.
+func (db *DB) SetFeedToken(userId string, tokenHash string, createdAt time.Time) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.feed_token(user_id, token_hash, created_at)
+		VALUES($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+	`, userId, tokenHash, createdAt)
+	if err != nil {
+		db.logger.Error("Error inserting feed token.")
+		return err
+	}
+
+	return nil
+}
+
+// GetFeedTokenHash returns the hash of the calendar feed token of a user, empty when the user has none.
+func (db *DB) GetFeedTokenHash(userId string) (string, error) {
+	results, err := db.conn().Query(`
+		SELECT token_hash
+		FROM public.feed_token
+		WHERE user_id = $1`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving feed token.")
+		return "", err
+	}
+	defer results.Close()
+
+	var tokenHash string
+	for results.Next() {
+		if err := results.Scan(&tokenHash); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return "", err
+		}
+	}
+
+	return tokenHash, nil
+}
+
+// DeleteFeedToken revokes the calendar feed token of a user.
+func (db *DB) DeleteFeedToken(userId string) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public.feed_token WHERE user_id = $1
+	`, userId)
+	if err != nil {
+		db.logger.Error("Error deleting feed token.")
+		return err
+	}
+
+	return nil
+}
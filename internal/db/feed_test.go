@@ -0,0 +1,106 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+)
+
+func (d *dbTestSuite) TestSetFeedToken() {
+	errSetToken := errors.New("error inserting feed token")
+	createdAt := time.Date(2026, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errSetToken,
+			expectedResp: errSetToken,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.feed_token(.+) ON CONFLICT \\(user_id\\) DO UPDATE").
+				WithArgs("00001", "hash", createdAt)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.SetFeedToken("00001", "hash", createdAt)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetFeedTokenHash() {
+	errGetToken := errors.New("any error")
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp string
+		expectedErr  error
+	}{
+		"success": {
+			dbRows:       sqlmock.NewRows([]string{"token_hash"}).AddRow("hash"),
+			expectedResp: "hash",
+		},
+		"no token": {
+			dbRows: sqlmock.NewRows([]string{"token_hash"}),
+		},
+		"fail": {
+			dbError:     errGetToken,
+			expectedErr: errGetToken,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT token_hash FROM public.feed_token WHERE user_id = \\$1").WithArgs("00001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetFeedTokenHash("00001")
+			d.Assert().Equal(test.expectedErr, err)
+			d.Assert().Equal(test.expectedResp, resp)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteFeedToken() {
+	errDeleteToken := errors.New("error deleting feed token")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errDeleteToken,
+			expectedResp: errDeleteToken,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.feed_token").WithArgs("00001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteFeedToken("00001")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
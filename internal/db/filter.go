@@ -0,0 +1,177 @@
+package db
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/filter"
+	"github.com/google/uuid"
+)
+
+// filterKind tells how the values of a field of a filter expression are checked and compared.
+type filterKind int
+
+const (
+	filterId filterKind = iota
+	filterText
+	filterState
+	filterTime
+)
+
+// filterOps lists the operators each kind of field supports.
+var filterOps = map[filterKind][]filter.Op{
+	filterId:    {filter.Equal, filter.NotEqual, filter.In, filter.NotIn},
+	filterText:  {filter.Equal, filter.NotEqual, filter.Contains, filter.NotContains, filter.In, filter.NotIn},
+	filterState: {filter.Equal, filter.NotEqual, filter.In, filter.NotIn},
+	filterTime:  {filter.Equal, filter.NotEqual, filter.Less, filter.LessOrEqual, filter.Greater, filter.GreaterOrEqual},
+}
+
+// taskFilterFields lists the fields a filter expression can compare, named after their JSON
+// fields, which are also their columns in public.task.
+var taskFilterFields = map[string]filterKind{
+	"id":            filterId,
+	"user_id":       filterId,
+	"parent_id":     filterId,
+	"project_id":    filterId,
+	"description":   filterText,
+	"recurrence":    filterText,
+	"state":         filterState,
+	"due_at":        filterTime,
+	"remind_at":     filterTime,
+	"completed_at":  filterTime,
+	"created_at":    filterTime,
+	"archived_at":   filterTime,
+	"snoozed_until": filterTime,
+}
+
+// compileTaskFilter compiles a filter expression into a condition over public.task written with ?
+// placeholders, as conditions.add takes them. The errors about the expression wrap
+// filter.ErrInvalidExpression.
+func compileTaskFilter(expr filter.Expr) (string, []any, error) {
+	switch e := expr.(type) {
+	case *filter.Logical:
+		left, leftArgs, err := compileTaskFilter(e.Left)
+		if err != nil {
+			return "", nil, err
+		}
+		right, rightArgs, err := compileTaskFilter(e.Right)
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + left + " " + strings.ToUpper(string(e.Op)) + " " + right + ")", append(leftArgs, rightArgs...), nil
+	case *filter.Not:
+		clause, args, err := compileTaskFilter(e.Expr)
+		if err != nil {
+			return "", nil, err
+		}
+		// a comparison with a null column is neither true nor false, and NOT would leave it out
+		return "(" + clause + ") IS NOT TRUE", args, nil
+	case *filter.Comparison:
+		return compileTaskComparison(e)
+	default:
+		return "", nil, fmt.Errorf("unexpected filter expression %T", expr)
+	}
+}
+
+// compileTaskComparison compiles a comparison of a filter expression, checking its field, its
+// operator and its values.
+func compileTaskComparison(c *filter.Comparison) (string, []any, error) {
+	kind, ok := taskFilterFields[c.Field]
+	if !ok {
+		fields := slices.Sorted(maps.Keys(taskFilterFields))
+		return "", nil, filter.Errorf(c.Position, "unknown field %q, expected one of %s", c.Field, strings.Join(fields, ", "))
+	}
+	if !slices.Contains(filterOps[kind], c.Op) {
+		return "", nil, filter.Errorf(c.Position, "%s does not support the %s operator", c.Field, c.Op)
+	}
+	column := c.Field
+
+	if value := c.Values[0]; value.Null {
+		if c.Op == filter.Equal {
+			return column + " IS NULL", nil, nil
+		}
+		return column + " IS NOT NULL", nil, nil
+	}
+
+	if kind == filterTime {
+		return compileTimeComparison(column, c.Op, c.Values[0])
+	}
+
+	args := make([]any, 0, len(c.Values))
+	for _, value := range c.Values {
+		switch kind {
+		case filterId:
+			if _, err := uuid.Parse(value.Text); err != nil {
+				return "", nil, filter.Errorf(value.Position, "invalid id %q", value.Text)
+			}
+		case filterState:
+			if !common.TaskState(value.Text).Valid() {
+				return "", nil, filter.Errorf(value.Position, "invalid state %q", value.Text)
+			}
+		}
+		args = append(args, value.Text)
+	}
+
+	switch c.Op {
+	case filter.Equal:
+		return column + " = ?", args, nil
+	case filter.NotEqual:
+		return column + " IS DISTINCT FROM ?", args, nil
+	case filter.Contains:
+		return column + " ILIKE ?", []any{likePattern(c.Values[0].Text)}, nil
+	case filter.NotContains:
+		return "(" + column + " ILIKE ?) IS NOT TRUE", []any{likePattern(c.Values[0].Text)}, nil
+	case filter.In:
+		return column + " IN (" + placeholders(len(args)) + ")", args, nil
+	default:
+		return "(" + column + " IN (" + placeholders(len(args)) + ")) IS NOT TRUE", args, nil
+	}
+}
+
+// compileTimeComparison compiles a comparison of a time field with an RFC 3339 time or a date,
+// which stands for the whole UTC day: due_at = 2026-01-31 matches any time of that day, and
+// due_at > 2026-01-31 the times from the next day on.
+func compileTimeComparison(column string, op filter.Op, value filter.Value) (string, []any, error) {
+	if t, err := time.Parse(time.RFC3339, value.Text); err == nil {
+		switch op {
+		case filter.NotEqual:
+			return column + " IS DISTINCT FROM ?", []any{t}, nil
+		default:
+			return column + " " + string(op) + " ?", []any{t}, nil
+		}
+	}
+
+	day, err := time.Parse(time.DateOnly, value.Text)
+	if err != nil {
+		return "", nil, filter.Errorf(value.Position, "invalid time %q, expected a date or an RFC 3339 time", value.Text)
+	}
+	next := day.AddDate(0, 0, 1)
+
+	switch op {
+	case filter.Equal:
+		return "(" + column + " >= ? AND " + column + " < ?)", []any{day, next}, nil
+	case filter.NotEqual:
+		return "(" + column + " >= ? AND " + column + " < ?) IS NOT TRUE", []any{day, next}, nil
+	case filter.Less, filter.GreaterOrEqual:
+		return column + " " + string(op) + " ?", []any{day}, nil
+	case filter.LessOrEqual:
+		return column + " < ?", []any{next}, nil
+	default:
+		return column + " >= ?", []any{next}, nil
+	}
+}
+
+// likePattern returns the ILIKE pattern matching the values containing s.
+func likePattern(s string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + escaped + "%"
+}
+
+// placeholders returns n comma separated ? placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
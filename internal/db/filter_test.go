@@ -0,0 +1,135 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileTaskFilter(t *testing.T) {
+	day := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+
+	tests := map[string]struct {
+		expr           string
+		expectedClause string
+		expectedArgs   []any
+	}{
+		"equal": {
+			expr:           "user_id = 00000000-0000-0000-0000-000000000001",
+			expectedClause: "user_id = ?",
+			expectedArgs:   []any{"00000000-0000-0000-0000-000000000001"},
+		},
+		"not equal": {
+			expr:           `recurrence != "FREQ=DAILY"`,
+			expectedClause: "recurrence IS DISTINCT FROM ?",
+			expectedArgs:   []any{"FREQ=DAILY"},
+		},
+		"null": {
+			expr:           "due_at = null and project_id != null",
+			expectedClause: "(due_at IS NULL AND project_id IS NOT NULL)",
+			expectedArgs:   nil,
+		},
+		"contains": {
+			expr:           `description ~ "100%_done\\"`,
+			expectedClause: "description ILIKE ?",
+			expectedArgs:   []any{`%100\%\_done\\%`},
+		},
+		"does not contain": {
+			expr:           "description !~ deploy",
+			expectedClause: "(description ILIKE ?) IS NOT TRUE",
+			expectedArgs:   []any{"%deploy%"},
+		},
+		"in": {
+			expr:           "state in (to_do, in_progress)",
+			expectedClause: "state IN (?, ?)",
+			expectedArgs:   []any{"to_do", "in_progress"},
+		},
+		"not in": {
+			expr:           "state not in (done)",
+			expectedClause: "(state IN (?)) IS NOT TRUE",
+			expectedArgs:   []any{"done"},
+		},
+		"time": {
+			expr:           "created_at >= 2026-01-31T00:00:00Z or due_at != 2026-01-31T00:00:00Z",
+			expectedClause: "(created_at >= ? OR due_at IS DISTINCT FROM ?)",
+			expectedArgs:   []any{day, day},
+		},
+		"date": {
+			expr:           "due_at = 2026-01-31",
+			expectedClause: "(due_at >= ? AND due_at < ?)",
+			expectedArgs:   []any{day, nextDay},
+		},
+		"not date": {
+			expr:           "due_at != 2026-01-31",
+			expectedClause: "(due_at >= ? AND due_at < ?) IS NOT TRUE",
+			expectedArgs:   []any{day, nextDay},
+		},
+		"date bounds": {
+			expr:           "due_at < 2026-01-31 or due_at <= 2026-01-31 or due_at > 2026-01-31 or due_at >= 2026-01-31",
+			expectedClause: "(((due_at < ? OR due_at < ?) OR due_at >= ?) OR due_at >= ?)",
+			expectedArgs:   []any{day, nextDay, nextDay, day},
+		},
+		"not": {
+			expr:           "not (state = done and archived_at != null)",
+			expectedClause: "((state = ? AND archived_at IS NOT NULL)) IS NOT TRUE",
+			expectedArgs:   []any{"done"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := filter.Parse(test.expr)
+			require.NoError(t, err)
+
+			clause, args, err := compileTaskFilter(expr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedClause, clause)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
+
+func TestCompileTaskFilterErrors(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		expected string
+	}{
+		"unknown field": {
+			expr: "state = done and priority = high",
+			expected: "invalid filter expression: unknown field \"priority\", expected one of archived_at, completed_at, " +
+				"created_at, description, due_at, id, parent_id, project_id, recurrence, remind_at, snoozed_until, state, " +
+				"user_id at position 18",
+		},
+		"unsupported operator": {
+			expr:     "state ~ do",
+			expected: "invalid filter expression: state does not support the ~ operator at position 1",
+		},
+		"invalid id": {
+			expr:     "project_id in (00000000-0000-0000-0000-000000000001, 0001)",
+			expected: "invalid filter expression: invalid id \"0001\" at position 54",
+		},
+		"invalid state": {
+			expr:     "state = finished",
+			expected: "invalid filter expression: invalid state \"finished\" at position 9",
+		},
+		"invalid time": {
+			expr:     "due_at < tomorrow",
+			expected: "invalid filter expression: invalid time \"tomorrow\", expected a date or an RFC 3339 time at position 10",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := filter.Parse(test.expr)
+			require.NoError(t, err)
+
+			_, _, err = compileTaskFilter(expr)
+			assert.ErrorIs(t, err, filter.ErrInvalidExpression)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+// ErrLabelNameTaken is returned when a label gets the name of another label of its user.
+var ErrLabelNameTaken = errors.New("label name already taken")
+
+func (db *DB) AddLabel(label *common.Label) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.label(id, user_id, name)
+		VALUES($1, $2, $3)
+	`, label.Id, label.UserId, label.Name)
+	if err != nil {
+		if isLabelNameTaken(err) {
+			return ErrLabelNameTaken
+		}
+		db.logger.Error("Error inserting label.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) UpdateLabel(label *common.Label) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.label
+		SET name = $1
+		WHERE id = $2
+	`, label.Name, label.Id)
+	if err != nil {
+		if isLabelNameTaken(err) {
+			return ErrLabelNameTaken
+		}
+		db.logger.Error("Error updating label.")
+		return err
+	}
+
+	return nil
+}
+
+// isLabelNameTaken tells whether err violates the unique index of the label names of a user, which
+// catches the names taken concurrently.
+func isLabelNameTaken(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "label_user_name_key"
+}
+
+func (db *DB) GetLabel(id string) (*common.Label, error) {
+	results, err := db.conn().Query(`
+		SELECT id, user_id, name
+		FROM public.label
+		WHERE id = $1`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving label.")
+		return nil, err
+	}
+	defer results.Close()
+
+	label := common.Label{}
+	for results.Next() {
+		err = results.Scan(
+			&label.Id,
+			&label.UserId,
+			&label.Name)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &label, nil
+}
+
+// DeleteLabel deletes a label, detaching it from every task.
+func (db *DB) DeleteLabel(id string) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public.label WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error deleting label.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) ListUserLabels(userId string) ([]common.Label, error) {
+	results, err := db.conn().Query(`
+		SELECT id, user_id, name
+		FROM public.label
+		WHERE user_id = $1
+		ORDER BY name`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving user labels.")
+		return nil, err
+	}
+	defer results.Close()
+
+	labels := make([]common.Label, 0)
+	for results.Next() {
+		label := common.Label{}
+		err = results.Scan(
+			&label.Id,
+			&label.UserId,
+			&label.Name)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// SetTaskLabels replaces the labels attached to a task.
+func (db *DB) SetTaskLabels(taskId string, labelIds []string) error {
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM public.task_label WHERE task_id = $1
+	`, taskId)
+	if err != nil {
+		db.logger.Error("Error detaching task labels.")
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO public.task_label(task_id, label_id)
+		SELECT $1, unnest($2::uuid[])
+	`, taskId, pq.Array(labelIds))
+	if err != nil {
+		db.logger.Error("Error attaching task labels.")
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CopyTaskLabels attaches the labels of a task to another one.
+func (db *DB) CopyTaskLabels(fromId string, toId string) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.task_label(task_id, label_id)
+		SELECT $2, label_id FROM public.task_label WHERE task_id = $1
+		ON CONFLICT DO NOTHING
+	`, fromId, toId)
+	if err != nil {
+		db.logger.Error("Error copying task labels.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTaskLabels returns the label names of the given tasks, sorted by name and keyed by task id.
+func (db *DB) ListTaskLabels(taskIds []string) (map[string][]string, error) {
+	results, err := db.conn().Query(`
+		SELECT tl.task_id, l.name
+		FROM public.task_label tl
+		JOIN public.label l ON l.id = tl.label_id
+		WHERE tl.task_id = ANY($1::uuid[])
+		ORDER BY l.name`, pq.Array(taskIds))
+	if err != nil {
+		db.logger.Error("Error retrieving task labels.")
+		return nil, err
+	}
+	defer results.Close()
+
+	labels := make(map[string][]string)
+	for results.Next() {
+		var taskId, name string
+		if err := results.Scan(&taskId, &name); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		labels[taskId] = append(labels[taskId], name)
+	}
+
+	return labels, nil
+}
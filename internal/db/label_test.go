@@ -0,0 +1,320 @@
+package db
+
+import (
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+var labelColumnNames = []string{"id", "user_id", "name"}
+
+func (d *dbTestSuite) TestAddLabel() {
+	errAddLabel := errors.New("error inserting label")
+	label := &common.Label{
+		Id:     "0001",
+		UserId: "00001",
+		Name:   "home",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errAddLabel,
+			expectedResp: errAddLabel,
+		},
+		"name taken": {
+			dbError:      &pq.Error{Code: "23505", Constraint: "label_user_name_key"},
+			expectedResp: ErrLabelNameTaken,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.label").WithArgs(label.Id, label.UserId, label.Name)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddLabel(label)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestUpdateLabel() {
+	errUpdateLabel := errors.New("error updating label")
+	label := &common.Label{
+		Id:     "0001",
+		UserId: "00001",
+		Name:   "home",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errUpdateLabel,
+			expectedResp: errUpdateLabel,
+		},
+		"name taken": {
+			dbError:      &pq.Error{Code: "23505", Constraint: "label_user_name_key"},
+			expectedResp: ErrLabelNameTaken,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec("UPDATE public.label").WithArgs(label.Name, label.Id)
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			err := d.db.UpdateLabel(label)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetLabel() {
+	errGetLabel := errors.New("any error")
+	label := &common.Label{
+		Id:     "0001",
+		UserId: "00001",
+		Name:   "home",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp *common.Label
+		expectedErr  error
+	}{
+		"success": {
+			dbRows:       sqlmock.NewRows(labelColumnNames).AddRow(label.Id, label.UserId, label.Name),
+			expectedResp: label,
+		},
+		"not found": {
+			dbRows:       sqlmock.NewRows(labelColumnNames),
+			expectedResp: &common.Label{},
+		},
+		"fail": {
+			dbError:     errGetLabel,
+			expectedErr: errGetLabel,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.label WHERE id = (.+)").WithArgs(label.Id)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetLabel(label.Id)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteLabel() {
+	errDeleteLabel := errors.New("error deleting label")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errDeleteLabel,
+			expectedResp: errDeleteLabel,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.label").WithArgs("0001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteLabel("0001")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListUserLabels() {
+	errListLabels := errors.New("any error")
+	labels := []common.Label{
+		{Id: "0001", UserId: "00001", Name: "home"},
+		{Id: "0002", UserId: "00001", Name: "work"},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []common.Label
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(labelColumnNames).
+				AddRow(labels[0].Id, labels[0].UserId, labels[0].Name).
+				AddRow(labels[1].Id, labels[1].UserId, labels[1].Name),
+			expectedResp: labels,
+		},
+		"fail": {
+			dbError:     errListLabels,
+			expectedErr: errListLabels,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.label WHERE user_id = (.+) ORDER BY name").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListUserLabels("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestSetTaskLabels() {
+	errSetLabels := errors.New("error attaching task labels")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errSetLabels,
+			expectedResp: errSetLabels,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			d.mock.ExpectExec("DELETE FROM public.task_label").WithArgs("0001").WillReturnResult(sqlmock.NewResult(0, 2))
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.task_label").WithArgs("0001", `{"0002","0003"}`)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(0, 2))
+				d.mock.ExpectCommit()
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+				d.mock.ExpectRollback()
+			}
+
+			err := d.db.SetTaskLabels("0001", []string{"0002", "0003"})
+			d.Assert().Equal(test.expectedResp, err)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (d *dbTestSuite) TestCopyTaskLabels() {
+	errCopyLabels := errors.New("error copying task labels")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errCopyLabels,
+			expectedResp: errCopyLabels,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.task_label").WithArgs("0001", "0002")
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.CopyTaskLabels("0001", "0002")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTaskLabels() {
+	errListLabels := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp map[string][]string
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows([]string{"task_id", "name"}).
+				AddRow("0001", "home").
+				AddRow("0002", "home").
+				AddRow("0001", "work"),
+			expectedResp: map[string][]string{
+				"0001": {"home", "work"},
+				"0002": {"home"},
+			},
+		},
+		"fail": {
+			dbError:     errListLabels,
+			expectedErr: errListLabels,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.task_label").WithArgs(`{"0001","0002"}`)
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTaskLabels([]string{"0001", "0002"})
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
@@ -6,8 +6,10 @@ package mock_db
 
 import (
 	reflect "reflect"
+	time "time"
 
 	common "github.com/aborgesrodrigues/to-do-api/internal/common"
+	db "github.com/aborgesrodrigues/to-do-api/internal/db"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,6 +36,76 @@ func (m *MockDBInterface) EXPECT() *MockDBInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddAttachment mocks base method.
+func (m *MockDBInterface) AddAttachment(attachment *common.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockDBInterfaceMockRecorder) AddAttachment(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockDBInterface)(nil).AddAttachment), attachment)
+}
+
+// AddChecklistItem mocks base method.
+func (m *MockDBInterface) AddChecklistItem(item *common.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockDBInterfaceMockRecorder) AddChecklistItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockDBInterface)(nil).AddChecklistItem), item)
+}
+
+// AddComment mocks base method.
+func (m *MockDBInterface) AddComment(comment *common.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockDBInterfaceMockRecorder) AddComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockDBInterface)(nil).AddComment), comment)
+}
+
+// AddLabel mocks base method.
+func (m *MockDBInterface) AddLabel(label *common.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLabel", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLabel indicates an expected call of AddLabel.
+func (mr *MockDBInterfaceMockRecorder) AddLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLabel", reflect.TypeOf((*MockDBInterface)(nil).AddLabel), label)
+}
+
+// AddProject mocks base method.
+func (m *MockDBInterface) AddProject(project *common.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProject indicates an expected call of AddProject.
+func (mr *MockDBInterfaceMockRecorder) AddProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProject", reflect.TypeOf((*MockDBInterface)(nil).AddProject), project)
+}
+
 // AddTask mocks base method.
 func (m *MockDBInterface) AddTask(task *common.Task) error {
 	m.ctrl.T.Helper()
@@ -48,6 +120,62 @@ func (mr *MockDBInterfaceMockRecorder) AddTask(task interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockDBInterface)(nil).AddTask), task)
 }
 
+// AddTaskDependency mocks base method.
+func (m *MockDBInterface) AddTaskDependency(dependency *common.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskDependency", dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskDependency indicates an expected call of AddTaskDependency.
+func (mr *MockDBInterfaceMockRecorder) AddTaskDependency(dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskDependency", reflect.TypeOf((*MockDBInterface)(nil).AddTaskDependency), dependency)
+}
+
+// AddTaskRevision mocks base method.
+func (m *MockDBInterface) AddTaskRevision(revision *common.TaskRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskRevision", revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskRevision indicates an expected call of AddTaskRevision.
+func (mr *MockDBInterfaceMockRecorder) AddTaskRevision(revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskRevision", reflect.TypeOf((*MockDBInterface)(nil).AddTaskRevision), revision)
+}
+
+// AddTemplate mocks base method.
+func (m *MockDBInterface) AddTemplate(template *common.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTemplate", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTemplate indicates an expected call of AddTemplate.
+func (mr *MockDBInterfaceMockRecorder) AddTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTemplate", reflect.TypeOf((*MockDBInterface)(nil).AddTemplate), template)
+}
+
+// AddTimeEntry mocks base method.
+func (m *MockDBInterface) AddTimeEntry(entry *common.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTimeEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTimeEntry indicates an expected call of AddTimeEntry.
+func (mr *MockDBInterfaceMockRecorder) AddTimeEntry(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTimeEntry", reflect.TypeOf((*MockDBInterface)(nil).AddTimeEntry), entry)
+}
+
 // AddUser mocks base method.
 func (m *MockDBInterface) AddUser(user *common.User) error {
 	m.ctrl.T.Helper()
@@ -62,147 +190,1143 @@ func (mr *MockDBInterfaceMockRecorder) AddUser(user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockDBInterface)(nil).AddUser), user)
 }
 
+// CopyTaskLabels mocks base method.
+func (m *MockDBInterface) CopyTaskLabels(fromId, toId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyTaskLabels", fromId, toId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyTaskLabels indicates an expected call of CopyTaskLabels.
+func (mr *MockDBInterfaceMockRecorder) CopyTaskLabels(fromId, toId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyTaskLabels", reflect.TypeOf((*MockDBInterface)(nil).CopyTaskLabels), fromId, toId)
+}
+
+// CountOpenDependencies mocks base method.
+func (m *MockDBInterface) CountOpenDependencies(id string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenDependencies", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenDependencies indicates an expected call of CountOpenDependencies.
+func (mr *MockDBInterfaceMockRecorder) CountOpenDependencies(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenDependencies", reflect.TypeOf((*MockDBInterface)(nil).CountOpenDependencies), id)
+}
+
+// CountStateTasks mocks base method.
+func (m *MockDBInterface) CountStateTasks(userId string, state common.TaskState) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStateTasks", userId, state)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStateTasks indicates an expected call of CountStateTasks.
+func (mr *MockDBInterfaceMockRecorder) CountStateTasks(userId, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStateTasks", reflect.TypeOf((*MockDBInterface)(nil).CountStateTasks), userId, state)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockDBInterface) DeleteAttachment(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockDBInterfaceMockRecorder) DeleteAttachment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockDBInterface)(nil).DeleteAttachment), id)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockDBInterface) DeleteChecklistItem(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockDBInterfaceMockRecorder) DeleteChecklistItem(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockDBInterface)(nil).DeleteChecklistItem), id)
+}
+
+// DeleteComment mocks base method.
+func (m *MockDBInterface) DeleteComment(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockDBInterfaceMockRecorder) DeleteComment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockDBInterface)(nil).DeleteComment), id)
+}
+
+// DeleteFeedToken mocks base method.
+func (m *MockDBInterface) DeleteFeedToken(userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeedToken", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeedToken indicates an expected call of DeleteFeedToken.
+func (mr *MockDBInterfaceMockRecorder) DeleteFeedToken(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeedToken", reflect.TypeOf((*MockDBInterface)(nil).DeleteFeedToken), userId)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockDBInterface) DeleteLabel(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockDBInterfaceMockRecorder) DeleteLabel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockDBInterface)(nil).DeleteLabel), id)
+}
+
+// DeleteProject mocks base method.
+func (m *MockDBInterface) DeleteProject(id string, deletion common.ProjectDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", id, deletion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockDBInterfaceMockRecorder) DeleteProject(id, deletion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockDBInterface)(nil).DeleteProject), id, deletion)
+}
+
 // DeleteTask mocks base method.
-func (m *MockDBInterface) DeleteTask(id string) error {
+func (m *MockDBInterface) DeleteTask(id string, deletedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", id)
+	ret := m.ctrl.Call(m, "DeleteTask", id, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockDBInterfaceMockRecorder) DeleteTask(id interface{}) *gomock.Call {
+func (mr *MockDBInterfaceMockRecorder) DeleteTask(id, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockDBInterface)(nil).DeleteTask), id, deletedAt)
+}
+
+// DeleteTaskDependency mocks base method.
+func (m *MockDBInterface) DeleteTaskDependency(dependency *common.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskDependency", dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskDependency indicates an expected call of DeleteTaskDependency.
+func (mr *MockDBInterfaceMockRecorder) DeleteTaskDependency(dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskDependency", reflect.TypeOf((*MockDBInterface)(nil).DeleteTaskDependency), dependency)
+}
+
+// DeleteTaskTree mocks base method.
+func (m *MockDBInterface) DeleteTaskTree(id string, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTree", id, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTree indicates an expected call of DeleteTaskTree.
+func (mr *MockDBInterfaceMockRecorder) DeleteTaskTree(id, deletedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockDBInterface)(nil).DeleteTask), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTree", reflect.TypeOf((*MockDBInterface)(nil).DeleteTaskTree), id, deletedAt)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockDBInterface) DeleteTemplate(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockDBInterfaceMockRecorder) DeleteTemplate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockDBInterface)(nil).DeleteTemplate), id)
 }
 
 // DeleteUser mocks base method.
-func (m *MockDBInterface) DeleteUser(id string) error {
+func (m *MockDBInterface) DeleteUser(id string, deletedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret := m.ctrl.Call(m, "DeleteUser", id, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockDBInterfaceMockRecorder) DeleteUser(id interface{}) *gomock.Call {
+func (mr *MockDBInterfaceMockRecorder) DeleteUser(id, deletedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDBInterface)(nil).DeleteUser), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDBInterface)(nil).DeleteUser), id, deletedAt)
 }
 
 // DeleteUserTasks mocks base method.
-func (m *MockDBInterface) DeleteUserTasks(userId string) error {
+func (m *MockDBInterface) DeleteUserTasks(userId string, deletedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserTasks", userId)
+	ret := m.ctrl.Call(m, "DeleteUserTasks", userId, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUserTasks indicates an expected call of DeleteUserTasks.
-func (mr *MockDBInterfaceMockRecorder) DeleteUserTasks(userId interface{}) *gomock.Call {
+func (mr *MockDBInterfaceMockRecorder) DeleteUserTasks(userId, deletedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTasks", reflect.TypeOf((*MockDBInterface)(nil).DeleteUserTasks), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTasks", reflect.TypeOf((*MockDBInterface)(nil).DeleteUserTasks), userId, deletedAt)
 }
 
-// GetTask mocks base method.
-func (m *MockDBInterface) GetTask(id string) (*common.Task, error) {
+// GetAttachment mocks base method.
+func (m *MockDBInterface) GetAttachment(id string) (*common.Attachment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", id)
+	ret := m.ctrl.Call(m, "GetAttachment", id)
+	ret0, _ := ret[0].(*common.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockDBInterfaceMockRecorder) GetAttachment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockDBInterface)(nil).GetAttachment), id)
+}
+
+// GetChecklistItem mocks base method.
+func (m *MockDBInterface) GetChecklistItem(id string) (*common.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItem", id)
+	ret0, _ := ret[0].(*common.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklistItem indicates an expected call of GetChecklistItem.
+func (mr *MockDBInterfaceMockRecorder) GetChecklistItem(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItem", reflect.TypeOf((*MockDBInterface)(nil).GetChecklistItem), id)
+}
+
+// GetComment mocks base method.
+func (m *MockDBInterface) GetComment(id string) (*common.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", id)
+	ret0, _ := ret[0].(*common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockDBInterfaceMockRecorder) GetComment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockDBInterface)(nil).GetComment), id)
+}
+
+// GetDeletedTask mocks base method.
+func (m *MockDBInterface) GetDeletedTask(id string) (*common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedTask", id)
 	ret0, _ := ret[0].(*common.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockDBInterfaceMockRecorder) GetTask(id interface{}) *gomock.Call {
+// GetDeletedTask indicates an expected call of GetDeletedTask.
+func (mr *MockDBInterfaceMockRecorder) GetDeletedTask(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockDBInterface)(nil).GetTask), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedTask", reflect.TypeOf((*MockDBInterface)(nil).GetDeletedTask), id)
 }
 
-// GetUser mocks base method.
-func (m *MockDBInterface) GetUser(id string) (*common.User, error) {
+// GetDeletedUser mocks base method.
+func (m *MockDBInterface) GetDeletedUser(id string) (*common.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", id)
+	ret := m.ctrl.Call(m, "GetDeletedUser", id)
 	ret0, _ := ret[0].(*common.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockDBInterfaceMockRecorder) GetUser(id interface{}) *gomock.Call {
+// GetDeletedUser indicates an expected call of GetDeletedUser.
+func (mr *MockDBInterfaceMockRecorder) GetDeletedUser(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDBInterface)(nil).GetUser), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedUser", reflect.TypeOf((*MockDBInterface)(nil).GetDeletedUser), id)
 }
 
-// ListTasks mocks base method.
-func (m *MockDBInterface) ListTasks() ([]common.Task, error) {
+// GetFeedTokenHash mocks base method.
+func (m *MockDBInterface) GetFeedTokenHash(userId string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTasks")
-	ret0, _ := ret[0].([]common.Task)
+	ret := m.ctrl.Call(m, "GetFeedTokenHash", userId)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTasks indicates an expected call of ListTasks.
-func (mr *MockDBInterfaceMockRecorder) ListTasks() *gomock.Call {
+// GetFeedTokenHash indicates an expected call of GetFeedTokenHash.
+func (mr *MockDBInterfaceMockRecorder) GetFeedTokenHash(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockDBInterface)(nil).ListTasks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedTokenHash", reflect.TypeOf((*MockDBInterface)(nil).GetFeedTokenHash), userId)
 }
 
-// ListUserTasks mocks base method.
-func (m *MockDBInterface) ListUserTasks(id string) ([]common.Task, error) {
+// GetLabel mocks base method.
+func (m *MockDBInterface) GetLabel(id string) (*common.Label, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserTasks", id)
-	ret0, _ := ret[0].([]common.Task)
+	ret := m.ctrl.Call(m, "GetLabel", id)
+	ret0, _ := ret[0].(*common.Label)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserTasks indicates an expected call of ListUserTasks.
-func (mr *MockDBInterfaceMockRecorder) ListUserTasks(id interface{}) *gomock.Call {
+// GetLabel indicates an expected call of GetLabel.
+func (mr *MockDBInterfaceMockRecorder) GetLabel(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTasks", reflect.TypeOf((*MockDBInterface)(nil).ListUserTasks), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabel", reflect.TypeOf((*MockDBInterface)(nil).GetLabel), id)
 }
 
-// ListUsers mocks base method.
-func (m *MockDBInterface) ListUsers() ([]common.User, error) {
+// GetProject mocks base method.
+func (m *MockDBInterface) GetProject(id string) (*common.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers")
-	ret0, _ := ret[0].([]common.User)
+	ret := m.ctrl.Call(m, "GetProject", id)
+	ret0, _ := ret[0].(*common.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockDBInterfaceMockRecorder) ListUsers() *gomock.Call {
+// GetProject indicates an expected call of GetProject.
+func (mr *MockDBInterfaceMockRecorder) GetProject(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockDBInterface)(nil).ListUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockDBInterface)(nil).GetProject), id)
 }
 
-// UpdateTask mocks base method.
-func (m *MockDBInterface) UpdateTask(task *common.Task) error {
+// GetRunningTimeEntry mocks base method.
+func (m *MockDBInterface) GetRunningTimeEntry(userId string) (*common.TimeEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", task)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetRunningTimeEntry", userId)
+	ret0, _ := ret[0].(*common.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockDBInterfaceMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+// GetRunningTimeEntry indicates an expected call of GetRunningTimeEntry.
+func (mr *MockDBInterfaceMockRecorder) GetRunningTimeEntry(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockDBInterface)(nil).UpdateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimeEntry", reflect.TypeOf((*MockDBInterface)(nil).GetRunningTimeEntry), userId)
 }
 
-// UpdateUser mocks base method.
-func (m *MockDBInterface) UpdateUser(user *common.User) error {
+// GetTask mocks base method.
+func (m *MockDBInterface) GetTask(id string) (*common.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", user)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetTask", id)
+	ret0, _ := ret[0].(*common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockDBInterfaceMockRecorder) UpdateUser(user interface{}) *gomock.Call {
+// GetTask indicates an expected call of GetTask.
+func (mr *MockDBInterfaceMockRecorder) GetTask(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockDBInterface)(nil).UpdateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockDBInterface)(nil).GetTask), id)
+}
+
+// GetTaskProgress mocks base method.
+func (m *MockDBInterface) GetTaskProgress(id string) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskProgress", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskProgress indicates an expected call of GetTaskProgress.
+func (mr *MockDBInterfaceMockRecorder) GetTaskProgress(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskProgress", reflect.TypeOf((*MockDBInterface)(nil).GetTaskProgress), id)
+}
+
+// GetTemplate mocks base method.
+func (m *MockDBInterface) GetTemplate(id string) (*common.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", id)
+	ret0, _ := ret[0].(*common.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockDBInterfaceMockRecorder) GetTemplate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockDBInterface)(nil).GetTemplate), id)
+}
+
+// GetUser mocks base method.
+func (m *MockDBInterface) GetUser(id string) (*common.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", id)
+	ret0, _ := ret[0].(*common.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockDBInterfaceMockRecorder) GetUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDBInterface)(nil).GetUser), id)
+}
+
+// InTx mocks base method.
+func (m *MockDBInterface) InTx(fn func(db.DBInterface) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockDBInterfaceMockRecorder) InTx(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockDBInterface)(nil).InTx), fn)
+}
+
+// IterUserTasks mocks base method.
+func (m *MockDBInterface) IterUserTasks(id string, filter common.TaskFilter) db.TaskIter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterUserTasks", id, filter)
+	ret0, _ := ret[0].(db.TaskIter)
+	return ret0
+}
+
+// IterUserTasks indicates an expected call of IterUserTasks.
+func (mr *MockDBInterfaceMockRecorder) IterUserTasks(id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterUserTasks", reflect.TypeOf((*MockDBInterface)(nil).IterUserTasks), id, filter)
+}
+
+// LastChecklistPosition mocks base method.
+func (m *MockDBInterface) LastChecklistPosition(taskId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastChecklistPosition", taskId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastChecklistPosition indicates an expected call of LastChecklistPosition.
+func (mr *MockDBInterfaceMockRecorder) LastChecklistPosition(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastChecklistPosition", reflect.TypeOf((*MockDBInterface)(nil).LastChecklistPosition), taskId)
+}
+
+// LastTaskPosition mocks base method.
+func (m *MockDBInterface) LastTaskPosition(userId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastTaskPosition", userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastTaskPosition indicates an expected call of LastTaskPosition.
+func (mr *MockDBInterfaceMockRecorder) LastTaskPosition(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastTaskPosition", reflect.TypeOf((*MockDBInterface)(nil).LastTaskPosition), userId)
+}
+
+// ListChecklistItems mocks base method.
+func (m *MockDBInterface) ListChecklistItems(taskIds []string) (map[string][]common.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChecklistItems", taskIds)
+	ret0, _ := ret[0].(map[string][]common.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChecklistItems indicates an expected call of ListChecklistItems.
+func (mr *MockDBInterfaceMockRecorder) ListChecklistItems(taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChecklistItems", reflect.TypeOf((*MockDBInterface)(nil).ListChecklistItems), taskIds)
+}
+
+// ListChecklistPositions mocks base method.
+func (m *MockDBInterface) ListChecklistPositions(taskId string) ([]common.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChecklistPositions", taskId)
+	ret0, _ := ret[0].([]common.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChecklistPositions indicates an expected call of ListChecklistPositions.
+func (mr *MockDBInterfaceMockRecorder) ListChecklistPositions(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChecklistPositions", reflect.TypeOf((*MockDBInterface)(nil).ListChecklistPositions), taskId)
+}
+
+// ListCommentsOfTasks mocks base method.
+func (m *MockDBInterface) ListCommentsOfTasks(taskIds []string) (map[string][]common.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsOfTasks", taskIds)
+	ret0, _ := ret[0].(map[string][]common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsOfTasks indicates an expected call of ListCommentsOfTasks.
+func (mr *MockDBInterfaceMockRecorder) ListCommentsOfTasks(taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsOfTasks", reflect.TypeOf((*MockDBInterface)(nil).ListCommentsOfTasks), taskIds)
+}
+
+// ListDeletedAttachments mocks base method.
+func (m *MockDBInterface) ListDeletedAttachments(before time.Time) ([]common.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedAttachments", before)
+	ret0, _ := ret[0].([]common.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedAttachments indicates an expected call of ListDeletedAttachments.
+func (mr *MockDBInterfaceMockRecorder) ListDeletedAttachments(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedAttachments", reflect.TypeOf((*MockDBInterface)(nil).ListDeletedAttachments), before)
+}
+
+// ListDeletedTasks mocks base method.
+func (m *MockDBInterface) ListDeletedTasks(userId string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedTasks", userId)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedTasks indicates an expected call of ListDeletedTasks.
+func (mr *MockDBInterfaceMockRecorder) ListDeletedTasks(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedTasks", reflect.TypeOf((*MockDBInterface)(nil).ListDeletedTasks), userId)
+}
+
+// ListDeletedUsers mocks base method.
+func (m *MockDBInterface) ListDeletedUsers(id string) ([]common.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedUsers", id)
+	ret0, _ := ret[0].([]common.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedUsers indicates an expected call of ListDeletedUsers.
+func (mr *MockDBInterfaceMockRecorder) ListDeletedUsers(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedUsers", reflect.TypeOf((*MockDBInterface)(nil).ListDeletedUsers), id)
+}
+
+// ListDueReminders mocks base method.
+func (m *MockDBInterface) ListDueReminders(now time.Time) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueReminders", now)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueReminders indicates an expected call of ListDueReminders.
+func (mr *MockDBInterfaceMockRecorder) ListDueReminders(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueReminders", reflect.TypeOf((*MockDBInterface)(nil).ListDueReminders), now)
+}
+
+// ListProjects mocks base method.
+func (m *MockDBInterface) ListProjects() ([]common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects")
+	ret0, _ := ret[0].([]common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockDBInterfaceMockRecorder) ListProjects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockDBInterface)(nil).ListProjects))
+}
+
+// ListRevisionsOfTasks mocks base method.
+func (m *MockDBInterface) ListRevisionsOfTasks(taskIds []string) (map[string][]common.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisionsOfTasks", taskIds)
+	ret0, _ := ret[0].(map[string][]common.TaskRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisionsOfTasks indicates an expected call of ListRevisionsOfTasks.
+func (mr *MockDBInterfaceMockRecorder) ListRevisionsOfTasks(taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisionsOfTasks", reflect.TypeOf((*MockDBInterface)(nil).ListRevisionsOfTasks), taskIds)
+}
+
+// ListSnoozedTasks mocks base method.
+func (m *MockDBInterface) ListSnoozedTasks(now time.Time) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSnoozedTasks", now)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSnoozedTasks indicates an expected call of ListSnoozedTasks.
+func (mr *MockDBInterfaceMockRecorder) ListSnoozedTasks(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSnoozedTasks", reflect.TypeOf((*MockDBInterface)(nil).ListSnoozedTasks), now)
+}
+
+// ListSubtasks mocks base method.
+func (m *MockDBInterface) ListSubtasks(id string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubtasks", id)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubtasks indicates an expected call of ListSubtasks.
+func (mr *MockDBInterfaceMockRecorder) ListSubtasks(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubtasks", reflect.TypeOf((*MockDBInterface)(nil).ListSubtasks), id)
+}
+
+// ListTaskAncestors mocks base method.
+func (m *MockDBInterface) ListTaskAncestors(id string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAncestors", id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAncestors indicates an expected call of ListTaskAncestors.
+func (mr *MockDBInterfaceMockRecorder) ListTaskAncestors(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAncestors", reflect.TypeOf((*MockDBInterface)(nil).ListTaskAncestors), id)
+}
+
+// ListTaskAttachments mocks base method.
+func (m *MockDBInterface) ListTaskAttachments(taskId string) ([]common.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAttachments", taskId)
+	ret0, _ := ret[0].([]common.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAttachments indicates an expected call of ListTaskAttachments.
+func (mr *MockDBInterfaceMockRecorder) ListTaskAttachments(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAttachments", reflect.TypeOf((*MockDBInterface)(nil).ListTaskAttachments), taskId)
+}
+
+// ListTaskComments mocks base method.
+func (m *MockDBInterface) ListTaskComments(taskId string) ([]common.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskComments", taskId)
+	ret0, _ := ret[0].([]common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskComments indicates an expected call of ListTaskComments.
+func (mr *MockDBInterfaceMockRecorder) ListTaskComments(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskComments", reflect.TypeOf((*MockDBInterface)(nil).ListTaskComments), taskId)
+}
+
+// ListTaskDependencies mocks base method.
+func (m *MockDBInterface) ListTaskDependencies(id string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskDependencies", id)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskDependencies indicates an expected call of ListTaskDependencies.
+func (mr *MockDBInterfaceMockRecorder) ListTaskDependencies(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskDependencies", reflect.TypeOf((*MockDBInterface)(nil).ListTaskDependencies), id)
+}
+
+// ListTaskLabels mocks base method.
+func (m *MockDBInterface) ListTaskLabels(taskIds []string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskLabels", taskIds)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskLabels indicates an expected call of ListTaskLabels.
+func (mr *MockDBInterfaceMockRecorder) ListTaskLabels(taskIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskLabels", reflect.TypeOf((*MockDBInterface)(nil).ListTaskLabels), taskIds)
+}
+
+// ListTaskPositions mocks base method.
+func (m *MockDBInterface) ListTaskPositions(userId string) ([]common.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskPositions", userId)
+	ret0, _ := ret[0].([]common.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskPositions indicates an expected call of ListTaskPositions.
+func (mr *MockDBInterfaceMockRecorder) ListTaskPositions(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskPositions", reflect.TypeOf((*MockDBInterface)(nil).ListTaskPositions), userId)
+}
+
+// ListTaskRevisions mocks base method.
+func (m *MockDBInterface) ListTaskRevisions(taskId string) ([]common.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskRevisions", taskId)
+	ret0, _ := ret[0].([]common.TaskRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskRevisions indicates an expected call of ListTaskRevisions.
+func (mr *MockDBInterfaceMockRecorder) ListTaskRevisions(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskRevisions", reflect.TypeOf((*MockDBInterface)(nil).ListTaskRevisions), taskId)
+}
+
+// ListTasks mocks base method.
+func (m *MockDBInterface) ListTasks(filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", filter, page)
+	ret0, _ := ret[0].(*common.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockDBInterfaceMockRecorder) ListTasks(filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockDBInterface)(nil).ListTasks), filter, page)
+}
+
+// ListTemplates mocks base method.
+func (m *MockDBInterface) ListTemplates(userId string) ([]common.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", userId)
+	ret0, _ := ret[0].([]common.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockDBInterfaceMockRecorder) ListTemplates(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockDBInterface)(nil).ListTemplates), userId)
+}
+
+// ListTimeEntries mocks base method.
+func (m *MockDBInterface) ListTimeEntries(userId string, from, to time.Time) ([]common.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimeEntries", userId, from, to)
+	ret0, _ := ret[0].([]common.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntries indicates an expected call of ListTimeEntries.
+func (mr *MockDBInterfaceMockRecorder) ListTimeEntries(userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntries", reflect.TypeOf((*MockDBInterface)(nil).ListTimeEntries), userId, from, to)
+}
+
+// ListTransitiveDependencies mocks base method.
+func (m *MockDBInterface) ListTransitiveDependencies(id string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransitiveDependencies", id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransitiveDependencies indicates an expected call of ListTransitiveDependencies.
+func (mr *MockDBInterfaceMockRecorder) ListTransitiveDependencies(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransitiveDependencies", reflect.TypeOf((*MockDBInterface)(nil).ListTransitiveDependencies), id)
+}
+
+// ListUserLabels mocks base method.
+func (m *MockDBInterface) ListUserLabels(userId string) ([]common.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLabels", userId)
+	ret0, _ := ret[0].([]common.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLabels indicates an expected call of ListUserLabels.
+func (mr *MockDBInterfaceMockRecorder) ListUserLabels(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLabels", reflect.TypeOf((*MockDBInterface)(nil).ListUserLabels), userId)
+}
+
+// ListUserProjects mocks base method.
+func (m *MockDBInterface) ListUserProjects(userId string) ([]common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserProjects", userId)
+	ret0, _ := ret[0].([]common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserProjects indicates an expected call of ListUserProjects.
+func (mr *MockDBInterfaceMockRecorder) ListUserProjects(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProjects", reflect.TypeOf((*MockDBInterface)(nil).ListUserProjects), userId)
+}
+
+// ListUserTasks mocks base method.
+func (m *MockDBInterface) ListUserTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTasks", id, filter, page)
+	ret0, _ := ret[0].(*common.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTasks indicates an expected call of ListUserTasks.
+func (mr *MockDBInterfaceMockRecorder) ListUserTasks(id, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTasks", reflect.TypeOf((*MockDBInterface)(nil).ListUserTasks), id, filter, page)
+}
+
+// ListUsers mocks base method.
+func (m *MockDBInterface) ListUsers(page common.PageRequest) (*common.UserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", page)
+	ret0, _ := ret[0].(*common.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockDBInterfaceMockRecorder) ListUsers(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockDBInterface)(nil).ListUsers), page)
+}
+
+// ListWIPLimits mocks base method.
+func (m *MockDBInterface) ListWIPLimits(userId string) (common.WIPLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWIPLimits", userId)
+	ret0, _ := ret[0].(common.WIPLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWIPLimits indicates an expected call of ListWIPLimits.
+func (mr *MockDBInterfaceMockRecorder) ListWIPLimits(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWIPLimits", reflect.TypeOf((*MockDBInterface)(nil).ListWIPLimits), userId)
+}
+
+// MarkTaskReminded mocks base method.
+func (m *MockDBInterface) MarkTaskReminded(id string, remindedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTaskReminded", id, remindedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTaskReminded indicates an expected call of MarkTaskReminded.
+func (mr *MockDBInterfaceMockRecorder) MarkTaskReminded(id, remindedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTaskReminded", reflect.TypeOf((*MockDBInterface)(nil).MarkTaskReminded), id, remindedAt)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockDBInterface) PurgeDeleted(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockDBInterfaceMockRecorder) PurgeDeleted(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockDBInterface)(nil).PurgeDeleted), before)
+}
+
+// RestoreTask mocks base method.
+func (m *MockDBInterface) RestoreTask(id string, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", id, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockDBInterfaceMockRecorder) RestoreTask(id, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockDBInterface)(nil).RestoreTask), id, deletedAt)
+}
+
+// RestoreUser mocks base method.
+func (m *MockDBInterface) RestoreUser(id string, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", id, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockDBInterfaceMockRecorder) RestoreUser(id, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockDBInterface)(nil).RestoreUser), id, deletedAt)
+}
+
+// SearchTasks mocks base method.
+func (m *MockDBInterface) SearchTasks(search common.TaskSearch) ([]common.TaskSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", search)
+	ret0, _ := ret[0].([]common.TaskSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockDBInterfaceMockRecorder) SearchTasks(search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockDBInterface)(nil).SearchTasks), search)
+}
+
+// SetChecklistPositions mocks base method.
+func (m *MockDBInterface) SetChecklistPositions(positions []common.Position) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChecklistPositions", positions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChecklistPositions indicates an expected call of SetChecklistPositions.
+func (mr *MockDBInterfaceMockRecorder) SetChecklistPositions(positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChecklistPositions", reflect.TypeOf((*MockDBInterface)(nil).SetChecklistPositions), positions)
+}
+
+// SetFeedToken mocks base method.
+func (m *MockDBInterface) SetFeedToken(userId, tokenHash string, createdAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeedToken", userId, tokenHash, createdAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFeedToken indicates an expected call of SetFeedToken.
+func (mr *MockDBInterfaceMockRecorder) SetFeedToken(userId, tokenHash, createdAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeedToken", reflect.TypeOf((*MockDBInterface)(nil).SetFeedToken), userId, tokenHash, createdAt)
+}
+
+// SetTaskLabels mocks base method.
+func (m *MockDBInterface) SetTaskLabels(taskId string, labelIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskLabels", taskId, labelIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskLabels indicates an expected call of SetTaskLabels.
+func (mr *MockDBInterfaceMockRecorder) SetTaskLabels(taskId, labelIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskLabels", reflect.TypeOf((*MockDBInterface)(nil).SetTaskLabels), taskId, labelIds)
+}
+
+// SetTaskPositions mocks base method.
+func (m *MockDBInterface) SetTaskPositions(positions []common.Position) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskPositions", positions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskPositions indicates an expected call of SetTaskPositions.
+func (mr *MockDBInterfaceMockRecorder) SetTaskPositions(positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskPositions", reflect.TypeOf((*MockDBInterface)(nil).SetTaskPositions), positions)
+}
+
+// SetWIPLimits mocks base method.
+func (m *MockDBInterface) SetWIPLimits(userId string, limits common.WIPLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWIPLimits", userId, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWIPLimits indicates an expected call of SetWIPLimits.
+func (mr *MockDBInterfaceMockRecorder) SetWIPLimits(userId, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWIPLimits", reflect.TypeOf((*MockDBInterface)(nil).SetWIPLimits), userId, limits)
+}
+
+// SnoozeTask mocks base method.
+func (m *MockDBInterface) SnoozeTask(id string, until *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeTask", id, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnoozeTask indicates an expected call of SnoozeTask.
+func (mr *MockDBInterfaceMockRecorder) SnoozeTask(id, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeTask", reflect.TypeOf((*MockDBInterface)(nil).SnoozeTask), id, until)
+}
+
+// StopTimeEntry mocks base method.
+func (m *MockDBInterface) StopTimeEntry(id string, stoppedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimeEntry", id, stoppedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopTimeEntry indicates an expected call of StopTimeEntry.
+func (mr *MockDBInterfaceMockRecorder) StopTimeEntry(id, stoppedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimeEntry", reflect.TypeOf((*MockDBInterface)(nil).StopTimeEntry), id, stoppedAt)
+}
+
+// ToggleChecklistItem mocks base method.
+func (m *MockDBInterface) ToggleChecklistItem(taskId, id string) (*common.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistItem", taskId, id)
+	ret0, _ := ret[0].(*common.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleChecklistItem indicates an expected call of ToggleChecklistItem.
+func (mr *MockDBInterfaceMockRecorder) ToggleChecklistItem(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistItem", reflect.TypeOf((*MockDBInterface)(nil).ToggleChecklistItem), taskId, id)
+}
+
+// UpdateComment mocks base method.
+func (m *MockDBInterface) UpdateComment(comment *common.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockDBInterfaceMockRecorder) UpdateComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockDBInterface)(nil).UpdateComment), comment)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockDBInterface) UpdateLabel(label *common.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockDBInterfaceMockRecorder) UpdateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockDBInterface)(nil).UpdateLabel), label)
+}
+
+// UpdateProject mocks base method.
+func (m *MockDBInterface) UpdateProject(project *common.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockDBInterfaceMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockDBInterface)(nil).UpdateProject), project)
+}
+
+// UpdateTask mocks base method.
+func (m *MockDBInterface) UpdateTask(task *common.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockDBInterfaceMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockDBInterface)(nil).UpdateTask), task)
+}
+
+// UpdateUser mocks base method.
+func (m *MockDBInterface) UpdateUser(user *common.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockDBInterfaceMockRecorder) UpdateUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockDBInterface)(nil).UpdateUser), user)
+}
+
+// WakeTask mocks base method.
+func (m *MockDBInterface) WakeTask(id string, snoozedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WakeTask", id, snoozedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WakeTask indicates an expected call of WakeTask.
+func (mr *MockDBInterfaceMockRecorder) WakeTask(id, snoozedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WakeTask", reflect.TypeOf((*MockDBInterface)(nil).WakeTask), id, snoozedUntil)
 }
@@ -2,6 +2,8 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
 	"go.uber.org/zap"
@@ -11,23 +13,120 @@ type DBInterface interface {
 	AddTask(task *common.Task) error
 	UpdateTask(task *common.Task) error
 	GetTask(id string) (*common.Task, error)
-	DeleteTask(id string) error
-	DeleteUserTasks(userId string) error
-	ListTasks() ([]common.Task, error)
-	ListUserTasks(id string) ([]common.Task, error)
+	DeleteTask(id string, deletedAt time.Time) error
+	DeleteTaskTree(id string, deletedAt time.Time) error
+	DeleteUserTasks(userId string, deletedAt time.Time) error
+	ListTasks(filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error)
+	ListUserTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error)
+	IterUserTasks(id string, filter common.TaskFilter) TaskIter
+	SearchTasks(search common.TaskSearch) ([]common.TaskSearchResult, error)
+	ListSubtasks(id string) ([]common.Task, error)
+	ListTaskAncestors(id string) ([]string, error)
+	GetTaskProgress(id string) (done int, total int, err error)
+	ListDueReminders(now time.Time) ([]common.Task, error)
+	MarkTaskReminded(id string, remindedAt time.Time) error
+	SnoozeTask(id string, until *time.Time) error
+	ListSnoozedTasks(now time.Time) ([]common.Task, error)
+	WakeTask(id string, snoozedUntil time.Time) (bool, error)
+	AddTaskRevision(revision *common.TaskRevision) error
+	ListTaskRevisions(taskId string) ([]common.TaskRevision, error)
+	ListRevisionsOfTasks(taskIds []string) (map[string][]common.TaskRevision, error)
+	ListTaskPositions(userId string) ([]common.Position, error)
+	LastTaskPosition(userId string) (string, error)
+	SetTaskPositions(positions []common.Position) error
+
+	AddTaskDependency(dependency *common.TaskDependency) error
+	DeleteTaskDependency(dependency *common.TaskDependency) error
+	ListTaskDependencies(id string) ([]common.Task, error)
+	ListTransitiveDependencies(id string) ([]string, error)
+	CountOpenDependencies(id string) (int, error)
+
+	AddProject(project *common.Project) error
+	UpdateProject(project *common.Project) error
+	GetProject(id string) (*common.Project, error)
+	DeleteProject(id string, deletion common.ProjectDeletion) error
+	ListProjects() ([]common.Project, error)
+	ListUserProjects(userId string) ([]common.Project, error)
+
+	AddLabel(label *common.Label) error
+	UpdateLabel(label *common.Label) error
+	GetLabel(id string) (*common.Label, error)
+	DeleteLabel(id string) error
+	ListUserLabels(userId string) ([]common.Label, error)
+	SetTaskLabels(taskId string, labelIds []string) error
+	CopyTaskLabels(fromId string, toId string) error
+	ListTaskLabels(taskIds []string) (map[string][]string, error)
+
+	AddComment(comment *common.Comment) error
+	UpdateComment(comment *common.Comment) error
+	GetComment(id string) (*common.Comment, error)
+	DeleteComment(id string) error
+	ListTaskComments(taskId string) ([]common.Comment, error)
+	ListCommentsOfTasks(taskIds []string) (map[string][]common.Comment, error)
+
+	AddAttachment(attachment *common.Attachment) error
+	GetAttachment(id string) (*common.Attachment, error)
+	DeleteAttachment(id string) error
+	ListTaskAttachments(taskId string) ([]common.Attachment, error)
+
+	AddTimeEntry(entry *common.TimeEntry) error
+	GetRunningTimeEntry(userId string) (*common.TimeEntry, error)
+	StopTimeEntry(id string, stoppedAt time.Time) error
+	ListTimeEntries(userId string, from time.Time, to time.Time) ([]common.TimeEntry, error)
+
+	AddChecklistItem(item *common.ChecklistItem) error
+	GetChecklistItem(id string) (*common.ChecklistItem, error)
+	ToggleChecklistItem(taskId string, id string) (*common.ChecklistItem, error)
+	DeleteChecklistItem(id string) error
+	ListChecklistItems(taskIds []string) (map[string][]common.ChecklistItem, error)
+	ListChecklistPositions(taskId string) ([]common.Position, error)
+	LastChecklistPosition(taskId string) (string, error)
+	SetChecklistPositions(positions []common.Position) error
+
+	AddTemplate(template *common.Template) error
+	GetTemplate(id string) (*common.Template, error)
+	DeleteTemplate(id string) error
+	ListTemplates(userId string) ([]common.Template, error)
+
+	ListWIPLimits(userId string) (common.WIPLimits, error)
+	SetWIPLimits(userId string, limits common.WIPLimits) error
+	CountStateTasks(userId string, state common.TaskState) (int, error)
+
+	SetFeedToken(userId string, tokenHash string, createdAt time.Time) error
+	GetFeedTokenHash(userId string) (string, error)
+	DeleteFeedToken(userId string) error
 
 	AddUser(user *common.User) error
 	UpdateUser(user *common.User) error
 	GetUser(id string) (*common.User, error)
-	DeleteUser(id string) error
-	ListUsers() ([]common.User, error)
+	DeleteUser(id string, deletedAt time.Time) error
+	ListUsers(page common.PageRequest) (*common.UserPage, error)
+
+	ListDeletedTasks(userId string) ([]common.Task, error)
+	GetDeletedTask(id string) (*common.Task, error)
+	RestoreTask(id string, deletedAt time.Time) error
+	ListDeletedUsers(id string) ([]common.User, error)
+	GetDeletedUser(id string) (*common.User, error)
+	RestoreUser(id string, deletedAt time.Time) error
+	ListDeletedAttachments(before time.Time) ([]common.Attachment, error)
+	PurgeDeleted(before time.Time) (int, error)
+
+	InTx(fn func(tx DBInterface) error) error
 }
 
+// ErrVersionConflict is returned when a row is updated from a version it is no longer at, because
+// it changed since it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
 type Config struct {
 	Logger *zap.Logger
 }
 
 type DB struct {
-	db     *sql.DB
+	db *sql.DB
+	// tx is the transaction the statements run within, if any, and depth how many InTx calls
+	// it is nested in.
+	tx     *sql.Tx
+	depth  int
 	logger *zap.Logger
 }
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a page is requested with a cursor that was not returned with a
+// page of the same list.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor returns the cursor of the page following the item with the given sort keys. Cursors
+// are opaque to the clients: base64 encoded JSON arrays of the keys.
+func encodeCursor(keys ...string) string {
+	data, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the n sort keys of the item a cursor follows. The last key of every list is
+// the id of the item, which breaks the ties of the other keys.
+func decodeCursor(cursor string, n int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	keys := make([]string, 0, n)
+	if err := json.Unmarshal(data, &keys); err != nil || len(keys) != n {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(keys[n-1]); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return keys, nil
+}
+
+// addPage adds the condition keeping the items past the cursor of a page of a list ordered by the
+// given columns, and returns the LIMIT clause of the page. One more item than the page holds is
+// read, telling whether there is a next page.
+func addPage(conds *conditions, page common.PageRequest, columns ...string) (string, error) {
+	if page.Cursor != "" {
+		keys, err := decodeCursor(page.Cursor, len(columns))
+		if err != nil {
+			return "", err
+		}
+
+		args := make([]any, len(keys))
+		for i, key := range keys {
+			args[i] = key
+		}
+		conds.add("("+strings.Join(columns, ", ")+") > ("+placeholders(len(keys))+")", args...)
+	}
+
+	if page.Limit == 0 {
+		return "", nil
+	}
+	return "\n\t\tLIMIT " + strconv.Itoa(page.Limit+1), nil
+}
+
+// pageItems drops the item read past the end of a page, returning the items of the page and the
+// cursor of the next one, made of the sort keys of the last item of the page.
+func pageItems[T any](items []T, page common.PageRequest, keys func(item T) []string) ([]T, string) {
+	if page.Limit == 0 || len(items) <= page.Limit {
+		return items, ""
+	}
+
+	items = items[:page.Limit]
+	return items, encodeCursor(keys(items[len(items)-1])...)
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	keys, err := decodeCursor(encodeCursor("a5", "00000000-0000-0000-0000-000000000001"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a5", "00000000-0000-0000-0000-000000000001"}, keys)
+
+	tests := map[string]string{
+		"not base64":     "not a cursor",
+		"not json":       "bm90IGpzb24",
+		"too few keys":   encodeCursor("00000000-0000-0000-0000-000000000001"),
+		"too many keys":  encodeCursor("a5", "b", "00000000-0000-0000-0000-000000000001"),
+		"id not an uuid": encodeCursor("a5", "0001"),
+	}
+
+	for name, cursor := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeCursor(cursor, 2)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
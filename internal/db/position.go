@@ -0,0 +1,78 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+// ListTaskPositions returns the positions of the tasks of a user, in order. Within a transaction
+// the tasks stay locked until it ends, so the positions cannot change before they are saved.
+func (db *DB) ListTaskPositions(userId string) ([]common.Position, error) {
+	results, err := db.conn().Query(`
+		SELECT id, position
+		FROM public.task
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY position, id
+		FOR UPDATE`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving task positions.")
+		return nil, err
+	}
+	defer results.Close()
+
+	positions := make([]common.Position, 0)
+	for results.Next() {
+		position := common.Position{}
+		if err := results.Scan(&position.Id, &position.Position); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
+
+// LastTaskPosition returns the position of the last task of a user, empty when the user has no tasks.
+func (db *DB) LastTaskPosition(userId string) (string, error) {
+	var position string
+	err := db.conn().QueryRow(`
+		SELECT position
+		FROM public.task
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY position DESC
+		LIMIT 1`, userId).Scan(&position)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		db.logger.Error("Error retrieving last task position.")
+		return "", err
+	}
+
+	return position, nil
+}
+
+// SetTaskPositions updates the positions of a set of tasks in a single statement.
+func (db *DB) SetTaskPositions(positions []common.Position) error {
+	ids := make([]string, len(positions))
+	keys := make([]string, len(positions))
+	for i, position := range positions {
+		ids[i] = position.Id
+		keys[i] = position.Position
+	}
+
+	_, err := db.conn().Exec(`
+		UPDATE public.task t
+		SET position = p.position
+		FROM unnest($1::uuid[], $2::varchar[]) AS p(id, position)
+		WHERE t.id = p.id
+	`, pq.Array(ids), pq.Array(keys))
+	if err != nil {
+		db.logger.Error("Error updating task positions.")
+		return err
+	}
+
+	return nil
+}
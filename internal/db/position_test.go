@@ -0,0 +1,111 @@
+package db
+
+import (
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (d *dbTestSuite) TestListTaskPositions() {
+	errList := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.Position
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: []common.Position{{Id: "0001", Position: "a"}, {Id: "0002", Position: "b"}},
+		},
+		"fail": {
+			dbError:     errList,
+			expectedErr: errList,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT id, position FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL ORDER BY position, id FOR UPDATE$").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).
+					AddRow("0001", "a").
+					AddRow("0002", "b"))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTaskPositions("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestLastTaskPosition() {
+	errLast := errors.New("any error")
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp string
+		expectedErr  error
+	}{
+		"success": {
+			dbRows:       sqlmock.NewRows([]string{"position"}).AddRow("b"),
+			expectedResp: "b",
+		},
+		"no tasks": {
+			dbRows: sqlmock.NewRows([]string{"position"}),
+		},
+		"fail": {
+			dbError:     errLast,
+			expectedErr: errLast,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockLast := d.mock.ExpectQuery("SELECT position FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL ORDER BY position DESC LIMIT 1").WithArgs("00001")
+			if test.dbError == nil {
+				mockLast.WillReturnRows(test.dbRows)
+			} else {
+				mockLast.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.LastTaskPosition("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestSetTaskPositions() {
+	errSet := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError     error
+		expectedErr error
+	}{
+		"success": {},
+		"fail": {
+			dbError:     errSet,
+			expectedErr: errSet,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockSet := d.mock.ExpectExec("UPDATE public.task t SET position = p.position FROM unnest\\(\\$1::uuid\\[\\], \\$2::varchar\\[\\]\\)").
+				WithArgs(`{"0001","0002"}`, `{"a","b"}`)
+			if test.dbError == nil {
+				mockSet.WillReturnResult(sqlmock.NewResult(0, 2))
+			} else {
+				mockSet.WillReturnError(test.dbError)
+			}
+
+			err := d.db.SetTaskPositions([]common.Position{{Id: "0001", Position: "a"}, {Id: "0002", Position: "b"}})
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
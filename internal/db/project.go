@@ -0,0 +1,148 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+const projectColumns = `id, user_id, name`
+
+func (db *DB) AddProject(project *common.Project) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.project(id, user_id, name)
+		VALUES($1, $2, $3)
+	`, project.Id, project.UserId, project.Name)
+	if err != nil {
+		db.logger.Error("Error inserting project.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) UpdateProject(project *common.Project) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.project
+		SET name = $1
+		WHERE id = $2
+	`, project.Name, project.Id)
+	if err != nil {
+		db.logger.Error("Error updating project.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) GetProject(id string) (*common.Project, error) {
+	results, err := db.conn().Query(`
+		SELECT `+projectColumns+`
+		FROM public.project
+		WHERE id = $1`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving project.")
+		return nil, err
+	}
+	defer results.Close()
+
+	project := common.Project{}
+	for results.Next() {
+		err = results.Scan(
+			&project.Id,
+			&project.UserId,
+			&project.Name)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &project, nil
+}
+
+// DeleteProject deletes a project, archiving or moving its tasks as told by deletion.
+func (db *DB) DeleteProject(id string, deletion common.ProjectDeletion) error {
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return err
+	}
+	defer tx.Rollback()
+
+	if deletion.Archive {
+		_, err = tx.Exec(`
+			UPDATE public.task
+			SET project_id = NULL, archived_at = now(), version = version + 1
+			WHERE project_id = $1
+		`, id)
+	} else {
+		_, err = tx.Exec(`
+			UPDATE public.task
+			SET project_id = $2, version = version + 1
+			WHERE project_id = $1
+		`, id, deletion.MoveTo)
+	}
+	if err != nil {
+		db.logger.Error("Error releasing project tasks.")
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM public.project WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error deleting project.")
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) ListProjects() ([]common.Project, error) {
+	results, err := db.conn().Query(`
+		SELECT ` + projectColumns + `
+		FROM public.project
+		ORDER BY name`)
+	if err != nil {
+		db.logger.Error("Error retrieving projects.")
+		return nil, err
+	}
+	defer results.Close()
+
+	return db.scanProjects(results)
+}
+
+func (db *DB) ListUserProjects(userId string) ([]common.Project, error) {
+	results, err := db.conn().Query(`
+		SELECT `+projectColumns+`
+		FROM public.project
+		WHERE user_id = $1
+		ORDER BY name`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving user projects.")
+		return nil, err
+	}
+	defer results.Close()
+
+	return db.scanProjects(results)
+}
+
+func (db *DB) scanProjects(results *sql.Rows) ([]common.Project, error) {
+	projects := make([]common.Project, 0)
+	for results.Next() {
+		project := common.Project{}
+		err := results.Scan(
+			&project.Id,
+			&project.UserId,
+			&project.Name)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
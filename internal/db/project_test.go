@@ -0,0 +1,220 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+var projectColumnNames = []string{"id", "user_id", "name"}
+
+func (d *dbTestSuite) TestAddProject() {
+	errAddProject := errors.New("error inserting project")
+	project := &common.Project{
+		Id:     "0001",
+		UserId: "00001",
+		Name:   "house",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errAddProject,
+			expectedResp: errAddProject,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.project").WithArgs(project.Id, project.UserId, project.Name)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddProject(project)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestUpdateProject() {
+	errUpdateProject := errors.New("error updating project")
+	project := &common.Project{
+		Id:     "0001",
+		UserId: "00001",
+		Name:   "house",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			dbError:      errUpdateProject,
+			expectedResp: errUpdateProject,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec("UPDATE public.project").WithArgs(project.Name, project.Id)
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			err := d.db.UpdateProject(project)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetProject() {
+	errGetProject := errors.New("any error")
+	project := &common.Project{
+		Id:     "0001",
+		UserId: "00001",
+		Name:   "house",
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp *common.Project
+		expectedErr  error
+	}{
+		"success": {
+			dbRows:       sqlmock.NewRows(projectColumnNames).AddRow(project.Id, project.UserId, project.Name),
+			expectedResp: project,
+		},
+		"not found": {
+			dbRows:       sqlmock.NewRows(projectColumnNames),
+			expectedResp: &common.Project{},
+		},
+		"fail": {
+			dbError:     errGetProject,
+			expectedErr: errGetProject,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.project WHERE id = (.+)").WithArgs(project.Id)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetProject(project.Id)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteProject() {
+	errDeleteProject := errors.New("error deleting project")
+	moveTo := "0002"
+
+	tests := map[string]struct {
+		deletion      common.ProjectDeletion
+		expectedQuery string
+		args          []driver.Value
+		dbError       error
+		expectedResp  error
+	}{
+		"archive": {
+			deletion:      common.ProjectDeletion{Archive: true},
+			expectedQuery: `UPDATE public.task SET project_id = NULL, archived_at = now\(\), version = version \+ 1 WHERE project_id = \$1`,
+			args:          []driver.Value{"0001"},
+		},
+		"move": {
+			deletion:      common.ProjectDeletion{MoveTo: &moveTo},
+			expectedQuery: `UPDATE public.task SET project_id = \$2, version = version \+ 1 WHERE project_id = \$1`,
+			args:          []driver.Value{"0001", moveTo},
+		},
+		"fail": {
+			deletion:      common.ProjectDeletion{Archive: true},
+			expectedQuery: `UPDATE public.task SET project_id = NULL`,
+			args:          []driver.Value{"0001"},
+			dbError:       errDeleteProject,
+			expectedResp:  errDeleteProject,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			d.mock.ExpectExec(test.expectedQuery).WithArgs(test.args...).WillReturnResult(sqlmock.NewResult(0, 2))
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.project").WithArgs("0001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mock.ExpectCommit()
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+				d.mock.ExpectRollback()
+			}
+
+			err := d.db.DeleteProject("0001", test.deletion)
+			d.Assert().Equal(test.expectedResp, err)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListUserProjects() {
+	errListProjects := errors.New("any error")
+	projects := []common.Project{
+		{Id: "0001", UserId: "00001", Name: "garden"},
+		{Id: "0002", UserId: "00001", Name: "house"},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []common.Project
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(projectColumnNames).
+				AddRow(projects[0].Id, projects[0].UserId, projects[0].Name).
+				AddRow(projects[1].Id, projects[1].UserId, projects[1].Name),
+			expectedResp: projects,
+		},
+		"fail": {
+			dbError:     errListProjects,
+			expectedErr: errListProjects,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.project WHERE user_id = (.+) ORDER BY name").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListUserProjects("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
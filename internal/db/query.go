@@ -0,0 +1,37 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// conditions accumulates the WHERE conditions of a query together with their arguments.
+type conditions struct {
+	clauses []string
+	args    []any
+}
+
+// add appends a condition written with ? placeholders, which are renumbered to $n
+// following the arguments already added.
+func (c *conditions) add(clause string, args ...any) {
+	var b strings.Builder
+	for _, r := range clause {
+		if r == '?' {
+			c.args = append(c.args, args[0])
+			args = args[1:]
+			fmt.Fprintf(&b, "$%d", len(c.args))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	c.clauses = append(c.clauses, b.String())
+}
+
+// where returns the WHERE clause joining all the conditions with AND, or an empty string when there is none.
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return "\n\t\tWHERE " + strings.Join(c.clauses, " AND ")
+}
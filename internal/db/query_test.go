@@ -0,0 +1,19 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions(t *testing.T) {
+	conds := conditions{}
+	assert.Equal(t, "", conds.where())
+
+	conds.add("user_id = ?", "0001")
+	conds.add("state NOT IN (?, ?)", "done", "cancelled")
+	conds.add("parent_id IS NULL")
+
+	assert.Equal(t, "\n\t\tWHERE user_id = $1 AND state NOT IN ($2, $3) AND parent_id IS NULL", conds.where())
+	assert.Equal(t, []any{"0001", "done", "cancelled"}, conds.args)
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+const revisionColumns = `id, task_id, revision, COALESCE(actor_id::varchar, ''), created_at, changes`
+
+// AddTaskRevision saves a revision of a task, numbering it after the latest revision of the task.
+func (db *DB) AddTaskRevision(revision *common.TaskRevision) error {
+	changes, err := json.Marshal(revision.Changes)
+	if err != nil {
+		db.logger.Error("Error encoding revision changes.")
+		return err
+	}
+
+	// the revision is numbered under the lock of the task row its update took earlier in the same
+	// transaction, and a concurrent revision still violates task_revision_un instead of reusing the number
+	err = db.conn().QueryRow(`
+		INSERT INTO public.task_revision(id, task_id, revision, actor_id, created_at, changes)
+		VALUES($1, $2, (SELECT COALESCE(MAX(revision), 0) + 1 FROM public.task_revision WHERE task_id = $2), NULLIF($3, '')::uuid, $4, $5)
+		RETURNING revision
+	`, revision.Id, revision.TaskId, revision.ActorId, revision.CreatedAt, changes).Scan(&revision.Revision)
+	if err != nil {
+		db.logger.Error("Error inserting task revision.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTaskRevisions returns the revisions of a task, oldest first.
+func (db *DB) ListTaskRevisions(taskId string) ([]common.TaskRevision, error) {
+	results, err := db.conn().Query(`
+		SELECT `+revisionColumns+`
+		FROM public.task_revision
+		WHERE task_id = $1
+		ORDER BY revision`, taskId)
+	if err != nil {
+		db.logger.Error("Error retrieving task revisions.")
+		return nil, err
+	}
+	defer results.Close()
+
+	revisions := make([]common.TaskRevision, 0)
+	for results.Next() {
+		revision := common.TaskRevision{}
+		if err := db.scanRevision(results, &revision); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// ListRevisionsOfTasks returns the revisions of the given tasks by task id, oldest first.
+func (db *DB) ListRevisionsOfTasks(taskIds []string) (map[string][]common.TaskRevision, error) {
+	results, err := db.conn().Query(`
+		SELECT `+revisionColumns+`
+		FROM public.task_revision
+		WHERE task_id = ANY($1::uuid[])
+		ORDER BY task_id, revision`, pq.Array(taskIds))
+	if err != nil {
+		db.logger.Error("Error retrieving task revisions.")
+		return nil, err
+	}
+	defer results.Close()
+
+	revisions := make(map[string][]common.TaskRevision)
+	for results.Next() {
+		revision := common.TaskRevision{}
+		if err := db.scanRevision(results, &revision); err != nil {
+			return nil, err
+		}
+
+		revisions[revision.TaskId] = append(revisions[revision.TaskId], revision)
+	}
+
+	return revisions, nil
+}
+
+// scanRevision scans a row selecting revisionColumns, decoding the changes of the revision.
+func (db *DB) scanRevision(results *sql.Rows, revision *common.TaskRevision) error {
+	var changes []byte
+	err := results.Scan(
+		&revision.Id,
+		&revision.TaskId,
+		&revision.Revision,
+		&revision.ActorId,
+		&revision.CreatedAt,
+		&changes)
+	if err != nil {
+		db.logger.Error("Error mapping database data to struct.")
+		return err
+	}
+	if err := json.Unmarshal(changes, &revision.Changes); err != nil {
+		db.logger.Error("Error decoding revision changes.")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+var revisionColumnNames = []string{"id", "task_id", "revision", "actor_id", "created_at", "changes"}
+
+func (d *dbTestSuite) TestAddTaskRevision() {
+	errAddRevision := errors.New("error inserting revision")
+	now := time.Now()
+	changes := []common.FieldChange{
+		{Field: "state", Old: json.RawMessage(`"to_do"`), New: json.RawMessage(`"done"`)},
+	}
+
+	tests := map[string]struct {
+		dbError          error
+		expectedRevision int
+		expectedErr      error
+	}{
+		"success": {
+			expectedRevision: 3,
+		},
+		"fail": {
+			dbError:     errAddRevision,
+			expectedErr: errAddRevision,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			revision := &common.TaskRevision{Id: "0001", TaskId: "00001", ActorId: "000001", CreatedAt: now, Changes: changes}
+
+			mockInsert := d.mock.ExpectQuery("INSERT INTO public.task_revision(.+) RETURNING revision").
+				WithArgs("0001", "00001", "000001", now, []byte(`[{"field":"state","old":"to_do","new":"done"}]`))
+			if test.dbError == nil {
+				mockInsert.WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(3))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddTaskRevision(revision)
+			d.Assert().Equal(test.expectedErr, err)
+			d.Assert().Equal(test.expectedRevision, revision.Revision)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTaskRevisions() {
+	errListRevisions := errors.New("any error")
+	now := time.Now()
+	revisions := []common.TaskRevision{
+		{
+			Id:        "0001",
+			TaskId:    "00001",
+			Revision:  1,
+			ActorId:   "000001",
+			CreatedAt: now,
+			Changes: []common.FieldChange{
+				{Field: "description", Old: json.RawMessage(`"buy milk"`), New: json.RawMessage(`"buy bread"`)},
+			},
+		},
+		{
+			Id:        "0002",
+			TaskId:    "00001",
+			Revision:  2,
+			CreatedAt: now,
+			Changes: []common.FieldChange{
+				{Field: "due_at", Old: json.RawMessage(`null`), New: json.RawMessage(`"2024-05-01T10:00:00Z"`)},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []common.TaskRevision
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(revisionColumnNames).
+				AddRow("0001", "00001", 1, "000001", now, []byte(`[{"field":"description","old":"buy milk","new":"buy bread"}]`)).
+				AddRow("0002", "00001", 2, "", now, []byte(`[{"field":"due_at","old":null,"new":"2024-05-01T10:00:00Z"}]`)),
+			expectedResp: revisions,
+		},
+		"fail": {
+			dbError:     errListRevisions,
+			expectedErr: errListRevisions,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.task_revision WHERE task_id = (.+) ORDER BY revision").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTaskRevisions("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListRevisionsOfTasks() {
+	errListRevisions := errors.New("any error")
+	now := time.Now()
+	revisions := []common.TaskRevision{
+		{
+			Id:        "0001",
+			TaskId:    "00001",
+			Revision:  1,
+			ActorId:   "000001",
+			CreatedAt: now,
+			Changes: []common.FieldChange{
+				{Field: "description", Old: json.RawMessage(`"buy milk"`), New: json.RawMessage(`"buy bread"`)},
+			},
+		},
+		{
+			Id:        "0002",
+			TaskId:    "00002",
+			Revision:  1,
+			CreatedAt: now,
+			Changes: []common.FieldChange{
+				{Field: "state", Old: json.RawMessage(`"to_do"`), New: json.RawMessage(`"done"`)},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp map[string][]common.TaskRevision
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(revisionColumnNames).
+				AddRow("0001", "00001", 1, "000001", now, []byte(`[{"field":"description","old":"buy milk","new":"buy bread"}]`)).
+				AddRow("0002", "00002", 1, "", now, []byte(`[{"field":"state","old":"to_do","new":"done"}]`)),
+			expectedResp: map[string][]common.TaskRevision{"00001": revisions[:1], "00002": revisions[1:]},
+		},
+		"fail": {
+			dbError:     errListRevisions,
+			expectedErr: errListRevisions,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.task_revision WHERE task_id = ANY(.+) ORDER BY task_id, revision").
+				WithArgs(`{"00001","00002"}`)
+			if test.dbError == nil {
+				mockList.WillReturnRows(test.dbRows)
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListRevisionsOfTasks([]string{"00001", "00002"})
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
@@ -1,15 +1,57 @@
 package db
 
 import (
+	"database/sql"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
 )
 
-func (db *DB) AddTask(task *common.Task) error {
-	_, err := db.db.Exec(`
-		INSERT INTO public.task(id, user_id, description, state)
-		VALUES($1, $2, $3, $4)
-	`, task.Id, task.UserId, task.Description, task.State)
+const taskColumns = `id, user_id, description, state, due_at, remind_at, completed_at, parent_id, recurrence, project_id, archived_at, position, snoozed_until, created_at, version`
+
+// TaskIter yields the tasks read by a query one row at a time, so that long lists need not fit
+// in memory. The query runs when the iterator is ranged over and a failure ends it with an error.
+type TaskIter = iter.Seq2[common.Task, error]
+
+// prefixedTaskColumns qualifies the task columns with a table alias, for queries joining other tables.
+func prefixedTaskColumns(alias string) string {
+	columns := strings.Split(taskColumns, ", ")
+	for i := range columns {
+		columns[i] = alias + "." + columns[i]
+	}
+	return strings.Join(columns, ", ")
+}
 
+// scanTask scans a row selecting taskColumns, followed by any extra columns scanned into extra.
+func scanTask(results *sql.Rows, task *common.Task, extra ...any) error {
+	return results.Scan(append([]any{
+		&task.Id,
+		&task.UserId,
+		&task.Description,
+		&task.State,
+		&task.DueAt,
+		&task.RemindAt,
+		&task.CompletedAt,
+		&task.ParentId,
+		&task.Recurrence,
+		&task.ProjectId,
+		&task.ArchivedAt,
+		&task.Position,
+		&task.SnoozedUntil,
+		&task.CreatedAt,
+		&task.Version}, extra...)...)
+}
+
+func (db *DB) AddTask(task *common.Task) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.task(id, user_id, description, state, due_at, remind_at, completed_at, parent_id, recurrence, project_id, position, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, task.Id, task.UserId, task.Description, task.State, task.DueAt, task.RemindAt, task.CompletedAt, task.ParentId, task.Recurrence, task.ProjectId, task.Position, task.CreatedAt)
 	if err != nil {
 		db.logger.Error("Error inserting task.")
 		return err
@@ -18,65 +60,117 @@ func (db *DB) AddTask(task *common.Task) error {
 	return nil
 }
 
+// UpdateTask updates a task from its version, bumping it, and returns ErrVersionConflict when the
+// task is at another version.
 func (db *DB) UpdateTask(task *common.Task) error {
-	_, err := db.db.Exec(`
+	// a new reminder time re-arms the reminder
+	result, err := db.conn().Exec(`
 		UPDATE public.task
-		SET user_id = $1, description = $2, state = $3
-		WHERE id = $4
-	`, task.UserId, task.Description, task.State, task.Id)
+		SET user_id = $1, description = $2, state = $3, due_at = $4, remind_at = $5,
+			reminded_at = CASE WHEN remind_at IS DISTINCT FROM $5 THEN NULL ELSE reminded_at END,
+			completed_at = $6, parent_id = $7, recurrence = $8, project_id = $9, version = version + 1
+		WHERE id = $10 AND version = $11
+	`, task.UserId, task.Description, task.State, task.DueAt, task.RemindAt, task.CompletedAt, task.ParentId, task.Recurrence, task.ProjectId, task.Id, task.Version)
+	if err != nil {
+		db.logger.Error("Error updating task.")
+		return err
+	}
 
+	rows, err := result.RowsAffected()
 	if err != nil {
 		db.logger.Error("Error updating task.")
 		return err
 	}
+	if rows == 0 {
+		return ErrVersionConflict
+	}
+	task.Version++
 
 	return nil
 }
 
 func (db *DB) GetTask(id string) (*common.Task, error) {
-	results, err := db.db.Query(`
-		SELECT id, user_id, description, state 
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`
 		FROM public.task
-		WHERE id= $1`, id)
-
+		WHERE id= $1 AND deleted_at IS NULL`, id)
 	if err != nil {
 		db.logger.Error("Error retrieving task.")
 		return nil, err
 	}
+	defer results.Close()
 
 	task := common.Task{}
 	for results.Next() {
-		err = results.Scan(
-			&task.Id,
-			&task.UserId,
-			&task.Description,
-			&task.State)
+		err = scanTask(results, &task)
 		if err != nil {
 			db.logger.Error("Error mapping database data to struct.")
 			return nil, err
 		}
 	}
+
 	return &task, nil
 }
 
-func (db *DB) DeleteTask(id string) error {
-	_, err := db.db.Exec(`
-		DELETE FROM public.task WHERE id = $1
+// DeleteTask moves a task to the trash and its subtasks up to the deleted task's parent.
+func (db *DB) DeleteTask(id string, deletedAt time.Time) error {
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE public.task
+		SET parent_id = (SELECT parent_id FROM public.task WHERE id = $1), version = version + 1
+		WHERE parent_id = $1
 	`, id)
+	if err != nil {
+		db.logger.Error("Error reparenting subtasks.")
+		return err
+	}
 
+	_, err = tx.Exec(`
+		UPDATE public.task
+		SET deleted_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, deletedAt)
 	if err != nil {
 		db.logger.Error("Error deleting task.")
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (db *DB) DeleteUserTasks(userId string) error {
-	_, err := db.db.Exec(`
-		DELETE FROM public.task WHERE user_id = $1
-	`, userId)
+// DeleteTaskTree moves a task to the trash together with all of its subtasks, at any depth.
+func (db *DB) DeleteTaskTree(id string, deletedAt time.Time) error {
+	_, err := db.conn().Exec(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM public.task WHERE id = $1
+			UNION
+			SELECT t.id FROM public.task t JOIN tree ON t.parent_id = tree.id
+		)
+		UPDATE public.task
+		SET deleted_at = $2
+		WHERE id IN (SELECT id FROM tree) AND deleted_at IS NULL
+	`, id, deletedAt)
+	if err != nil {
+		db.logger.Error("Error deleting task tree.")
+		return err
+	}
 
+	return nil
+}
+
+// DeleteUserTasks moves all the tasks of a user to the trash.
+func (db *DB) DeleteUserTasks(userId string, deletedAt time.Time) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.task
+		SET deleted_at = $2
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`, userId, deletedAt)
 	if err != nil {
 		db.logger.Error("Error deleting user tasks.")
 		return err
@@ -85,53 +179,363 @@ func (db *DB) DeleteUserTasks(userId string) error {
 	return nil
 }
 
-func (db *DB) ListTasks() ([]common.Task, error) {
-	results, err := db.db.Query(`
-		SELECT id, user_id, description, state 
-		FROM public.task`)
+// ListTasks returns a page of the tasks matching filter, ordered by id.
+func (db *DB) ListTasks(filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	conds := conditions{}
+	if err := addTaskFilter(&conds, filter); err != nil {
+		return nil, err
+	}
+	limit, err := addPage(&conds, page, "id")
+	if err != nil {
+		return nil, err
+	}
 
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`
+		FROM public.task`+conds.where()+`
+		ORDER BY id`+limit, conds.args...)
 	if err != nil {
 		return nil, err
 	}
+	defer results.Close()
 
-	tasks := make([]common.Task, 0)
+	tasks, err := db.scanTasks(results)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &common.TaskPage{}
+	result.Items, result.NextCursor = pageItems(tasks, page, func(task common.Task) []string {
+		return []string{task.Id}
+	})
+	return result, nil
+}
+
+// ListUserTasks returns a page of the tasks of a user in their custom order.
+func (db *DB) ListUserTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	query, args, err := userTasksQuery(id, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	results, err := db.conn().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	tasks, err := db.scanTasks(results)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &common.TaskPage{}
+	result.Items, result.NextCursor = pageItems(tasks, page, func(task common.Task) []string {
+		return []string{task.Position, task.Id}
+	})
+	return result, nil
+}
+
+// IterUserTasks returns the tasks of a user in their custom order, like ListUserTasks, but reads
+// them one row at a time while the iterator is ranged over.
+func (db *DB) IterUserTasks(id string, filter common.TaskFilter) TaskIter {
+	return func(yield func(common.Task, error) bool) {
+		query, args, err := userTasksQuery(id, filter, common.PageRequest{})
+		if err != nil {
+			yield(common.Task{}, err)
+			return
+		}
+		results, err := db.conn().Query(query, args...)
+		if err != nil {
+			db.logger.Error("Error retrieving user tasks.")
+			yield(common.Task{}, err)
+			return
+		}
+		defer results.Close()
+
+		for results.Next() {
+			task := common.Task{}
+			if err := scanTask(results, &task); err != nil {
+				db.logger.Error("Error mapping database data to struct.")
+				yield(common.Task{}, err)
+				return
+			}
+			if !yield(task, nil) {
+				return
+			}
+		}
+		if err := results.Err(); err != nil {
+			db.logger.Error("Error retrieving user tasks.")
+			yield(common.Task{}, err)
+		}
+	}
+}
+
+// userTasksQuery builds the query selecting a page of the tasks of a user matching filter.
+func userTasksQuery(id string, filter common.TaskFilter, page common.PageRequest) (string, []any, error) {
+	conds := conditions{}
+	conds.add("user_id = ?", id)
+	if err := addTaskFilter(&conds, filter); err != nil {
+		return "", nil, err
+	}
+	limit, err := addPage(&conds, page, "position", "id")
+	if err != nil {
+		return "", nil, err
+	}
+
+	return `
+		SELECT ` + taskColumns + `
+		FROM public.task` + conds.where() + `
+		ORDER BY position, id` + limit, conds.args, nil
+}
+
+// SearchTasks returns the tasks whose description matches a full-text search, most relevant first.
+// Archived and deleted tasks are left out.
+func (db *DB) SearchTasks(search common.TaskSearch) ([]common.TaskSearchResult, error) {
+	// the query is the first argument, so the ranking and the highlighting refer to it as $1
+	conds := conditions{}
+	conds.add("search_vector @@ websearch_to_tsquery('english', ?)", search.Query)
+	conds.add("archived_at IS NULL")
+	conds.add("deleted_at IS NULL")
+	if search.UserId != "" {
+		conds.add("user_id = ?", search.UserId)
+	}
+	if search.State != "" {
+		conds.add("state = ?", search.State)
+	}
+	args := append(conds.args, search.Limit)
+
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`,
+			ts_rank(search_vector, websearch_to_tsquery('english', $1)) AS rank,
+			ts_headline('english', description, websearch_to_tsquery('english', $1), 'MaxFragments=2') AS snippet
+		FROM public.task`+conds.where()+`
+		ORDER BY rank DESC, id
+		LIMIT $`+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		db.logger.Error("Error searching tasks.")
+		return nil, err
+	}
+	defer results.Close()
+
+	tasks := make([]common.TaskSearchResult, 0)
 	for results.Next() {
-		task := common.Task{}
-		err = results.Scan(
-			&task.Id,
-			&task.UserId,
-			&task.Description,
-			&task.State)
+		result := common.TaskSearchResult{}
+		err := scanTask(results, &result.Task, &result.Rank, &result.Snippet)
 		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
 			return nil, err
 		}
-		tasks = append(tasks, task)
+
+		tasks = append(tasks, result)
 	}
+
 	return tasks, nil
 }
 
-func (db *DB) ListUserTasks(id string) ([]common.Task, error) {
-	results, err := db.db.Query(`
-		SELECT id, user_id, description, state 
+// addTaskFilter adds the conditions of a task filter to the conditions of a query over public.task.
+// Deleted tasks are always left out. The errors about the filter expression wrap
+// filter.ErrInvalidExpression.
+func addTaskFilter(conds *conditions, filter common.TaskFilter) error {
+	conds.add("deleted_at IS NULL")
+
+	if !filter.IncludeArchived {
+		conds.add("archived_at IS NULL")
+	}
+
+	if !filter.IncludeSnoozed {
+		conds.add("(snoozed_until IS NULL OR snoozed_until <= now())")
+	}
+
+	if filter.ProjectId != "" {
+		conds.add("project_id = ?", filter.ProjectId)
+	}
+
+	if filter.Ready {
+		conds.add("state NOT IN (?, ?)", common.TaskStateDone, common.TaskStateCancelled)
+		conds.add(`NOT EXISTS (
+			SELECT 1 FROM public.task_dependency d JOIN public.task dt ON dt.id = d.depends_on_id
+			WHERE d.task_id = task.id AND dt.deleted_at IS NULL AND dt.state NOT IN (?, ?))`, common.TaskStateDone, common.TaskStateCancelled)
+	}
+
+	if len(filter.Labels) > 0 {
+		labels := slices.Compact(slices.Sorted(slices.Values(filter.Labels)))
+		matching := `(
+			SELECT COUNT(*) FROM public.task_label tl JOIN public.label l ON l.id = tl.label_id
+			WHERE tl.task_id = task.id AND l.name = ANY(?))`
+		if filter.LabelMatch == common.LabelMatchAll {
+			conds.add(matching+" = ?", pq.Array(labels), len(labels))
+		} else {
+			conds.add(matching+" > 0", pq.Array(labels))
+		}
+	}
+
+	if filter.Expr != nil {
+		clause, args, err := compileTaskFilter(filter.Expr)
+		if err != nil {
+			return err
+		}
+		conds.add(clause, args...)
+	}
+
+	return nil
+}
+
+// ListSubtasks returns the direct children of a task.
+func (db *DB) ListSubtasks(id string) ([]common.Task, error) {
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`
 		FROM public.task
-		WHERE user_id = $1`, id)
+		WHERE parent_id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving subtasks.")
+		return nil, err
+	}
+	defer results.Close()
+
+	return db.scanTasks(results)
+}
 
+// ListTaskAncestors returns the ids of a task and of all its ancestors, starting from the task itself.
+func (db *DB) ListTaskAncestors(id string) ([]string, error) {
+	results, err := db.conn().Query(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 0 AS depth FROM public.task WHERE id = $1
+			UNION
+			SELECT t.id, t.parent_id, a.depth + 1 FROM public.task t JOIN ancestors a ON t.id = a.parent_id
+		)
+		SELECT id FROM ancestors ORDER BY depth`, id)
 	if err != nil {
+		db.logger.Error("Error retrieving task ancestors.")
 		return nil, err
 	}
+	defer results.Close()
+
+	ids := make([]string, 0)
+	for results.Next() {
+		var ancestorId string
+		if err := results.Scan(&ancestorId); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		ids = append(ids, ancestorId)
+	}
+
+	return ids, nil
+}
+
+// GetTaskProgress counts the direct subtasks of a task that are done and the ones
+// that count towards its progress, i.e. the ones not cancelled. Deeper subtasks only
+// count through the state of their parent.
+func (db *DB) GetTaskProgress(id string) (done int, total int, err error) {
+	err = db.conn().QueryRow(`
+		SELECT COUNT(*) FILTER (WHERE state = $2), COUNT(*) FILTER (WHERE state <> $3)
+		FROM public.task
+		WHERE parent_id = $1 AND deleted_at IS NULL`, id, common.TaskStateDone, common.TaskStateCancelled).Scan(&done, &total)
+	if err != nil {
+		db.logger.Error("Error retrieving task progress.")
+		return 0, 0, err
+	}
+
+	return done, total, nil
+}
 
+// ListDueReminders returns the tasks whose reminder time is at or before now
+// and that have not been reminded yet.
+func (db *DB) ListDueReminders(now time.Time) ([]common.Task, error) {
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`
+		FROM public.task
+		WHERE remind_at <= $1 AND reminded_at IS NULL AND deleted_at IS NULL
+		ORDER BY remind_at`, now)
+	if err != nil {
+		db.logger.Error("Error retrieving due reminders.")
+		return nil, err
+	}
+	defer results.Close()
+
+	return db.scanTasks(results)
+}
+
+// MarkTaskReminded flags the reminder of a task as sent so it is not emitted again.
+func (db *DB) MarkTaskReminded(id string, remindedAt time.Time) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.task
+		SET reminded_at = $1
+		WHERE id = $2
+	`, remindedAt, id)
+	if err != nil {
+		db.logger.Error("Error marking task as reminded.")
+		return err
+	}
+
+	return nil
+}
+
+// SnoozeTask hides a task from the lists until a time, or shows it again when until is nil.
+func (db *DB) SnoozeTask(id string, until *time.Time) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.task
+		SET snoozed_until = $1, version = version + 1
+		WHERE id = $2
+	`, until, id)
+	if err != nil {
+		db.logger.Error("Error snoozing task.")
+		return err
+	}
+
+	return nil
+}
+
+// ListSnoozedTasks returns the snoozed tasks whose snooze time is at or before now.
+func (db *DB) ListSnoozedTasks(now time.Time) ([]common.Task, error) {
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`
+		FROM public.task
+		WHERE snoozed_until <= $1 AND deleted_at IS NULL
+		ORDER BY snoozed_until`, now)
+	if err != nil {
+		db.logger.Error("Error retrieving snoozed tasks.")
+		return nil, err
+	}
+	defer results.Close()
+
+	return db.scanTasks(results)
+}
+
+// WakeTask ends the snooze of a task, unless it was snoozed again since it was read with
+// snoozedUntil. It reports whether the task woke up.
+func (db *DB) WakeTask(id string, snoozedUntil time.Time) (bool, error) {
+	result, err := db.conn().Exec(`
+		UPDATE public.task
+		SET snoozed_until = NULL, version = version + 1
+		WHERE id = $1 AND snoozed_until = $2
+	`, id, snoozedUntil)
+	if err != nil {
+		db.logger.Error("Error waking task.")
+		return false, err
+	}
+
+	woken, err := result.RowsAffected()
+	if err != nil {
+		db.logger.Error("Error waking task.")
+		return false, err
+	}
+
+	return woken > 0, nil
+}
+
+func (db *DB) scanTasks(results *sql.Rows) ([]common.Task, error) {
 	tasks := make([]common.Task, 0)
 	for results.Next() {
 		task := common.Task{}
-		err = results.Scan(
-			&task.Id,
-			&task.UserId,
-			&task.Description,
-			&task.State)
+		err := scanTask(results, &task)
 		if err != nil {
 			return nil, err
 		}
+
 		tasks = append(tasks, task)
 	}
+
 	return tasks, nil
 }
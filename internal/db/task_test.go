@@ -1,18 +1,26 @@
 package db
 
 import (
+	"database/sql/driver"
 	"errors"
+	"slices"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/filter"
 )
 
+var taskColumnNames = []string{"id", "user_id", "description", "state", "due_at", "remind_at", "completed_at", "parent_id", "recurrence", "project_id", "archived_at", "position", "snoozed_until", "created_at", "version"}
+
 func (d *dbTestSuite) TestAddTask() {
 	errAddTask := errors.New("error inserting task")
+	now := time.Now()
 	task := &common.Task{
 		UserId:      "0001",
 		Description: "description 1",
 		State:       "to_do",
+		CreatedAt:   &now,
 	}
 
 	tests := map[string]struct {
@@ -34,7 +42,7 @@ func (d *dbTestSuite) TestAddTask() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockInsert := d.mock.ExpectExec("INSERT INTO public.task").WithArgs(test.task.Id, test.task.UserId, test.task.Description, test.task.State)
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.task").WithArgs(test.task.Id, test.task.UserId, test.task.Description, test.task.State, test.task.DueAt, test.task.RemindAt, test.task.CompletedAt, test.task.ParentId, test.task.Recurrence, test.task.ProjectId, test.task.Position, test.task.CreatedAt)
 			if test.dbError == nil {
 				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
 			} else {
@@ -50,40 +58,47 @@ func (d *dbTestSuite) TestAddTask() {
 
 func (d *dbTestSuite) TestUpdateTask() {
 	errAddTask := errors.New("error updating task")
-	task := &common.Task{
-		UserId:      "0001",
-		Description: "description 1",
-		State:       "to_do",
-	}
 
 	tests := map[string]struct {
-		task         *common.Task
-		dbError      error
-		expectedResp error
+		task            *common.Task
+		dbResult        driver.Result
+		dbError         error
+		expectedVersion int
+		expectedResp    error
 	}{
 		"success": {
-			task:         task,
-			dbError:      nil,
-			expectedResp: nil,
+			task:            &common.Task{UserId: "0001", Description: "description 1", State: "to_do", Version: 2},
+			dbResult:        sqlmock.NewResult(1, 1),
+			expectedVersion: 3,
+		},
+		"version conflict": {
+			task:            &common.Task{UserId: "0001", Description: "description 1", State: "to_do", Version: 2},
+			dbResult:        sqlmock.NewResult(0, 0),
+			expectedVersion: 2,
+			expectedResp:    ErrVersionConflict,
 		},
 		"fail": {
-			task:         task,
-			dbError:      errAddTask,
-			expectedResp: errAddTask,
+			task:            &common.Task{UserId: "0001", Description: "description 1", State: "to_do", Version: 2},
+			dbError:         errAddTask,
+			expectedVersion: 2,
+			expectedResp:    errAddTask,
 		},
 	}
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockUpdate := d.mock.ExpectExec("UPDATE public.task").WithArgs(test.task.UserId, test.task.Description, test.task.State, test.task.Id)
+			mockUpdate := d.mock.ExpectExec("UPDATE public.task SET (.+), version = version \\+ 1 WHERE id = \\$10 AND version = \\$11").
+				WithArgs(test.task.UserId, test.task.Description, test.task.State, test.task.DueAt, test.task.RemindAt, test.task.CompletedAt, test.task.ParentId, test.task.Recurrence, test.task.ProjectId, test.task.Id, test.task.Version)
 			if test.dbError == nil {
-				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+				mockUpdate.WillReturnResult(test.dbResult)
 			} else {
 				mockUpdate.WillReturnError(test.dbError)
 			}
 
 			err := d.db.UpdateTask(test.task)
-			d.Assert().Equal(err, test.expectedResp)
+			d.Assert().Equal(test.expectedResp, err)
+			d.Assert().Equal(test.expectedVersion, test.task.Version)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
 		})
 
 	}
@@ -96,7 +111,7 @@ func (d *dbTestSuite) TestGetTask() {
 		Description: "description 1",
 		State:       "to_do",
 	}
-	rowTask := sqlmock.NewRows([]string{"id", "user_id", "description", "state"}).AddRow("", task.UserId, task.Description, task.State)
+	rowTask := sqlmock.NewRows(taskColumnNames).AddRow("", task.UserId, task.Description, task.State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
 
 	tests := map[string]struct {
 		id           string
@@ -121,7 +136,7 @@ func (d *dbTestSuite) TestGetTask() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockGet := d.mock.ExpectQuery("SELECT id, user_id, description, state FROM public.task").WithArgs(test.id)
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.task").WithArgs(test.id)
 			if test.dbError == nil {
 				mockGet.WillReturnRows(test.dbRowTask)
 			} else {
@@ -138,6 +153,61 @@ func (d *dbTestSuite) TestGetTask() {
 
 func (d *dbTestSuite) TestDeleteTask() {
 	errAddTask := errors.New("error deleting task")
+	now := time.Now()
+
+	tests := map[string]struct {
+		id           string
+		dbError1     error
+		dbError2     error
+		expectedResp error
+	}{
+		"success": {
+			dbError1:     nil,
+			dbError2:     nil,
+			expectedResp: nil,
+		},
+		"fail1": {
+			dbError1:     errAddTask,
+			dbError2:     nil,
+			expectedResp: errAddTask,
+		},
+		"fail2": {
+			dbError1:     nil,
+			dbError2:     errAddTask,
+			expectedResp: errAddTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			mockReparent := d.mock.ExpectExec("UPDATE public.task SET parent_id").WithArgs(test.id)
+			if test.dbError1 == nil {
+				mockReparent.WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mockDelete := d.mock.ExpectExec("UPDATE public.task SET deleted_at = (.+) WHERE id = (.+) AND deleted_at IS NULL").WithArgs(test.id, now)
+				if test.dbError2 == nil {
+					mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+					d.mock.ExpectCommit()
+				} else {
+					mockDelete.WillReturnError(test.dbError2)
+					d.mock.ExpectRollback()
+				}
+			} else {
+				mockReparent.WillReturnError(test.dbError1)
+				d.mock.ExpectRollback()
+			}
+
+			err := d.db.DeleteTask(test.id, now)
+			d.Assert().Equal(err, test.expectedResp)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteTaskTree() {
+	errDeleteTask := errors.New("error deleting task")
+	now := time.Now()
 
 	tests := map[string]struct {
 		id           string
@@ -145,33 +215,35 @@ func (d *dbTestSuite) TestDeleteTask() {
 		expectedResp error
 	}{
 		"success": {
+			id:           "0001",
 			dbError:      nil,
 			expectedResp: nil,
 		},
 		"fail": {
-			dbError:      errAddTask,
-			expectedResp: errAddTask,
+			id:           "0001",
+			dbError:      errDeleteTask,
+			expectedResp: errDeleteTask,
 		},
 	}
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockDelete := d.mock.ExpectExec("DELETE FROM public.task").WithArgs(test.id)
+			mockDelete := d.mock.ExpectExec("WITH RECURSIVE tree AS (.+) UPDATE public.task SET deleted_at = (.+) WHERE id IN").WithArgs(test.id, now)
 			if test.dbError == nil {
-				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDelete.WillReturnResult(sqlmock.NewResult(3, 3))
 			} else {
 				mockDelete.WillReturnError(test.dbError)
 			}
 
-			err := d.db.DeleteTask(test.id)
-			d.Assert().Equal(err, test.expectedResp)
+			err := d.db.DeleteTaskTree(test.id, now)
+			d.Assert().Equal(test.expectedResp, err)
 		})
-
 	}
 }
 
 func (d *dbTestSuite) TestDeleteUserTasks() {
 	errAddTask := errors.New("error deleting task")
+	now := time.Now()
 
 	tests := map[string]struct {
 		id           string
@@ -190,14 +262,14 @@ func (d *dbTestSuite) TestDeleteUserTasks() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockDelete := d.mock.ExpectExec("DELETE FROM public.task").WithArgs(test.id)
+			mockDelete := d.mock.ExpectExec("UPDATE public.task SET deleted_at = (.+) WHERE user_id = (.+)").WithArgs(test.id, now)
 			if test.dbError == nil {
 				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
 			} else {
 				mockDelete.WillReturnError(test.dbError)
 			}
 
-			err := d.db.DeleteUserTasks(test.id)
+			err := d.db.DeleteUserTasks(test.id, now)
 			d.Assert().Equal(err, test.expectedResp)
 		})
 
@@ -219,9 +291,347 @@ func (d *dbTestSuite) TestListTasks() {
 		},
 	}
 
-	rowTasks := sqlmock.NewRows([]string{"id", "user_id", "description", "state"}).
-		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State).
-		AddRow("", listTasks[1].UserId, listTasks[1].Description, listTasks[1].State)
+	rowTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0).
+		AddRow("", listTasks[1].UserId, listTasks[1].Description, listTasks[1].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	rowProjectTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	rowFilterTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	filterExpr, err := filter.Parse(`state in (to_do, in_progress) and description ~ "deploy"`)
+	d.Require().NoError(err)
+
+	pageTasks := []common.Task{
+		{Id: "00000000-0000-0000-0000-000000000002", UserId: "0001", Description: "description 2", State: "to_do"},
+		{Id: "00000000-0000-0000-0000-000000000003", UserId: "0001", Description: "description 3", State: "to_do"},
+	}
+	rowPageTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow(pageTasks[0].Id, pageTasks[0].UserId, pageTasks[0].Description, pageTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0).
+		AddRow(pageTasks[1].Id, pageTasks[1].UserId, pageTasks[1].Description, pageTasks[1].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	tests := map[string]struct {
+		filter        common.TaskFilter
+		page          common.PageRequest
+		expectedQuery string
+		expectedArgs  []driver.Value
+		dbError       error
+		dbRowTask     *sqlmock.Rows
+		expectedResp  *common.TaskPage
+		expectedErr   error
+	}{
+		"success": {
+			expectedQuery: "SELECT (.+) FROM public.task WHERE deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) ORDER BY id$",
+			expectedArgs:  []driver.Value{},
+			dbError:       nil,
+			dbRowTask:     rowTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks},
+			expectedErr:   nil,
+		},
+		"project with archived tasks": {
+			filter:        common.TaskFilter{ProjectId: "0009", IncludeArchived: true},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE deleted_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) AND project_id = \\$1 ORDER BY id$",
+			expectedArgs:  []driver.Value{"0009"},
+			dbError:       nil,
+			dbRowTask:     rowProjectTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks[:1]},
+			expectedErr:   nil,
+		},
+		"filter expression": {
+			filter:        common.TaskFilter{Expr: filterExpr, IncludeArchived: true, IncludeSnoozed: true},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE deleted_at IS NULL AND \\(state IN \\(\\$1, \\$2\\) AND description ILIKE \\$3\\) ORDER BY id$",
+			expectedArgs:  []driver.Value{"to_do", "in_progress", "%deploy%"},
+			dbError:       nil,
+			dbRowTask:     rowFilterTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks[:1]},
+			expectedErr:   nil,
+		},
+		"page": {
+			page:          common.PageRequest{Limit: 1, Cursor: encodeCursor("00000000-0000-0000-0000-000000000001")},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) AND \\(id\\) > \\(\\$1\\) ORDER BY id LIMIT 2$",
+			expectedArgs:  []driver.Value{"00000000-0000-0000-0000-000000000001"},
+			dbRowTask:     rowPageTasks,
+			expectedResp:  &common.TaskPage{Items: pageTasks[:1], NextCursor: encodeCursor(pageTasks[0].Id)},
+		},
+		"invalid cursor": {
+			page:        common.PageRequest{Limit: 1, Cursor: "not a cursor"},
+			expectedErr: ErrInvalidCursor,
+		},
+		"fail": {
+			expectedQuery: "SELECT (.+) FROM public.task",
+			expectedArgs:  []driver.Value{},
+			dbError:       errGetTask,
+			dbRowTask:     nil,
+			expectedResp:  nil,
+			expectedErr:   errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			if test.expectedQuery != "" {
+				mockGet := d.mock.ExpectQuery(test.expectedQuery).WithArgs(test.expectedArgs...)
+				if test.dbError == nil {
+					mockGet.WillReturnRows(test.dbRowTask)
+				} else {
+					mockGet.WillReturnError(errGetTask)
+				}
+			}
+
+			task, err := d.db.ListTasks(test.filter, test.page)
+			d.Assert().Equal(task, test.expectedResp)
+			d.Assert().Equal(err, test.expectedErr)
+		})
+
+	}
+}
+
+func (d *dbTestSuite) TestUserTasks() {
+	errGetTask := errors.New("any error")
+	listTasks := []common.Task{
+		{
+			UserId:      "0001",
+			Description: "description 1",
+			State:       "to_do",
+		},
+		{
+			UserId:      "0001",
+			Description: "description 2",
+			State:       "to_do",
+		},
+	}
+
+	rowTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0).
+		AddRow("", listTasks[1].UserId, listTasks[1].Description, listTasks[1].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	rowReadyTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	rowAnyLabelTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	rowAllLabelsTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+
+	snoozedUntil := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	snoozedTasks := []common.Task{listTasks[0], listTasks[1]}
+	snoozedTasks[1].SnoozedUntil = &snoozedUntil
+	rowSnoozedTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0).
+		AddRow("", listTasks[1].UserId, listTasks[1].Description, listTasks[1].State, nil, nil, nil, nil, "", nil, nil, "", snoozedUntil, nil, 0)
+
+	pageTasks := []common.Task{
+		{Id: "00000000-0000-0000-0000-000000000002", UserId: "0001", Description: "description 2", State: "to_do", Position: "b"},
+		{Id: "00000000-0000-0000-0000-000000000003", UserId: "0001", Description: "description 3", State: "to_do", Position: "c"},
+	}
+	rowPageTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow(pageTasks[0].Id, pageTasks[0].UserId, pageTasks[0].Description, pageTasks[0].State, nil, nil, nil, nil, "", nil, nil, "b", nil, nil, 0).
+		AddRow(pageTasks[1].Id, pageTasks[1].UserId, pageTasks[1].Description, pageTasks[1].State, nil, nil, nil, nil, "", nil, nil, "c", nil, nil, 0)
+	rowLastPageTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow(pageTasks[0].Id, pageTasks[0].UserId, pageTasks[0].Description, pageTasks[0].State, nil, nil, nil, nil, "", nil, nil, "b", nil, nil, 0).
+		AddRow(pageTasks[1].Id, pageTasks[1].UserId, pageTasks[1].Description, pageTasks[1].State, nil, nil, nil, nil, "", nil, nil, "c", nil, nil, 0)
+
+	tests := map[string]struct {
+		id            string
+		filter        common.TaskFilter
+		page          common.PageRequest
+		expectedQuery string
+		expectedArgs  []driver.Value
+		dbError       error
+		dbRowTask     *sqlmock.Rows
+		expectedResp  *common.TaskPage
+		expectedErr   error
+	}{
+		"success": {
+			id:            "0001",
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) ORDER BY position, id$",
+			expectedArgs:  []driver.Value{"0001"},
+			dbError:       nil,
+			dbRowTask:     rowTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks},
+			expectedErr:   nil,
+		},
+		"ready": {
+			id:            "0001",
+			filter:        common.TaskFilter{Ready: true},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) AND state NOT IN \\(\\$2, \\$3\\) AND NOT EXISTS",
+			expectedArgs:  []driver.Value{"0001", common.TaskStateDone, common.TaskStateCancelled, common.TaskStateDone, common.TaskStateCancelled},
+			dbError:       nil,
+			dbRowTask:     rowReadyTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks[:1]},
+			expectedErr:   nil,
+		},
+		"any label": {
+			id:            "0001",
+			filter:        common.TaskFilter{Labels: []string{"work", "home", "work"}},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) AND \\( SELECT COUNT(.+) = ANY\\(\\$2\\)\\) > 0 ORDER BY position, id$",
+			expectedArgs:  []driver.Value{"0001", `{"home","work"}`},
+			dbRowTask:     rowAnyLabelTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks[:1]},
+		},
+		"all labels": {
+			id:            "0001",
+			filter:        common.TaskFilter{Labels: []string{"work", "home"}, LabelMatch: common.LabelMatchAll},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) AND \\( SELECT COUNT(.+) = ANY\\(\\$2\\)\\) = \\$3 ORDER BY position, id$",
+			expectedArgs:  []driver.Value{"0001", `{"home","work"}`, 2},
+			dbRowTask:     rowAllLabelsTasks,
+			expectedResp:  &common.TaskPage{Items: listTasks[:1]},
+		},
+		"include snoozed": {
+			id:            "0001",
+			filter:        common.TaskFilter{IncludeSnoozed: true},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL ORDER BY position, id$",
+			expectedArgs:  []driver.Value{"0001"},
+			dbRowTask:     rowSnoozedTasks,
+			expectedResp:  &common.TaskPage{Items: snoozedTasks},
+		},
+		"page": {
+			id:            "0001",
+			page:          common.PageRequest{Limit: 1, Cursor: encodeCursor("a", "00000000-0000-0000-0000-000000000001")},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) AND \\(position, id\\) > \\(\\$2, \\$3\\) ORDER BY position, id LIMIT 2$",
+			expectedArgs:  []driver.Value{"0001", "a", "00000000-0000-0000-0000-000000000001"},
+			dbRowTask:     rowPageTasks,
+			expectedResp:  &common.TaskPage{Items: pageTasks[:1], NextCursor: encodeCursor("b", pageTasks[0].Id)},
+		},
+		"last page": {
+			id:            "0001",
+			page:          common.PageRequest{Limit: 2},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 (.+) ORDER BY position, id LIMIT 3$",
+			expectedArgs:  []driver.Value{"0001"},
+			dbRowTask:     rowLastPageTasks,
+			expectedResp:  &common.TaskPage{Items: pageTasks},
+		},
+		"fail": {
+			id:            "0001",
+			expectedQuery: "SELECT (.+) FROM public.task",
+			expectedArgs:  []driver.Value{"0001"},
+			dbError:       errGetTask,
+			dbRowTask:     nil,
+			expectedResp:  nil,
+			expectedErr:   errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery(test.expectedQuery).WithArgs(test.expectedArgs...)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRowTask)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			task, err := d.db.ListUserTasks(test.id, test.filter, test.page)
+			d.Assert().Equal(task, test.expectedResp)
+			d.Assert().Equal(err, test.expectedErr)
+		})
+
+	}
+}
+
+func (d *dbTestSuite) TestIterUserTasks() {
+	errGetTask := errors.New("any error")
+	listTasks := []common.Task{
+		{
+			UserId:      "0001",
+			Description: "description 1",
+			State:       "to_do",
+		},
+		{
+			UserId:      "0001",
+			Description: "description 2",
+			State:       "to_do",
+		},
+	}
+
+	newRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(taskColumnNames).
+			AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0).
+			AddRow("", listTasks[1].UserId, listTasks[1].Description, listTasks[1].State, nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0)
+	}
+
+	tests := map[string]struct {
+		filter        common.TaskFilter
+		expectedQuery string
+		dbError       error
+		dbRowTask     *sqlmock.Rows
+		stopAfter     int
+		expectedResp  []common.Task
+		expectedErr   error
+	}{
+		"success": {
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND archived_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) ORDER BY position, id$",
+			dbRowTask:     newRows(),
+			expectedResp:  listTasks,
+		},
+		"archived": {
+			filter:        common.TaskFilter{IncludeArchived: true},
+			expectedQuery: "SELECT (.+) FROM public.task WHERE user_id = \\$1 AND deleted_at IS NULL AND \\(snoozed_until IS NULL OR snoozed_until <= now\\(\\)\\) ORDER BY position, id$",
+			dbRowTask:     newRows(),
+			expectedResp:  listTasks,
+		},
+		"stopped early": {
+			expectedQuery: "SELECT (.+) FROM public.task",
+			dbRowTask:     newRows(),
+			stopAfter:     1,
+			expectedResp:  listTasks[:1],
+		},
+		"row error": {
+			expectedQuery: "SELECT (.+) FROM public.task",
+			dbRowTask:     newRows().RowError(1, errGetTask),
+			expectedResp:  listTasks[:1],
+			expectedErr:   errGetTask,
+		},
+		"fail": {
+			expectedQuery: "SELECT (.+) FROM public.task",
+			dbError:       errGetTask,
+			expectedErr:   errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery(test.expectedQuery).WithArgs("0001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRowTask)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			var tasks []common.Task
+			var err error
+			for task, iterErr := range d.db.IterUserTasks("0001", test.filter) {
+				if iterErr != nil {
+					err = iterErr
+					break
+				}
+				tasks = append(tasks, task)
+				if len(tasks) == test.stopAfter {
+					break
+				}
+			}
+			d.Assert().Equal(test.expectedResp, tasks)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListDueReminders() {
+	errGetTask := errors.New("any error")
+	now := time.Now()
+	listTasks := []common.Task{
+		{
+			UserId:      "0001",
+			Description: "description 1",
+			State:       "to_do",
+			RemindAt:    &now,
+		},
+	}
+	rowTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, now, nil, nil, "", nil, nil, "", nil, nil, 0)
 
 	tests := map[string]struct {
 		dbError      error
@@ -245,42 +655,191 @@ func (d *dbTestSuite) TestListTasks() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockGet := d.mock.ExpectQuery("SELECT id, user_id, description, state FROM public.task")
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.task WHERE remind_at <= (.+) AND reminded_at IS NULL").WithArgs(now)
 			if test.dbError == nil {
 				mockGet.WillReturnRows(test.dbRowTask)
 			} else {
 				mockGet.WillReturnError(errGetTask)
 			}
 
-			task, err := d.db.ListTasks()
-			d.Assert().Equal(task, test.expectedResp)
-			d.Assert().Equal(err, test.expectedErr)
+			tasks, err := d.db.ListDueReminders(now)
+			d.Assert().Equal(test.expectedResp, tasks)
+			d.Assert().Equal(test.expectedErr, err)
 		})
+	}
+}
 
+func (d *dbTestSuite) TestMarkTaskReminded() {
+	errMarkTask := errors.New("error marking task")
+	now := time.Now()
+
+	tests := map[string]struct {
+		id           string
+		dbError      error
+		expectedResp error
+	}{
+		"success": {
+			id:           "0001",
+			dbError:      nil,
+			expectedResp: nil,
+		},
+		"fail": {
+			id:           "0001",
+			dbError:      errMarkTask,
+			expectedResp: errMarkTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec("UPDATE public.task SET reminded_at").WithArgs(now, test.id)
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			err := d.db.MarkTaskReminded(test.id, now)
+			d.Assert().Equal(test.expectedResp, err)
+		})
 	}
 }
 
-func (d *dbTestSuite) TestUserTasks() {
+func (d *dbTestSuite) TestSnoozeTask() {
+	errSnoozeTask := errors.New("error snoozing task")
+	until := time.Now()
+
+	tests := map[string]struct {
+		until        *time.Time
+		dbError      error
+		expectedResp error
+	}{
+		"snooze": {
+			until: &until,
+		},
+		"unsnooze": {},
+		"fail": {
+			until:        &until,
+			dbError:      errSnoozeTask,
+			expectedResp: errSnoozeTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec("UPDATE public.task SET snoozed_until = \\$1, version = version \\+ 1 WHERE id = \\$2").WithArgs(test.until, "0001")
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			err := d.db.SnoozeTask("0001", test.until)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListSnoozedTasks() {
 	errGetTask := errors.New("any error")
+	now := time.Now()
 	listTasks := []common.Task{
 		{
-			UserId:      "0001",
-			Description: "description 1",
-			State:       "to_do",
+			UserId:       "0001",
+			Description:  "description 1",
+			State:        "to_do",
+			SnoozedUntil: &now,
+		},
+	}
+	rowTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, nil, "", nil, nil, "", now, nil, 0)
+
+	tests := map[string]struct {
+		dbError      error
+		dbRowTask    *sqlmock.Rows
+		expectedResp []common.Task
+		expectedErr  error
+	}{
+		"success": {
+			dbRowTask:    rowTasks,
+			expectedResp: listTasks,
+		},
+		"fail": {
+			dbError:     errGetTask,
+			expectedErr: errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.task WHERE snoozed_until <= \\$1 AND deleted_at IS NULL ORDER BY snoozed_until").WithArgs(now)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRowTask)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			tasks, err := d.db.ListSnoozedTasks(now)
+			d.Assert().Equal(test.expectedResp, tasks)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestWakeTask() {
+	errWakeTask := errors.New("error waking task")
+	snoozedUntil := time.Now()
+
+	tests := map[string]struct {
+		dbResult      driver.Result
+		dbError       error
+		expectedWoken bool
+		expectedErr   error
+	}{
+		"woken": {
+			dbResult:      sqlmock.NewResult(1, 1),
+			expectedWoken: true,
+		},
+		"snoozed again": {
+			dbResult: sqlmock.NewResult(0, 0),
+		},
+		"fail": {
+			dbError:     errWakeTask,
+			expectedErr: errWakeTask,
 		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec("UPDATE public.task SET snoozed_until = NULL, version = version \\+ 1 WHERE id = \\$1 AND snoozed_until = \\$2").WithArgs("0001", snoozedUntil)
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(test.dbResult)
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			woken, err := d.db.WakeTask("0001", snoozedUntil)
+			d.Assert().Equal(test.expectedWoken, woken)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListSubtasks() {
+	errGetTask := errors.New("any error")
+	parentId := "0001"
+	listTasks := []common.Task{
 		{
 			UserId:      "0001",
-			Description: "description 2",
+			Description: "description 1",
 			State:       "to_do",
+			ParentId:    &parentId,
 		},
 	}
-
-	rowTasks := sqlmock.NewRows([]string{"id", "user_id", "description", "state"}).
-		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State).
-		AddRow("", listTasks[1].UserId, listTasks[1].Description, listTasks[1].State)
+	rowTasks := sqlmock.NewRows(taskColumnNames).
+		AddRow("", listTasks[0].UserId, listTasks[0].Description, listTasks[0].State, nil, nil, nil, parentId, "", nil, nil, "", nil, nil, 0)
 
 	tests := map[string]struct {
-		id           string
 		dbError      error
 		dbRowTask    *sqlmock.Rows
 		expectedResp []common.Task
@@ -302,17 +861,159 @@ func (d *dbTestSuite) TestUserTasks() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockGet := d.mock.ExpectQuery("SELECT id, user_id, description, state FROM public.task")
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.task WHERE parent_id = (.+)").WithArgs(parentId)
 			if test.dbError == nil {
 				mockGet.WillReturnRows(test.dbRowTask)
 			} else {
 				mockGet.WillReturnError(errGetTask)
 			}
 
-			task, err := d.db.ListUserTasks(test.id)
-			d.Assert().Equal(task, test.expectedResp)
-			d.Assert().Equal(err, test.expectedErr)
+			tasks, err := d.db.ListSubtasks(parentId)
+			d.Assert().Equal(test.expectedResp, tasks)
+			d.Assert().Equal(test.expectedErr, err)
 		})
+	}
+}
 
+func (d *dbTestSuite) TestListTaskAncestors() {
+	errGetTask := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp []string
+		expectedErr  error
+	}{
+		"success": {
+			dbError:      nil,
+			dbRows:       sqlmock.NewRows([]string{"id"}).AddRow("0003").AddRow("0002").AddRow("0001"),
+			expectedResp: []string{"0003", "0002", "0001"},
+			expectedErr:  nil,
+		},
+		"fail": {
+			dbError:      errGetTask,
+			dbRows:       nil,
+			expectedResp: nil,
+			expectedErr:  errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("WITH RECURSIVE ancestors AS (.+) SELECT id FROM ancestors").WithArgs("0003")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			ids, err := d.db.ListTaskAncestors("0003")
+			d.Assert().Equal(test.expectedResp, ids)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetTaskProgress() {
+	errGetTask := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError       error
+		dbRows        *sqlmock.Rows
+		expectedDone  int
+		expectedTotal int
+		expectedErr   error
+	}{
+		"success": {
+			dbError:       nil,
+			dbRows:        sqlmock.NewRows([]string{"done", "total"}).AddRow(2, 5),
+			expectedDone:  2,
+			expectedTotal: 5,
+			expectedErr:   nil,
+		},
+		"fail": {
+			dbError:     errGetTask,
+			dbRows:      nil,
+			expectedErr: errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT COUNT(.+) FROM public.task WHERE parent_id = \\$1 AND deleted_at IS NULL").
+				WithArgs("0001", common.TaskStateDone, common.TaskStateCancelled)
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(errGetTask)
+			}
+
+			done, total, err := d.db.GetTaskProgress("0001")
+			d.Assert().Equal(test.expectedDone, done)
+			d.Assert().Equal(test.expectedTotal, total)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestSearchTasks() {
+	errSearchTasks := errors.New("any error")
+	results := []common.TaskSearchResult{
+		{
+			Task: common.Task{
+				Id:          "0001",
+				UserId:      "00001",
+				Description: "buy milk and bread",
+				State:       "to_do",
+			},
+			Rank:    0.0607927,
+			Snippet: "buy <b>milk</b> and bread",
+		},
+	}
+	searchColumnNames := append(slices.Clone(taskColumnNames), "rank", "snippet")
+
+	tests := map[string]struct {
+		search       common.TaskSearch
+		args         []driver.Value
+		query        string
+		dbError      error
+		expectedResp []common.TaskSearchResult
+		expectedErr  error
+	}{
+		"success": {
+			search:       common.TaskSearch{Query: "milk", Limit: 20},
+			args:         []driver.Value{"milk", 20},
+			query:        `SELECT (.+) ts_rank\(search_vector, websearch_to_tsquery\('english', \$1\)\) AS rank, (.+) FROM public.task WHERE search_vector @@ websearch_to_tsquery\('english', \$1\) AND archived_at IS NULL AND deleted_at IS NULL ORDER BY rank DESC, id LIMIT \$2`,
+			expectedResp: results,
+		},
+		"filtered": {
+			search:       common.TaskSearch{Query: "milk", UserId: "00001", State: "to_do", Limit: 5},
+			args:         []driver.Value{"milk", "00001", "to_do", 5},
+			query:        `SELECT (.+) FROM public.task WHERE search_vector @@ (.+) AND archived_at IS NULL AND deleted_at IS NULL AND user_id = \$2 AND state = \$3 ORDER BY rank DESC, id LIMIT \$4`,
+			expectedResp: results,
+		},
+		"fail": {
+			search:      common.TaskSearch{Query: "milk", Limit: 20},
+			args:        []driver.Value{"milk", 20},
+			query:       `SELECT (.+) FROM public.task WHERE search_vector @@ (.+)`,
+			dbError:     errSearchTasks,
+			expectedErr: errSearchTasks,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockSearch := d.mock.ExpectQuery(test.query).WithArgs(test.args...)
+			if test.dbError == nil {
+				mockSearch.WillReturnRows(sqlmock.NewRows(searchColumnNames).
+					AddRow("0001", "00001", "buy milk and bread", "to_do", nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0, 0.0607927, "buy <b>milk</b> and bread"))
+			} else {
+				mockSearch.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.SearchTasks(test.search)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
 	}
 }
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (db *DB) AddTemplate(template *common.Template) error {
+	tasks, err := json.Marshal(template.Tasks)
+	if err != nil {
+		db.logger.Error("Error encoding template tasks.")
+		return err
+	}
+
+	_, err = db.conn().Exec(`
+		INSERT INTO public.template(id, user_id, name, tasks)
+		VALUES($1, $2, $3, $4)
+	`, template.Id, template.UserId, template.Name, tasks)
+	if err != nil {
+		db.logger.Error("Error inserting template.")
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) GetTemplate(id string) (*common.Template, error) {
+	results, err := db.conn().Query(`
+		SELECT id, user_id, name, tasks
+		FROM public.template
+		WHERE id = $1`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving template.")
+		return nil, err
+	}
+	defer results.Close()
+
+	template := common.Template{}
+	for results.Next() {
+		if err := scanTemplate(results, &template); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &template, nil
+}
+
+func (db *DB) DeleteTemplate(id string) error {
+	_, err := db.conn().Exec(`
+		DELETE FROM public.template WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error deleting template.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTemplates returns the templates of a user.
+func (db *DB) ListTemplates(userId string) ([]common.Template, error) {
+	results, err := db.conn().Query(`
+		SELECT id, user_id, name, tasks
+		FROM public.template
+		WHERE user_id = $1
+		ORDER BY name, id`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving templates.")
+		return nil, err
+	}
+	defer results.Close()
+
+	templates := make([]common.Template, 0)
+	for results.Next() {
+		template := common.Template{}
+		if err := scanTemplate(results, &template); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// scanTemplate reads a template from the current row, decoding its tasks.
+func scanTemplate(results *sql.Rows, template *common.Template) error {
+	var tasks []byte
+	if err := results.Scan(&template.Id, &template.UserId, &template.Name, &tasks); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(tasks, &template.Tasks)
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"errors"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+var templateColumns = []string{"id", "user_id", "name", "tasks"}
+
+func (d *dbTestSuite) TestAddTemplate() {
+	errAddTemplate := errors.New("error inserting template")
+	template := &common.Template{Id: "0001", UserId: "00001", Name: "onboarding", Tasks: []common.TemplateTask{
+		{Description: "get a laptop", DueOffset: "24h", Checklist: []string{"charger"}},
+	}}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errAddTemplate,
+			expectedResp: errAddTemplate,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.template").
+				WithArgs(template.Id, template.UserId, template.Name, []byte(`[{"description":"get a laptop","due_offset":"24h","checklist":["charger"]}]`))
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddTemplate(template)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetTemplate() {
+	errGetTemplate := errors.New("any error")
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp *common.Template
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(templateColumns).
+				AddRow("0001", "00001", "onboarding", []byte(`[{"description":"get a laptop","labels":["it"]}]`)),
+			expectedResp: &common.Template{Id: "0001", UserId: "00001", Name: "onboarding", Tasks: []common.TemplateTask{
+				{Description: "get a laptop", Labels: []string{"it"}},
+			}},
+		},
+		"not found": {
+			dbRows:       sqlmock.NewRows(templateColumns),
+			expectedResp: &common.Template{},
+		},
+		"fail": {
+			dbError:     errGetTemplate,
+			expectedErr: errGetTemplate,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT id, user_id, name, tasks FROM public.template WHERE id = \\$1").WithArgs("0001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetTemplate("0001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestDeleteTemplate() {
+	errDeleteTemplate := errors.New("error deleting template")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errDeleteTemplate,
+			expectedResp: errDeleteTemplate,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockDelete := d.mock.ExpectExec("DELETE FROM public.template").WithArgs("0001")
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+
+			err := d.db.DeleteTemplate("0001")
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTemplates() {
+	errListTemplates := errors.New("any error")
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.Template
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: []common.Template{
+				{Id: "0001", UserId: "00001", Name: "onboarding", Tasks: []common.TemplateTask{{Description: "get a laptop"}}},
+				{Id: "0002", UserId: "00001", Name: "release", Tasks: []common.TemplateTask{{Description: "tag", DueOffset: "1h"}}},
+			},
+		},
+		"fail": {
+			dbError:     errListTemplates,
+			expectedErr: errListTemplates,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT id, user_id, name, tasks FROM public.template WHERE user_id = \\$1 ORDER BY name, id").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows(templateColumns).
+					AddRow("0001", "00001", "onboarding", []byte(`[{"description":"get a laptop"}]`)).
+					AddRow("0002", "00001", "release", []byte(`[{"description":"tag","due_offset":"1h"}]`)))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTemplates("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+const timeEntryColumns = `id, task_id, user_id, started_at, stopped_at`
+
+// ErrTimeEntryRunning is returned when a time entry is started while its user already runs one.
+var ErrTimeEntryRunning = errors.New("time entry already running")
+
+func (db *DB) AddTimeEntry(entry *common.TimeEntry) error {
+	_, err := db.conn().Exec(`
+		INSERT INTO public.time_entry(id, task_id, user_id, started_at, stopped_at)
+		VALUES($1, $2, $3, $4, $5)
+	`, entry.Id, entry.TaskId, entry.UserId, entry.StartedAt, entry.StoppedAt)
+	if err != nil {
+		// a timer started concurrently is only caught by the unique index of the running entries
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "time_entry_running_idx" {
+			return ErrTimeEntryRunning
+		}
+		db.logger.Error("Error inserting time entry.")
+		return err
+	}
+
+	return nil
+}
+
+// GetRunningTimeEntry returns the running timer of a user. The entry is empty when no timer runs.
+func (db *DB) GetRunningTimeEntry(userId string) (*common.TimeEntry, error) {
+	results, err := db.conn().Query(`
+		SELECT `+timeEntryColumns+`
+		FROM public.time_entry
+		WHERE user_id = $1 AND stopped_at IS NULL`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving running time entry.")
+		return nil, err
+	}
+	defer results.Close()
+
+	entry := common.TimeEntry{}
+	for results.Next() {
+		err = results.Scan(
+			&entry.Id,
+			&entry.TaskId,
+			&entry.UserId,
+			&entry.StartedAt,
+			&entry.StoppedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &entry, nil
+}
+
+func (db *DB) StopTimeEntry(id string, stoppedAt time.Time) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.time_entry
+		SET stopped_at = $2
+		WHERE id = $1 AND stopped_at IS NULL
+	`, id, stoppedAt)
+	if err != nil {
+		db.logger.Error("Error stopping time entry.")
+		return err
+	}
+
+	return nil
+}
+
+// ListTimeEntries returns the time entries of a user overlapping the period from from to to,
+// the running one included, ordered by start time.
+func (db *DB) ListTimeEntries(userId string, from time.Time, to time.Time) ([]common.TimeEntry, error) {
+	results, err := db.conn().Query(`
+		SELECT `+timeEntryColumns+`
+		FROM public.time_entry
+		WHERE user_id = $1 AND started_at < $3 AND (stopped_at IS NULL OR stopped_at > $2)
+		ORDER BY started_at, id`, userId, from, to)
+	if err != nil {
+		db.logger.Error("Error retrieving time entries.")
+		return nil, err
+	}
+	defer results.Close()
+
+	entries := make([]common.TimeEntry, 0)
+	for results.Next() {
+		entry := common.TimeEntry{}
+		err = results.Scan(
+			&entry.Id,
+			&entry.TaskId,
+			&entry.UserId,
+			&entry.StartedAt,
+			&entry.StoppedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/lib/pq"
+)
+
+var timeEntryColumnNames = []string{"id", "task_id", "user_id", "started_at", "stopped_at"}
+
+func (d *dbTestSuite) TestAddTimeEntry() {
+	errAddTimeEntry := errors.New("error inserting time entry")
+	entry := &common.TimeEntry{Id: "000001", TaskId: "0001", UserId: "00001", StartedAt: time.Now()}
+
+	tests := map[string]struct {
+		dbError     error
+		expectedErr error
+	}{
+		"success": {},
+		"already running": {
+			dbError:     &pq.Error{Code: "23505", Constraint: "time_entry_running_idx"},
+			expectedErr: ErrTimeEntryRunning,
+		},
+		"fail": {
+			dbError:     errAddTimeEntry,
+			expectedErr: errAddTimeEntry,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockInsert := d.mock.ExpectExec("INSERT INTO public.time_entry").WithArgs(entry.Id, entry.TaskId, entry.UserId, entry.StartedAt, entry.StoppedAt)
+			if test.dbError == nil {
+				mockInsert.WillReturnResult(sqlmock.NewResult(1, 1))
+			} else {
+				mockInsert.WillReturnError(test.dbError)
+			}
+
+			err := d.db.AddTimeEntry(entry)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetRunningTimeEntry() {
+	errGetTimeEntry := errors.New("any error")
+	startedAt := time.Now()
+
+	tests := map[string]struct {
+		dbRows       *sqlmock.Rows
+		dbError      error
+		expectedResp *common.TimeEntry
+		expectedErr  error
+	}{
+		"running": {
+			dbRows:       sqlmock.NewRows(timeEntryColumnNames).AddRow("000001", "0001", "00001", startedAt, nil),
+			expectedResp: &common.TimeEntry{Id: "000001", TaskId: "0001", UserId: "00001", StartedAt: startedAt},
+		},
+		"not running": {
+			dbRows:       sqlmock.NewRows(timeEntryColumnNames),
+			expectedResp: &common.TimeEntry{},
+		},
+		"fail": {
+			dbError:     errGetTimeEntry,
+			expectedErr: errGetTimeEntry,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.time_entry WHERE user_id = \\$1 AND stopped_at IS NULL").WithArgs("00001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetRunningTimeEntry("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestStopTimeEntry() {
+	errStopTimeEntry := errors.New("error stopping time entry")
+	stoppedAt := time.Now()
+
+	tests := map[string]struct {
+		dbError     error
+		expectedErr error
+	}{
+		"success": {},
+		"fail": {
+			dbError:     errStopTimeEntry,
+			expectedErr: errStopTimeEntry,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockUpdate := d.mock.ExpectExec("UPDATE public.time_entry SET stopped_at = \\$2 WHERE id = \\$1 AND stopped_at IS NULL").WithArgs("000001", stoppedAt)
+			if test.dbError == nil {
+				mockUpdate.WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				mockUpdate.WillReturnError(test.dbError)
+			}
+
+			err := d.db.StopTimeEntry("000001", stoppedAt)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListTimeEntries() {
+	errListTimeEntries := errors.New("any error")
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	startedAt := from.Add(9 * time.Hour)
+	stoppedAt := startedAt.Add(time.Hour)
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.TimeEntry
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: []common.TimeEntry{
+				{Id: "000001", TaskId: "0001", UserId: "00001", StartedAt: startedAt, StoppedAt: &stoppedAt},
+				{Id: "000002", TaskId: "0002", UserId: "00001", StartedAt: stoppedAt},
+			},
+		},
+		"fail": {
+			dbError:     errListTimeEntries,
+			expectedErr: errListTimeEntries,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.time_entry WHERE user_id = \\$1 AND started_at < \\$3 AND \\(stopped_at IS NULL OR stopped_at > \\$2\\) ORDER BY started_at, id").
+				WithArgs("00001", from, to)
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows(timeEntryColumnNames).
+					AddRow("000001", "0001", "00001", startedAt, stoppedAt).
+					AddRow("000002", "0002", "00001", stoppedAt, nil))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListTimeEntries("00001", from, to)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package db
+
+import (
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+// ListDeletedTasks returns the tasks of a user in the trash, most recently deleted first.
+func (db *DB) ListDeletedTasks(userId string) ([]common.Task, error) {
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`, deleted_at
+		FROM public.task
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id`, userId)
+	if err != nil {
+		db.logger.Error("Error retrieving deleted tasks.")
+		return nil, err
+	}
+	defer results.Close()
+
+	tasks := make([]common.Task, 0)
+	for results.Next() {
+		task := common.Task{}
+		if err := scanTask(results, &task, &task.DeletedAt); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// GetDeletedTask returns a task in the trash. The task is empty when there is no such task in the trash.
+func (db *DB) GetDeletedTask(id string) (*common.Task, error) {
+	results, err := db.conn().Query(`
+		SELECT `+taskColumns+`, deleted_at
+		FROM public.task
+		WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving deleted task.")
+		return nil, err
+	}
+	defer results.Close()
+
+	task := common.Task{}
+	for results.Next() {
+		if err := scanTask(results, &task, &task.DeletedAt); err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &task, nil
+}
+
+// RestoreTask takes a task out of the trash together with the subtasks deleted along with it,
+// that is at the same time.
+func (db *DB) RestoreTask(id string, deletedAt time.Time) error {
+	_, err := db.conn().Exec(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM public.task WHERE id = $1 AND deleted_at = $2
+			UNION
+			SELECT t.id FROM public.task t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at = $2
+		)
+		UPDATE public.task
+		SET deleted_at = NULL
+		WHERE id IN (SELECT id FROM tree)
+	`, id, deletedAt)
+	if err != nil {
+		db.logger.Error("Error restoring task.")
+		return err
+	}
+
+	return nil
+}
+
+// ListDeletedUsers returns the user with the given id when it is in the trash, so that a user only
+// ever sees their own account there.
+func (db *DB) ListDeletedUsers(id string) ([]common.User, error) {
+	results, err := db.conn().Query(`
+		SELECT id, username, name, deleted_at
+		FROM public.user
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving deleted users.")
+		return nil, err
+	}
+	defer results.Close()
+
+	users := make([]common.User, 0)
+	for results.Next() {
+		user := common.User{}
+		err = results.Scan(
+			&user.Id,
+			&user.Username,
+			&user.Name,
+			&user.DeletedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// GetDeletedUser returns a user in the trash. The user is empty when there is no such user in the trash.
+func (db *DB) GetDeletedUser(id string) (*common.User, error) {
+	results, err := db.conn().Query(`
+		SELECT id, username, name, deleted_at
+		FROM public.user
+		WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		db.logger.Error("Error retrieving deleted user.")
+		return nil, err
+	}
+	defer results.Close()
+
+	user := common.User{}
+	for results.Next() {
+		err = results.Scan(
+			&user.Id,
+			&user.Username,
+			&user.Name,
+			&user.DeletedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
+
+// RestoreUser takes a user out of the trash together with the tasks deleted along with the user,
+// that is at the same time.
+func (db *DB) RestoreUser(id string, deletedAt time.Time) error {
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE public.user
+		SET deleted_at = NULL
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		db.logger.Error("Error restoring user.")
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE public.task
+		SET deleted_at = NULL
+		WHERE user_id = $1 AND deleted_at = $2
+	`, id, deletedAt)
+	if err != nil {
+		db.logger.Error("Error restoring user tasks.")
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListDeletedAttachments returns the attachments of the tasks deleted before a given time.
+func (db *DB) ListDeletedAttachments(before time.Time) ([]common.Attachment, error) {
+	results, err := db.conn().Query(`
+		SELECT a.id, a.task_id, a.name, a.content_type, a.size, a.checksum, a.created_at
+		FROM public.attachment a
+		JOIN public.task t ON t.id = a.task_id
+		WHERE t.deleted_at < $1`, before)
+	if err != nil {
+		db.logger.Error("Error retrieving deleted attachments.")
+		return nil, err
+	}
+	defer results.Close()
+
+	attachments := make([]common.Attachment, 0)
+	for results.Next() {
+		attachment := common.Attachment{}
+		err = results.Scan(
+			&attachment.Id,
+			&attachment.TaskId,
+			&attachment.Name,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.CreatedAt)
+		if err != nil {
+			db.logger.Error("Error mapping database data to struct.")
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// PurgeDeleted permanently removes the tasks and users deleted before a given time and returns
+// how many were removed. Users still owning tasks are kept until their tasks are purged.
+func (db *DB) PurgeDeleted(before time.Time) (int, error) {
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	tasks, err := tx.Exec(`
+		DELETE FROM public.task WHERE deleted_at < $1
+	`, before)
+	if err != nil {
+		db.logger.Error("Error purging tasks.")
+		return 0, err
+	}
+
+	users, err := tx.Exec(`
+		DELETE FROM public.user u
+		WHERE u.deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM public.task t WHERE t.user_id = u.id)
+	`, before)
+	if err != nil {
+		db.logger.Error("Error purging users.")
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	purgedTasks, err := tasks.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	purgedUsers, err := users.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(purgedTasks + purgedUsers), nil
+}
@@ -0,0 +1,293 @@
+package db
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (d *dbTestSuite) TestListDeletedTasks() {
+	errListTasks := errors.New("any error")
+	deletedAt := time.Now()
+	tasks := []common.Task{
+		{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do", DeletedAt: &deletedAt},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.Task
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: tasks,
+		},
+		"fail": {
+			dbError:     errListTasks,
+			expectedErr: errListTasks,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+), deleted_at FROM public.task WHERE user_id = \\$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows(append(slices.Clone(taskColumnNames), "deleted_at")).
+					AddRow("0001", "00001", "description 1", "to_do", nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0, deletedAt))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListDeletedTasks("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestGetDeletedTask() {
+	errGetTask := errors.New("any error")
+	deletedAt := time.Now()
+
+	tests := map[string]struct {
+		dbError      error
+		dbRows       *sqlmock.Rows
+		expectedResp *common.Task
+		expectedErr  error
+	}{
+		"success": {
+			dbRows: sqlmock.NewRows(append(slices.Clone(taskColumnNames), "deleted_at")).
+				AddRow("0001", "00001", "description 1", "to_do", nil, nil, nil, nil, "", nil, nil, "", nil, nil, 0, deletedAt),
+			expectedResp: &common.Task{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do", DeletedAt: &deletedAt},
+		},
+		"not in the trash": {
+			dbRows:       sqlmock.NewRows(append(slices.Clone(taskColumnNames), "deleted_at")),
+			expectedResp: &common.Task{},
+		},
+		"fail": {
+			dbError:     errGetTask,
+			expectedErr: errGetTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockGet := d.mock.ExpectQuery("SELECT (.+) FROM public.task WHERE id = (.+) AND deleted_at IS NOT NULL").WithArgs("0001")
+			if test.dbError == nil {
+				mockGet.WillReturnRows(test.dbRows)
+			} else {
+				mockGet.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.GetDeletedTask("0001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestRestoreTask() {
+	errRestoreTask := errors.New("error restoring task")
+	deletedAt := time.Now()
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp error
+	}{
+		"success": {},
+		"fail": {
+			dbError:      errRestoreTask,
+			expectedResp: errRestoreTask,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockRestore := d.mock.ExpectExec("WITH RECURSIVE tree AS (.+) UPDATE public.task SET deleted_at = NULL WHERE id IN").WithArgs("0001", deletedAt)
+			if test.dbError == nil {
+				mockRestore.WillReturnResult(sqlmock.NewResult(2, 2))
+			} else {
+				mockRestore.WillReturnError(test.dbError)
+			}
+
+			err := d.db.RestoreTask("0001", deletedAt)
+			d.Assert().Equal(test.expectedResp, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListDeletedUsers() {
+	errListUsers := errors.New("any error")
+	deletedAt := time.Now()
+	users := []common.User{
+		{Id: "00001", Username: "username1", Name: "User Name 1", DeletedAt: &deletedAt},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.User
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: users,
+		},
+		"fail": {
+			dbError:     errListUsers,
+			expectedErr: errListUsers,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.user WHERE id = \\$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC").WithArgs("00001")
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows([]string{"id", "username", "name", "deleted_at"}).
+					AddRow("00001", "username1", "User Name 1", deletedAt))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListDeletedUsers("00001")
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestRestoreUser() {
+	errRestore := errors.New("error restoring user")
+	deletedAt := time.Now()
+
+	tests := map[string]struct {
+		dbError1     error
+		dbError2     error
+		expectedResp error
+	}{
+		"success": {},
+		"fail1": {
+			dbError1:     errRestore,
+			expectedResp: errRestore,
+		},
+		"fail2": {
+			dbError2:     errRestore,
+			expectedResp: errRestore,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			mockUser := d.mock.ExpectExec("UPDATE public.user SET deleted_at = NULL WHERE id = (.+)").WithArgs("00001")
+			if test.dbError1 == nil {
+				mockUser.WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mockTasks := d.mock.ExpectExec("UPDATE public.task SET deleted_at = NULL WHERE user_id = (.+) AND deleted_at = (.+)").WithArgs("00001", deletedAt)
+				if test.dbError2 == nil {
+					mockTasks.WillReturnResult(sqlmock.NewResult(2, 2))
+					d.mock.ExpectCommit()
+				} else {
+					mockTasks.WillReturnError(test.dbError2)
+					d.mock.ExpectRollback()
+				}
+			} else {
+				mockUser.WillReturnError(test.dbError1)
+				d.mock.ExpectRollback()
+			}
+
+			err := d.db.RestoreUser("00001", deletedAt)
+			d.Assert().Equal(test.expectedResp, err)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (d *dbTestSuite) TestListDeletedAttachments() {
+	errListAttachments := errors.New("any error")
+	before := time.Now()
+	attachments := []common.Attachment{
+		{Id: "0001", TaskId: "00001", Name: "receipt.pdf", ContentType: "application/pdf", Size: 1024, Checksum: "abcdef", CreatedAt: before},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		expectedResp []common.Attachment
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: attachments,
+		},
+		"fail": {
+			dbError:     errListAttachments,
+			expectedErr: errListAttachments,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			mockList := d.mock.ExpectQuery("SELECT (.+) FROM public.attachment a JOIN public.task t ON t.id = a.task_id WHERE t.deleted_at < (.+)").WithArgs(before)
+			if test.dbError == nil {
+				mockList.WillReturnRows(sqlmock.NewRows(attachmentColumnNames).
+					AddRow("0001", "00001", "receipt.pdf", "application/pdf", 1024, "abcdef", before))
+			} else {
+				mockList.WillReturnError(test.dbError)
+			}
+
+			resp, err := d.db.ListDeletedAttachments(before)
+			d.Assert().Equal(test.expectedResp, resp)
+			d.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (d *dbTestSuite) TestPurgeDeleted() {
+	errPurge := errors.New("error purging")
+	before := time.Now()
+
+	tests := map[string]struct {
+		dbError1     error
+		dbError2     error
+		expectedResp int
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: 4,
+		},
+		"fail1": {
+			dbError1:    errPurge,
+			expectedErr: errPurge,
+		},
+		"fail2": {
+			dbError2:    errPurge,
+			expectedErr: errPurge,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			mockTasks := d.mock.ExpectExec("DELETE FROM public.task WHERE deleted_at < (.+)").WithArgs(before)
+			if test.dbError1 == nil {
+				mockTasks.WillReturnResult(sqlmock.NewResult(0, 3))
+
+				mockUsers := d.mock.ExpectExec("DELETE FROM public.user u WHERE u.deleted_at < (.+) AND NOT EXISTS").WithArgs(before)
+				if test.dbError2 == nil {
+					mockUsers.WillReturnResult(sqlmock.NewResult(0, 1))
+					d.mock.ExpectCommit()
+				} else {
+					mockUsers.WillReturnError(test.dbError2)
+					d.mock.ExpectRollback()
+				}
+			} else {
+				mockTasks.WillReturnError(test.dbError1)
+				d.mock.ExpectRollback()
+			}
+
+			purged, err := d.db.PurgeDeleted(before)
+			d.Assert().Equal(test.expectedResp, purged)
+			d.Assert().Equal(test.expectedErr, err)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// querier runs statements, either directly on the database or within a transaction.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// txn is a unit of work started by begin.
+type txn interface {
+	querier
+	Commit() error
+	Rollback() error
+}
+
+// conn returns what the statements of the DB run on.
+func (db *DB) conn() querier {
+	if db.tx != nil {
+		return db.tx
+	}
+
+	return db.db
+}
+
+// begin starts a transaction or, when the DB already runs within one, a savepoint of it, so
+// the methods needing a transaction of their own can also be part of a larger one.
+func (db *DB) begin() (txn, error) {
+	if db.tx != nil {
+		return newSavepoint(db.tx, fmt.Sprintf("sp_%d", db.depth))
+	}
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// InTx runs fn with a DB whose statements all run within one transaction, committed when fn
+// succeeds and rolled back when it fails. Called within a transaction, InTx runs fn in a
+// savepoint instead, so a failing fn only undoes its own statements.
+func (db *DB) InTx(fn func(tx DBInterface) error) error {
+	tx, err := db.begin()
+	if err != nil {
+		db.logger.Error("Error starting transaction.")
+		return err
+	}
+	defer tx.Rollback()
+
+	inner := &DB{db: db.db, tx: db.tx, depth: db.depth + 1, logger: db.logger}
+	if inner.tx == nil {
+		inner.tx = tx.(*sql.Tx)
+	}
+
+	if err := fn(inner); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// savepoint is a txn nested in a transaction.
+type savepoint struct {
+	*sql.Tx
+	name string
+	done bool
+}
+
+func newSavepoint(tx *sql.Tx, name string) (*savepoint, error) {
+	if _, err := tx.Exec("SAVEPOINT " + name); err != nil {
+		return nil, err
+	}
+
+	return &savepoint{Tx: tx, name: name}, nil
+}
+
+// Commit keeps the statements run since the savepoint, leaving them to the enclosing transaction.
+func (sp *savepoint) Commit() error {
+	if sp.done {
+		return sql.ErrTxDone
+	}
+	sp.done = true
+
+	_, err := sp.Tx.Exec("RELEASE SAVEPOINT " + sp.name)
+	return err
+}
+
+// Rollback undoes the statements run since the savepoint.
+func (sp *savepoint) Rollback() error {
+	if sp.done {
+		return sql.ErrTxDone
+	}
+	sp.done = true
+
+	_, err := sp.Tx.Exec("ROLLBACK TO SAVEPOINT " + sp.name)
+	return err
+}
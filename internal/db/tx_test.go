@@ -0,0 +1,91 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+)
+
+func (d *dbTestSuite) TestInTx() {
+	errDelete := errors.New("any error")
+	deletedAt := time.Now()
+
+	tests := map[string]struct {
+		nested      bool
+		dbError     error
+		expectedErr error
+	}{
+		"commit": {},
+		"rollback": {
+			dbError:     errDelete,
+			expectedErr: errDelete,
+		},
+		"release savepoint": {
+			nested: true,
+		},
+		"rollback to savepoint": {
+			nested:  true,
+			dbError: errDelete,
+		},
+	}
+
+	for index, test := range tests {
+		d.Run(index, func() {
+			d.mock.ExpectBegin()
+			if test.nested {
+				d.mock.ExpectExec("^SAVEPOINT sp_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			mockDelete := d.mock.ExpectExec("UPDATE public.task SET deleted_at = (.+) WHERE id IN").WithArgs("0001", deletedAt)
+			if test.dbError == nil {
+				mockDelete.WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				mockDelete.WillReturnError(test.dbError)
+			}
+			if test.nested && test.dbError == nil {
+				d.mock.ExpectExec("^RELEASE SAVEPOINT sp_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			if test.nested && test.dbError != nil {
+				d.mock.ExpectExec("^ROLLBACK TO SAVEPOINT sp_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			if test.expectedErr == nil {
+				d.mock.ExpectCommit()
+			} else {
+				d.mock.ExpectRollback()
+			}
+
+			err := d.db.InTx(func(tx DBInterface) error {
+				if test.nested {
+					// the error of a savepoint stays within it
+					tx.InTx(func(sp DBInterface) error {
+						return sp.DeleteTaskTree("0001", deletedAt)
+					})
+					return nil
+				}
+
+				return tx.DeleteTaskTree("0001", deletedAt)
+			})
+			d.Assert().Equal(test.expectedErr, err)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (d *dbTestSuite) TestInTxJoinsMethodTransaction() {
+	deletedAt := time.Now()
+
+	d.mock.ExpectBegin()
+	d.mock.ExpectExec("^SAVEPOINT sp_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+	d.mock.ExpectExec("UPDATE public.task SET parent_id = (.+) WHERE parent_id = (.+)").WithArgs("0001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	d.mock.ExpectExec("UPDATE public.task SET deleted_at = (.+) WHERE id = (.+) AND deleted_at IS NULL").WithArgs("0001", deletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	d.mock.ExpectExec("^RELEASE SAVEPOINT sp_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+	d.mock.ExpectCommit()
+
+	err := d.db.InTx(func(tx DBInterface) error {
+		return tx.DeleteTask("0001", deletedAt)
+	})
+	d.Assert().NoError(err)
+	d.Assert().NoError(d.mock.ExpectationsWereMet())
+}
@@ -1,11 +1,13 @@
 package db
 
 import (
+	"time"
+
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
 )
 
 func (db *DB) AddUser(user *common.User) error {
-	_, err := db.db.Exec(`
+	_, err := db.conn().Exec(`
 		INSERT INTO public.user(id, username, name)
 		VALUES($1, $2, $3)
 	`, user.Id, user.Username, user.Name)
@@ -18,26 +20,37 @@ func (db *DB) AddUser(user *common.User) error {
 	return nil
 }
 
+// UpdateUser updates a user from its version, bumping it, and returns ErrVersionConflict when the
+// user is at another version.
 func (db *DB) UpdateUser(user *common.User) error {
-	_, err := db.db.Exec(`
+	result, err := db.conn().Exec(`
 		UPDATE public.user
-		SET username = $1, name = $2
-		WHERE id = $3
-	`, user.Username, user.Name, user.Id)
+		SET username = $1, name = $2, version = version + 1
+		WHERE id = $3 AND version = $4
+	`, user.Username, user.Name, user.Id, user.Version)
+	if err != nil {
+		db.logger.Error("Error updating user.")
+		return err
+	}
 
+	rows, err := result.RowsAffected()
 	if err != nil {
 		db.logger.Error("Error updating user.")
 		return err
 	}
+	if rows == 0 {
+		return ErrVersionConflict
+	}
+	user.Version++
 
 	return nil
 }
 
 func (db *DB) GetUser(id string) (*common.User, error) {
-	results, err := db.db.Query(`
-		SELECT id, username, name
+	results, err := db.conn().Query(`
+		SELECT id, username, name, version
 		FROM public.user
-		WHERE id= $1`, id)
+		WHERE id= $1 AND deleted_at IS NULL`, id)
 
 	if err != nil {
 		db.logger.Error("Error retrieving user.")
@@ -49,7 +62,8 @@ func (db *DB) GetUser(id string) (*common.User, error) {
 		err = results.Scan(
 			&user.Id,
 			&user.Username,
-			&user.Name)
+			&user.Name,
+			&user.Version)
 		if err != nil {
 			db.logger.Error("Error mapping database data to struct.")
 			return nil, err
@@ -58,10 +72,13 @@ func (db *DB) GetUser(id string) (*common.User, error) {
 	return &user, nil
 }
 
-func (db *DB) DeleteUser(id string) error {
-	_, err := db.db.Exec(`
-		DELETE FROM public.user WHERE id = $1
-	`, id)
+// DeleteUser moves a user to the trash.
+func (db *DB) DeleteUser(id string, deletedAt time.Time) error {
+	_, err := db.conn().Exec(`
+		UPDATE public.user
+		SET deleted_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, deletedAt)
 
 	if err != nil {
 		db.logger.Error("Error deleting user.")
@@ -71,10 +88,19 @@ func (db *DB) DeleteUser(id string) error {
 	return nil
 }
 
-func (db *DB) ListUsers() ([]common.User, error) {
-	results, err := db.db.Query(`
-		SELECT id, username, name
-		FROM public.user`)
+// ListUsers returns a page of the users, ordered by id.
+func (db *DB) ListUsers(page common.PageRequest) (*common.UserPage, error) {
+	conds := conditions{}
+	conds.add("deleted_at IS NULL")
+	limit, err := addPage(&conds, page, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := db.conn().Query(`
+		SELECT id, username, name, version
+		FROM public.user`+conds.where()+`
+		ORDER BY id`+limit, conds.args...)
 
 	if err != nil {
 		db.logger.Error("Error retrieving users.")
@@ -87,12 +113,18 @@ func (db *DB) ListUsers() ([]common.User, error) {
 		err = results.Scan(
 			&user.Id,
 			&user.Username,
-			&user.Name)
+			&user.Name,
+			&user.Version)
 		if err != nil {
 			db.logger.Error("Error mapping database data to struct.")
 			return nil, err
 		}
 		users = append(users, user)
 	}
-	return users, nil
+
+	result := &common.UserPage{}
+	result.Items, result.NextCursor = pageItems(users, page, func(user common.User) []string {
+		return []string{user.Id}
+	})
+	return result, nil
 }
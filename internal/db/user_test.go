@@ -1,7 +1,9 @@
 package db
 
 import (
+	"database/sql/driver"
 	"errors"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
@@ -49,39 +51,47 @@ func (d *dbTestSuite) TestAddUser() {
 
 func (d *dbTestSuite) TestUpdateUser() {
 	errUpdateUser := errors.New("error updating user")
-	user := &common.User{
-		Username: "username1",
-		Name:     "User Name 1",
-	}
 
 	tests := map[string]struct {
-		user         *common.User
-		dbError      error
-		expectedResp error
+		user            *common.User
+		dbResult        driver.Result
+		dbError         error
+		expectedVersion int
+		expectedResp    error
 	}{
 		"success": {
-			user:         user,
-			dbError:      nil,
-			expectedResp: nil,
+			user:            &common.User{Id: "00001", Username: "username1", Name: "User Name 1", Version: 2},
+			dbResult:        sqlmock.NewResult(1, 1),
+			expectedVersion: 3,
+		},
+		"version conflict": {
+			user:            &common.User{Id: "00001", Username: "username1", Name: "User Name 1", Version: 2},
+			dbResult:        sqlmock.NewResult(0, 0),
+			expectedVersion: 2,
+			expectedResp:    ErrVersionConflict,
 		},
 		"fail": {
-			user:         user,
-			dbError:      errUpdateUser,
-			expectedResp: errUpdateUser,
+			user:            &common.User{Id: "00001", Username: "username1", Name: "User Name 1", Version: 2},
+			dbError:         errUpdateUser,
+			expectedVersion: 2,
+			expectedResp:    errUpdateUser,
 		},
 	}
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockUpdate := d.mock.ExpectExec("UPDATE public.user").WithArgs(test.user.Username, test.user.Name, test.user.Id)
+			mockUpdate := d.mock.ExpectExec("UPDATE public.user SET username = \\$1, name = \\$2, version = version \\+ 1 WHERE id = \\$3 AND version = \\$4").
+				WithArgs(test.user.Username, test.user.Name, test.user.Id, test.user.Version)
 			if test.dbError == nil {
-				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
+				mockUpdate.WillReturnResult(test.dbResult)
 			} else {
 				mockUpdate.WillReturnError(test.dbError)
 			}
 
 			err := d.db.UpdateUser(test.user)
-			d.Assert().Equal(err, test.expectedResp)
+			d.Assert().Equal(test.expectedResp, err)
+			d.Assert().Equal(test.expectedVersion, test.user.Version)
+			d.Assert().NoError(d.mock.ExpectationsWereMet())
 		})
 
 	}
@@ -92,8 +102,9 @@ func (d *dbTestSuite) TestGetUser() {
 	user := &common.User{
 		Username: "username1",
 		Name:     "User Name 1",
+		Version:  2,
 	}
-	rowUser := sqlmock.NewRows([]string{"id", "username", "name"}).AddRow("", user.Username, user.Name)
+	rowUser := sqlmock.NewRows([]string{"id", "username", "name", "version"}).AddRow("", user.Username, user.Name, user.Version)
 
 	tests := map[string]struct {
 		id           string
@@ -118,7 +129,7 @@ func (d *dbTestSuite) TestGetUser() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockGet := d.mock.ExpectQuery("SELECT id, username, name FROM public.user").WithArgs(test.id)
+			mockGet := d.mock.ExpectQuery("SELECT id, username, name, version FROM public.user").WithArgs(test.id)
 			if test.dbError == nil {
 				mockGet.WillReturnRows(test.dbRowUser)
 			} else {
@@ -135,6 +146,7 @@ func (d *dbTestSuite) TestGetUser() {
 
 func (d *dbTestSuite) TestDeleteUser() {
 	errDeleteUser := errors.New("error deleting user")
+	now := time.Now()
 
 	tests := map[string]struct {
 		id           string
@@ -153,14 +165,14 @@ func (d *dbTestSuite) TestDeleteUser() {
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockUpdate := d.mock.ExpectExec("DELETE FROM public.user").WithArgs(test.id)
+			mockUpdate := d.mock.ExpectExec("UPDATE public.user SET deleted_at = (.+) WHERE id = (.+)").WithArgs(test.id, now)
 			if test.dbError == nil {
 				mockUpdate.WillReturnResult(sqlmock.NewResult(1, 1))
 			} else {
 				mockUpdate.WillReturnError(test.dbError)
 			}
 
-			err := d.db.DeleteUser(test.id)
+			err := d.db.DeleteUser(test.id, now)
 			d.Assert().Equal(err, test.expectedResp)
 		})
 
@@ -173,47 +185,77 @@ func (d *dbTestSuite) TestListUsers() {
 		{
 			Username: "username1",
 			Name:     "User Name 1",
+			Version:  1,
 		},
 		{
 			Username: "username2",
 			Name:     "User Name 2",
+			Version:  3,
 		},
 	}
 
-	rowUsers := sqlmock.NewRows([]string{"id", "username", "name"}).
-		AddRow("", listUsers[0].Username, listUsers[0].Name).
-		AddRow("", listUsers[1].Username, listUsers[1].Name)
+	rowUsers := sqlmock.NewRows([]string{"id", "username", "name", "version"}).
+		AddRow("", listUsers[0].Username, listUsers[0].Name, listUsers[0].Version).
+		AddRow("", listUsers[1].Username, listUsers[1].Name, listUsers[1].Version)
+
+	pageUsers := []common.User{
+		{Id: "00000000-0000-0000-0000-000000000002", Username: "username2", Name: "User Name 2", Version: 1},
+		{Id: "00000000-0000-0000-0000-000000000003", Username: "username3", Name: "User Name 3", Version: 2},
+	}
+	rowPageUsers := sqlmock.NewRows([]string{"id", "username", "name", "version"}).
+		AddRow(pageUsers[0].Id, pageUsers[0].Username, pageUsers[0].Name, pageUsers[0].Version).
+		AddRow(pageUsers[1].Id, pageUsers[1].Username, pageUsers[1].Name, pageUsers[1].Version)
 
 	tests := map[string]struct {
-		dbError      error
-		dbRowUser    *sqlmock.Rows
-		expectedResp []common.User
-		expectedErr  error
+		page          common.PageRequest
+		expectedQuery string
+		expectedArgs  []driver.Value
+		dbError       error
+		dbRowUser     *sqlmock.Rows
+		expectedResp  *common.UserPage
+		expectedErr   error
 	}{
 		"success": {
-			dbError:      nil,
-			dbRowUser:    rowUsers,
-			expectedResp: listUsers,
-			expectedErr:  nil,
+			expectedQuery: "SELECT id, username, name, version FROM public.user WHERE deleted_at IS NULL ORDER BY id$",
+			expectedArgs:  []driver.Value{},
+			dbError:       nil,
+			dbRowUser:     rowUsers,
+			expectedResp:  &common.UserPage{Items: listUsers},
+			expectedErr:   nil,
+		},
+		"page": {
+			page:          common.PageRequest{Limit: 1, Cursor: encodeCursor("00000000-0000-0000-0000-000000000001")},
+			expectedQuery: "SELECT id, username, name, version FROM public.user WHERE deleted_at IS NULL AND \\(id\\) > \\(\\$1\\) ORDER BY id LIMIT 2$",
+			expectedArgs:  []driver.Value{"00000000-0000-0000-0000-000000000001"},
+			dbRowUser:     rowPageUsers,
+			expectedResp:  &common.UserPage{Items: pageUsers[:1], NextCursor: encodeCursor(pageUsers[0].Id)},
+		},
+		"invalid cursor": {
+			page:        common.PageRequest{Cursor: encodeCursor("username1")},
+			expectedErr: ErrInvalidCursor,
 		},
 		"fail": {
-			dbError:      errListUsers,
-			dbRowUser:    nil,
-			expectedResp: nil,
-			expectedErr:  errListUsers,
+			expectedQuery: "SELECT id, username, name, version FROM public.user",
+			expectedArgs:  []driver.Value{},
+			dbError:       errListUsers,
+			dbRowUser:     nil,
+			expectedResp:  nil,
+			expectedErr:   errListUsers,
 		},
 	}
 
 	for index, test := range tests {
 		d.Run(index, func() {
-			mockGet := d.mock.ExpectQuery("SELECT id, username, name FROM public.user")
-			if test.dbError == nil {
-				mockGet.WillReturnRows(test.dbRowUser)
-			} else {
-				mockGet.WillReturnError(errListUsers)
+			if test.expectedQuery != "" {
+				mockGet := d.mock.ExpectQuery(test.expectedQuery).WithArgs(test.expectedArgs...)
+				if test.dbError == nil {
+					mockGet.WillReturnRows(test.dbRowUser)
+				} else {
+					mockGet.WillReturnError(errListUsers)
+				}
 			}
 
-			user, err := d.db.ListUsers()
+			user, err := d.db.ListUsers(test.page)
 			d.Assert().Equal(user, test.expectedResp)
 			d.Assert().Equal(err, test.expectedErr)
 		})
@@ -0,0 +1,35 @@
+// Package events provides an interface for emitting domain events about tasks.
+// The service layer is given an Emitter at creation to control what happens to events as they are produced.
+// One Emitter is provided:
+//
+//	zapEmitter writes events to a zap.Logger.
+//
+// A custom Emitter can be provided by the caller if desired.
+package events
+
+import (
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+type EventType string
+
+const (
+	TaskReminderType = EventType("task.reminder")
+	TaskChangedType  = EventType("task.changed")
+)
+
+// Event represents a single domain event about a task.
+type Event struct {
+	Type      EventType    `json:"type"`
+	Timestamp time.Time    `json:"timestamp"`
+	Task      *common.Task `json:"task"`
+	// Changes lists the fields of the task that changed, for the events about changes.
+	Changes []common.FieldChange `json:"changes,omitempty"`
+}
+
+// Emitter controls what happens to an event once it is produced.
+type Emitter interface {
+	Emit(event Event) error
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/events/events.go
+
+// Package mock_events is a generated GoMock package.
+package mock_events
+
+import (
+	reflect "reflect"
+
+	events "github.com/aborgesrodrigues/to-do-api/internal/events"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmitter is a mock of Emitter interface.
+type MockEmitter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmitterMockRecorder
+}
+
+// MockEmitterMockRecorder is the mock recorder for MockEmitter.
+type MockEmitterMockRecorder struct {
+	mock *MockEmitter
+}
+
+// NewMockEmitter creates a new mock instance.
+func NewMockEmitter(ctrl *gomock.Controller) *MockEmitter {
+	mock := &MockEmitter{ctrl: ctrl}
+	mock.recorder = &MockEmitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmitter) EXPECT() *MockEmitterMockRecorder {
+	return m.recorder
+}
+
+// Emit mocks base method.
+func (m *MockEmitter) Emit(event events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Emit", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Emit indicates an expected call of Emit.
+func (mr *MockEmitterMockRecorder) Emit(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emit", reflect.TypeOf((*MockEmitter)(nil).Emit), event)
+}
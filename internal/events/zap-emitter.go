@@ -0,0 +1,34 @@
+package events
+
+import (
+	"go.uber.org/zap"
+)
+
+type zapEmitter struct {
+	logger *zap.Logger
+}
+
+// NewZapEmitter provides a zap-based Emitter. It is the default emitter of the service and is
+// also useful in unit tests, where creating the zap logger using zaptest is recommended.
+func NewZapEmitter(logger *zap.Logger) Emitter {
+	return &zapEmitter{
+		logger: logger,
+	}
+}
+
+func (e zapEmitter) Emit(event Event) error {
+	fields := []zap.Field{
+		zap.String("type", string(event.Type)),
+		zap.Time("timestamp", event.Timestamp),
+	}
+	if event.Task != nil {
+		fields = append(fields,
+			zap.String("task_id", event.Task.Id),
+			zap.String("user_id", event.Task.UserId),
+		)
+	}
+
+	e.logger.Info("Task event.", fields...)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestZapEmitter(t *testing.T) {
+	core, observed := observer.New(zap.DebugLevel)
+	logger := zaptest.NewLogger(t, zaptest.WrapOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return core
+	})))
+
+	emitter := NewZapEmitter(logger)
+
+	e := Event{
+		Type:      TaskReminderType,
+		Timestamp: time.Time{},
+		Task: &common.Task{
+			Id:     "0001",
+			UserId: "00001",
+		},
+	}
+	err := emitter.Emit(e)
+
+	assert.NoError(t, err)
+	expected := []observer.LoggedEntry{
+		{
+			Entry: zapcore.Entry{
+				Level:   zap.InfoLevel,
+				Message: "Task event.",
+			},
+			Context: []zapcore.Field{
+				zap.String("type", string(e.Type)),
+				zap.Time("timestamp", e.Timestamp),
+				zap.String("task_id", e.Task.Id),
+				zap.String("user_id", e.Task.UserId),
+			},
+		},
+	}
+	assert.Equal(t, expected, observed.AllUntimed())
+}
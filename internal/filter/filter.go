@@ -0,0 +1,379 @@
+// Package filter parses the filter expressions of the task lists, such as:
+//
+//	state in (to_do, in_progress) and description ~ "deploy" and created_at > 2026-01-01
+//
+// An expression compares fields with values, using =, !=, <, <=, >, >=, ~ (contains), !~ (does not
+// contain), in and not in, and combines the comparisons with and, or, not and parentheses; and
+// binds tighter than or. Values are bare words, or double-quoted strings where a backslash escapes
+// the next character. The unquoted null keyword only compares with = and !=.
+//
+// Parse only checks the syntax of an expression: which fields exist and what their values mean is
+// up to the code compiling its AST, which reports its own errors with Errorf.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidExpression is returned when an expression cannot be parsed or compiled.
+var ErrInvalidExpression = errors.New("invalid filter expression")
+
+// Errorf returns an error wrapping ErrInvalidExpression about the part of an expression at pos.
+func Errorf(pos int, format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidExpression, fmt.Sprintf(format, args...), pos)
+}
+
+// Expr is a node of the AST of an expression: a *Logical, a *Not or a *Comparison.
+type Expr interface {
+	// Pos is the position of the node in the expression, counting characters from 1.
+	Pos() int
+}
+
+type LogicalOp string
+
+const (
+	And = LogicalOp("and")
+	Or  = LogicalOp("or")
+)
+
+// Logical joins two expressions with and or or.
+type Logical struct {
+	Op    LogicalOp
+	Left  Expr
+	Right Expr
+}
+
+func (e *Logical) Pos() int {
+	return e.Left.Pos()
+}
+
+// Not negates an expression.
+type Not struct {
+	Expr     Expr
+	Position int
+}
+
+func (e *Not) Pos() int {
+	return e.Position
+}
+
+type Op string
+
+const (
+	Equal          = Op("=")
+	NotEqual       = Op("!=")
+	Less           = Op("<")
+	LessOrEqual    = Op("<=")
+	Greater        = Op(">")
+	GreaterOrEqual = Op(">=")
+	Contains       = Op("~")
+	NotContains    = Op("!~")
+	In             = Op("in")
+	NotIn          = Op("not in")
+)
+
+// Comparison compares a field with a value or, for In and NotIn, with a list of values.
+type Comparison struct {
+	Field    string
+	Op       Op
+	Values   []Value
+	Position int
+}
+
+func (e *Comparison) Pos() int {
+	return e.Position
+}
+
+// Value is a value of a comparison as written in the expression, unquoted. Null is set for the
+// null keyword, leaving Text empty.
+type Value struct {
+	Text     string
+	Null     bool
+	Position int
+}
+
+// Parse parses an expression into its AST.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return nil, Errorf(1, "empty expression")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, Errorf(next.pos, "unexpected %s", next)
+	}
+
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("%q", t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// keyword reports whether the token is the given keyword, which is case-insensitive.
+func (t token) keyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// isKeyword reports whether the token is one of the keywords of the syntax.
+func (t token) isKeyword() bool {
+	for _, keyword := range []string{"and", "or", "not", "in", "null"} {
+		if t.keyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// lex splits an expression into tokens, ending with a tokenEnd.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+		case r == '=' || r == '~':
+			tokens = append(tokens, token{kind: tokenOp, text: string(r), pos: pos})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '!' && runes[i+1] == '~')) {
+				op += string(runes[i+1])
+			}
+			if op == "!" {
+				return nil, Errorf(pos, "'!' must be followed by '=' or '~'")
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+			i += len(op)
+		case r == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, Errorf(pos, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: pos})
+			i++
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), pos: pos})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEnd, pos: len(runes) + 1}), nil
+}
+
+// isWordRune reports whether r belongs to a bare word: anything but spaces, parentheses, commas,
+// quotes and operators, so that dates and times such as 2026-01-31T09:00:00+01:00 are words.
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`(),"=~<>!`, r)
+}
+
+// parser is a recursive descent parser over the tokens of an expression.
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEnd {
+		p.next++
+	}
+	return t
+}
+
+// parseOr parses the expressions joined with or.
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("or") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: Or, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses the expressions joined with and.
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword("and") {
+		p.advance()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: And, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses a negation, an expression between parentheses or a comparison.
+func (p *parser) parseUnary() (Expr, error) {
+	next := p.peek()
+	switch {
+	case next.keyword("not"):
+		p.advance()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr, Position: next.pos}, nil
+	case next.kind == tokenOpen:
+		p.advance()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenClose {
+			return nil, Errorf(closing.pos, "expected ')', found %s", closing)
+		}
+		return expr, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+// parseComparison parses a field, an operator and the value or list of values it is compared with.
+func (p *parser) parseComparison() (Expr, error) {
+	field := p.advance()
+	if field.kind != tokenWord || field.isKeyword() {
+		return nil, Errorf(field.pos, "expected a field, found %s", field)
+	}
+	comparison := &Comparison{Field: field.text, Position: field.pos}
+
+	op := p.advance()
+	switch {
+	case op.kind == tokenOp:
+		comparison.Op = Op(op.text)
+	case op.keyword("in"):
+		comparison.Op = In
+	case op.keyword("not") && p.peek().keyword("in"):
+		p.advance()
+		comparison.Op = NotIn
+	default:
+		return nil, Errorf(op.pos, "expected an operator after %s, found %s", field.text, op)
+	}
+
+	if comparison.Op != In && comparison.Op != NotIn {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if value.Null && comparison.Op != Equal && comparison.Op != NotEqual {
+			return nil, Errorf(value.Position, "null only compares with = and !=")
+		}
+		comparison.Values = []Value{value}
+		return comparison, nil
+	}
+
+	if open := p.advance(); open.kind != tokenOpen {
+		return nil, Errorf(open.pos, "expected '(' after %s, found %s", comparison.Op, open)
+	}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if value.Null {
+			return nil, Errorf(value.Position, "null only compares with = and !=")
+		}
+		comparison.Values = append(comparison.Values, value)
+
+		next := p.advance()
+		if next.kind == tokenClose {
+			return comparison, nil
+		}
+		if next.kind != tokenComma {
+			return nil, Errorf(next.pos, "expected ',' or ')', found %s", next)
+		}
+	}
+}
+
+// parseValue parses a bare word, a string or the null keyword.
+func (p *parser) parseValue() (Value, error) {
+	value := p.advance()
+	switch {
+	case value.kind == tokenString:
+		return Value{Text: value.text, Position: value.pos}, nil
+	case value.keyword("null"):
+		return Value{Null: true, Position: value.pos}, nil
+	case value.kind == tokenWord && !value.isKeyword():
+		return Value{Text: value.text, Position: value.pos}, nil
+	default:
+		return Value{}, Errorf(value.pos, "expected a value, found %s", value)
+	}
+}
@@ -0,0 +1,150 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected Expr
+	}{
+		"comparison": {
+			input:    "state = done",
+			expected: &Comparison{Field: "state", Op: Equal, Values: []Value{{Text: "done", Position: 9}}, Position: 1},
+		},
+		"operators without spaces": {
+			input:    "due_at<=2026-01-31T09:00:00+01:00",
+			expected: &Comparison{Field: "due_at", Op: LessOrEqual, Values: []Value{{Text: "2026-01-31T09:00:00+01:00", Position: 9}}, Position: 1},
+		},
+		"quoted string": {
+			input:    `description !~ "say \"hi\" \\ bye"`,
+			expected: &Comparison{Field: "description", Op: NotContains, Values: []Value{{Text: `say "hi" \ bye`, Position: 16}}, Position: 1},
+		},
+		"quoted keyword": {
+			input:    `description = "and"`,
+			expected: &Comparison{Field: "description", Op: Equal, Values: []Value{{Text: "and", Position: 15}}, Position: 1},
+		},
+		"null": {
+			input:    "due_at != NULL",
+			expected: &Comparison{Field: "due_at", Op: NotEqual, Values: []Value{{Null: true, Position: 11}}, Position: 1},
+		},
+		"in": {
+			input: "state in (to_do,in_progress)",
+			expected: &Comparison{Field: "state", Op: In, Values: []Value{
+				{Text: "to_do", Position: 11},
+				{Text: "in_progress", Position: 17},
+			}, Position: 1},
+		},
+		"not in": {
+			input:    "state NOT IN (done)",
+			expected: &Comparison{Field: "state", Op: NotIn, Values: []Value{{Text: "done", Position: 15}}, Position: 1},
+		},
+		"and binds tighter than or": {
+			input: "a = 1 or b = 2 and c = 3",
+			expected: &Logical{
+				Op:   Or,
+				Left: &Comparison{Field: "a", Op: Equal, Values: []Value{{Text: "1", Position: 5}}, Position: 1},
+				Right: &Logical{
+					Op:    And,
+					Left:  &Comparison{Field: "b", Op: Equal, Values: []Value{{Text: "2", Position: 14}}, Position: 10},
+					Right: &Comparison{Field: "c", Op: Equal, Values: []Value{{Text: "3", Position: 24}}, Position: 20},
+				},
+			},
+		},
+		"parentheses and not": {
+			input: "not (a = 1 or b = 2) and c = 3",
+			expected: &Logical{
+				Op: And,
+				Left: &Not{
+					Expr: &Logical{
+						Op:    Or,
+						Left:  &Comparison{Field: "a", Op: Equal, Values: []Value{{Text: "1", Position: 10}}, Position: 6},
+						Right: &Comparison{Field: "b", Op: Equal, Values: []Value{{Text: "2", Position: 19}}, Position: 15},
+					},
+					Position: 1,
+				},
+				Right: &Comparison{Field: "c", Op: Equal, Values: []Value{{Text: "3", Position: 30}}, Position: 26},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, expr)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"empty": {
+			input:    "  ",
+			expected: "invalid filter expression: empty expression at position 1",
+		},
+		"lone exclamation mark": {
+			input:    "state ! done",
+			expected: "invalid filter expression: '!' must be followed by '=' or '~' at position 7",
+		},
+		"unterminated string": {
+			input:    `description ~ "deploy`,
+			expected: "invalid filter expression: unterminated string at position 15",
+		},
+		"missing operator": {
+			input:    "state done",
+			expected: "invalid filter expression: expected an operator after state, found 'done' at position 7",
+		},
+		"missing value": {
+			input:    "state =",
+			expected: "invalid filter expression: expected a value, found end of expression at position 8",
+		},
+		"keyword as a field": {
+			input:    "and = 1",
+			expected: "invalid filter expression: expected a field, found 'and' at position 1",
+		},
+		"keyword as a value": {
+			input:    "state = or",
+			expected: "invalid filter expression: expected a value, found 'or' at position 9",
+		},
+		"null with an order": {
+			input:    "due_at < null",
+			expected: "invalid filter expression: null only compares with = and != at position 10",
+		},
+		"null in a list": {
+			input:    "state in (done, null)",
+			expected: "invalid filter expression: null only compares with = and != at position 17",
+		},
+		"in without a list": {
+			input:    "state in done",
+			expected: "invalid filter expression: expected '(' after in, found 'done' at position 10",
+		},
+		"unterminated list": {
+			input:    "state in (done",
+			expected: "invalid filter expression: expected ',' or ')', found end of expression at position 15",
+		},
+		"unbalanced parenthesis": {
+			input:    "(state = done",
+			expected: "invalid filter expression: expected ')', found end of expression at position 14",
+		},
+		"trailing tokens": {
+			input:    "state = done)",
+			expected: "invalid filter expression: unexpected ')' at position 13",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(test.input)
+			assert.ErrorIs(t, err, ErrInvalidExpression)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
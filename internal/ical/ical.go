@@ -0,0 +1,123 @@
+// Package ical writes calendars in the iCalendar format defined by RFC 5545.
+//
+// A Writer emits the content lines of a calendar one property at a time, escaping TEXT values
+// and folding lines longer than 75 octets:
+//
+//	w := ical.NewWriter(out)
+//	w.Begin("VCALENDAR")
+//	w.Property("VERSION", "2.0")
+//	w.Begin("VTODO")
+//	w.Text("SUMMARY", "buy milk; eggs")
+//	w.Time("DUE", due)
+//	w.End("VTODO")
+//	w.End("VCALENDAR")
+//	err := w.Flush()
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar documents.
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineLen is the longest content line, in octets and without the line break, before it is folded.
+const maxLineLen = 75
+
+// Writer writes the content lines of an iCalendar document. The first error met while writing
+// is kept and returned by Flush, so callers do not have to check every line.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewWriter returns a Writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Begin opens a component, such as VCALENDAR or VTODO.
+func (w *Writer) Begin(component string) {
+	w.line("BEGIN:" + component)
+}
+
+// End closes a component opened by Begin.
+func (w *Writer) End(component string) {
+	w.line("END:" + component)
+}
+
+// Property writes a property whose value is already in its iCalendar form, such as an RRULE.
+// The name may carry parameters, as in "TRIGGER;VALUE=DATE-TIME".
+func (w *Writer) Property(name string, value string) {
+	w.line(name + ":" + value)
+}
+
+// Text writes a property of type TEXT, escaping its value.
+func (w *Writer) Text(name string, value string) {
+	w.Property(name, Escape(value))
+}
+
+// TextList writes a property holding a list of TEXT values, such as CATEGORIES.
+func (w *Writer) TextList(name string, values []string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = Escape(value)
+	}
+	w.Property(name, strings.Join(escaped, ","))
+}
+
+// Time writes a property of type DATE-TIME, in UTC.
+func (w *Writer) Time(name string, t time.Time) {
+	w.Property(name, FormatTime(t))
+}
+
+// Flush writes any buffered data and returns the first error met while writing.
+func (w *Writer) Flush() error {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.err
+}
+
+// line writes a content line, folding it into continuation lines starting with a space.
+func (w *Writer) line(s string) {
+	if w.err != nil {
+		return
+	}
+
+	limit := maxLineLen
+	for len(s) > limit {
+		// never split a multi-byte character between two lines
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.write(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// continuation lines lose an octet to their leading space
+		limit = maxLineLen - 1
+	}
+	w.write(s + "\r\n")
+}
+
+func (w *Writer) write(s string) {
+	if w.err == nil {
+		_, w.err = w.w.WriteString(s)
+	}
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Escape escapes a value of type TEXT: backslashes, semicolons, commas and line breaks.
+func Escape(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// FormatTime formats t as a DATE-TIME in UTC, such as 20240131T093000Z.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,82 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	var out strings.Builder
+	w := NewWriter(&out)
+	w.Begin("VTODO")
+	w.Property("RRULE", "FREQ=WEEKLY;BYDAY=MO")
+	w.Text("SUMMARY", "buy milk; eggs, bread\nand butter\\")
+	w.TextList("CATEGORIES", []string{"home", "a,b"})
+	w.Time("DUE", time.Date(2026, time.January, 31, 9, 30, 0, 0, time.FixedZone("", -3*60*60)))
+	w.End("VTODO")
+
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "BEGIN:VTODO\r\n"+
+		"RRULE:FREQ=WEEKLY;BYDAY=MO\r\n"+
+		"SUMMARY:buy milk\\; eggs\\, bread\\nand butter\\\\\r\n"+
+		"CATEGORIES:home,a\\,b\r\n"+
+		"DUE:20260131T123000Z\r\n"+
+		"END:VTODO\r\n", out.String())
+}
+
+func TestWriterFolding(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected []string
+	}{
+		"short": {
+			value:    "short",
+			expected: []string{"SUMMARY:short"},
+		},
+		"exactly one line": {
+			value:    strings.Repeat("a", 67),
+			expected: []string{"SUMMARY:" + strings.Repeat("a", 67)},
+		},
+		"long": {
+			value:    strings.Repeat("a", 150),
+			expected: []string{"SUMMARY:" + strings.Repeat("a", 67), " " + strings.Repeat("a", 74), " " + strings.Repeat("a", 9)},
+		},
+		"multi-byte characters": {
+			// the 67th octet starts a two-octet character, which moves to the next line whole
+			value:    strings.Repeat("a", 66) + "éé",
+			expected: []string{"SUMMARY:" + strings.Repeat("a", 66), " éé"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			w := NewWriter(&out)
+			w.Text("SUMMARY", test.value)
+
+			assert.NoError(t, w.Flush())
+			assert.Equal(t, strings.Join(test.expected, "\r\n")+"\r\n", out.String())
+			for _, line := range test.expected {
+				assert.LessOrEqual(t, len(line), maxLineLen)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriterError(t *testing.T) {
+	w := NewWriter(failingWriter{})
+	w.Begin("VCALENDAR")
+	w.End("VCALENDAR")
+
+	assert.EqualError(t, w.Flush(), "disk full")
+}
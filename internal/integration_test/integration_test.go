@@ -11,7 +11,9 @@ import (
 
 	"github.com/aborgesrodrigues/to-do-api/cmd/handlers"
 	"github.com/aborgesrodrigues/to-do-api/internal/audit"
+	"github.com/aborgesrodrigues/to-do-api/internal/blob"
 	"github.com/aborgesrodrigues/to-do-api/internal/logging"
+	"github.com/aborgesrodrigues/to-do-api/internal/service"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -44,7 +46,9 @@ func (s *testSuite) SetupSuite() {
 
 	defer auditLogger.Close()
 
-	hdl := handlers.New(logger, auditLogger)
+	hdl := handlers.New(logger, auditLogger, service.AttachmentConfig{
+		Store: blob.NewFileStore(s.T().TempDir()),
+	})
 	s.handler = hdl
 
 	logger.Info("Server listening.", zap.String("addr", "8080"))
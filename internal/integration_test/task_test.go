@@ -11,10 +11,10 @@ import (
 )
 
 func (s *testSuite) listTasks() []common.Task {
-	tasks := make([]common.Task, 0)
-	s.call("GET", "http://localhost:8080/tasks", nil, &tasks)
+	page := &common.TaskPage{}
+	s.call("GET", "http://localhost:8080/tasks", nil, page)
 
-	return tasks
+	return page.Items
 }
 
 func (s *testSuite) getTask(id string) *common.Task {
@@ -11,10 +11,10 @@ import (
 )
 
 func (s *testSuite) listUsers() []common.User {
-	users := make([]common.User, 0)
-	s.call("GET", "http://localhost:8080/users", nil, &users)
+	page := &common.UserPage{}
+	s.call("GET", "http://localhost:8080/users", nil, page)
 
-	return users
+	return page.Items
 }
 
 func (s *testSuite) getUser(id string) *common.User {
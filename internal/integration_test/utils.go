@@ -17,18 +17,118 @@ func getRouter(hdl *handlers.Handler) *chi.Mux {
 				r.Get("/", hdl.GetUser)
 				r.Put("/", hdl.UpdateUser)
 				r.Delete("/", hdl.DeleteUser)
+				r.Post("/restore", hdl.RestoreUser)
 				r.Get("/tasks", hdl.ListUserTasks)
+				r.Get("/tasks.ics", hdl.GetTaskFeed)
+				r.Get("/projects", hdl.ListUserProjects)
+				r.Get("/timesheet", hdl.GetTimesheet)
+				r.Post("/import", hdl.ImportTasks)
+				r.Get("/export", hdl.ExportTasks)
+				r.Route("/board", func(r chi.Router) {
+					r.Get("/", hdl.GetBoard)
+					r.Put("/limits", hdl.SetWIPLimits)
+				})
+				r.Route("/feed_token", func(r chi.Router) {
+					r.Post("/", hdl.CreateFeedToken)
+					r.Delete("/", hdl.RevokeFeedToken)
+				})
+				r.Route("/labels", func(r chi.Router) {
+					r.Get("/", hdl.ListUserLabels)
+					r.Post("/", hdl.AddLabel)
+					r.Route("/{SubId}", func(r chi.Router) {
+						r.Use(hdl.SubIdMiddleware)
+						r.Get("/", hdl.GetLabel)
+						r.Put("/", hdl.UpdateLabel)
+						r.Delete("/", hdl.DeleteLabel)
+					})
+				})
 			})
 		})
 
+		r.Route("/projects", func(r chi.Router) {
+			r.Get("/", hdl.ListProjects)
+			r.Post("/", hdl.AddProject)
+			r.Route("/{Id}", func(r chi.Router) {
+				r.Use(hdl.IdMiddleware)
+				r.Get("/", hdl.GetProject)
+				r.Put("/", hdl.UpdateProject)
+				r.Delete("/", hdl.DeleteProject)
+				r.Get("/tasks", hdl.ListProjectTasks)
+			})
+		})
+
+		r.Route("/templates", func(r chi.Router) {
+			r.Get("/", hdl.ListTemplates)
+			r.Post("/", hdl.AddTemplate)
+			r.Route("/{Id}", func(r chi.Router) {
+				r.Use(hdl.IdMiddleware)
+				r.Get("/", hdl.GetTemplate)
+				r.Delete("/", hdl.DeleteTemplate)
+				r.Post("/instantiate", hdl.InstantiateTemplate)
+			})
+		})
+
+		r.Get("/trash", hdl.ListTrash)
+		r.Post("/tasks:batch", hdl.BatchTasks)
+
 		r.Route("/tasks", func(r chi.Router) {
 			r.Get("/", hdl.ListTasks)
 			r.Post("/", hdl.AddTask)
+			r.Get("/search", hdl.SearchTasks)
 			r.Route("/{Id}", func(r chi.Router) {
 				r.Use(hdl.IdMiddleware)
 				r.Get("/", hdl.GetTask)
 				r.Put("/", hdl.UpdateTask)
 				r.Delete("/", hdl.DeleteTask)
+				r.Post("/restore", hdl.RestoreTask)
+				r.Post("/move", hdl.MoveTask)
+				r.Route("/snooze", func(r chi.Router) {
+					r.Post("/", hdl.SnoozeTask)
+					r.Delete("/", hdl.UnsnoozeTask)
+				})
+				r.Get("/subtasks", hdl.ListSubtasks)
+				r.Get("/history", hdl.ListTaskRevisions)
+				r.Route("/revert/{SubId}", func(r chi.Router) {
+					r.Use(hdl.SubIdMiddleware)
+					r.Post("/", hdl.RevertTask)
+				})
+				r.Route("/comments", func(r chi.Router) {
+					r.Get("/", hdl.ListTaskComments)
+					r.Post("/", hdl.AddComment)
+					r.Route("/{SubId}", func(r chi.Router) {
+						r.Use(hdl.SubIdMiddleware)
+						r.Get("/", hdl.GetComment)
+						r.Put("/", hdl.UpdateComment)
+						r.Delete("/", hdl.DeleteComment)
+					})
+				})
+				r.Route("/attachments", func(r chi.Router) {
+					r.Get("/", hdl.ListTaskAttachments)
+					r.Post("/", hdl.AddAttachment)
+					r.Route("/{SubId}", func(r chi.Router) {
+						r.Use(hdl.SubIdMiddleware)
+						r.Get("/", hdl.DownloadAttachment)
+						r.Delete("/", hdl.DeleteAttachment)
+					})
+				})
+				r.Route("/dependencies", func(r chi.Router) {
+					r.Get("/", hdl.ListTaskDependencies)
+					r.Post("/", hdl.AddTaskDependency)
+					r.Delete("/", hdl.DeleteTaskDependency)
+				})
+				r.Route("/timer", func(r chi.Router) {
+					r.Post("/start", hdl.StartTimer)
+					r.Post("/stop", hdl.StopTimer)
+				})
+				r.Route("/checklist", func(r chi.Router) {
+					r.Post("/", hdl.AddChecklistItem)
+					r.Route("/{SubId}", func(r chi.Router) {
+						r.Use(hdl.SubIdMiddleware)
+						r.Post("/toggle", hdl.ToggleChecklistItem)
+						r.Post("/move", hdl.MoveChecklistItem)
+						r.Delete("/", hdl.DeleteChecklistItem)
+					})
+				})
 			})
 		})
 	})
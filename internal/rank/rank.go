@@ -0,0 +1,123 @@
+// Package rank generates lexicographic rank keys: strings that order a list when compared byte by
+// byte, so an item can be moved by giving it a key between the keys of its new neighbours, without
+// renumbering the other items.
+//
+// Keys are made of the digits and lower case letters and never end in "0", which guarantees that
+// there is always a key between two different keys. Repeated moves to the same place make keys
+// longer; once a key exceeds MaxLength the list should be given fresh keys with Spread.
+package rank
+
+import "strings"
+
+const (
+	digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+	// MaxLength is the length beyond which the keys of a list should be rebalanced.
+	MaxLength = 32
+)
+
+// Between returns a key sorting strictly between a and b, where an empty a stands for the start of
+// the list and an empty b for its end. ok is false when a does not sort before b, such as when
+// two items share the same key, in which case the list must be rebalanced first.
+func Between(a, b string) (key string, ok bool) {
+	if b != "" && a >= b {
+		return "", false
+	}
+
+	return midpoint(a, b), true
+}
+
+// After returns a short key sorting after a, for appending to the end of a list whose last key is a.
+// Unlike Between(a, ""), it takes the smallest step at the first digit it can increase, so that
+// appending many items keeps the keys short.
+func After(a string) string {
+	for i := 0; i < len(a); i++ {
+		if d := strings.IndexByte(digits, a[i]); d < len(digits)-1 {
+			return a[:i] + string(digits[d+1])
+		}
+	}
+
+	return midpoint(a, "")
+}
+
+// Spread returns n evenly spaced keys, in order, all of the same length before trailing zeros are
+// dropped, leaving room before the first and after the last one.
+func Spread(n int) []string {
+	// one more digit than needed to tell the keys apart, so each gap holds many keys
+	length, capacity := 1, len(digits)
+	for capacity <= n+1 {
+		length++
+		capacity *= len(digits)
+	}
+	length++
+	capacity *= len(digits)
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strings.TrimRight(encode((i+1)*(capacity/(n+1)), length), "0")
+	}
+
+	return keys
+}
+
+// encode writes a number with a fixed number of digits.
+func encode(value int, length int) string {
+	key := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		key[i] = digits[value%len(digits)]
+		value /= len(digits)
+	}
+
+	return string(key)
+}
+
+// midpoint returns a key between a and b, a sorting before b and neither ending in "0".
+// An empty b stands for the end of the list.
+func midpoint(a, b string) string {
+	if b != "" {
+		// the common prefix, reading the missing digits of a as zeros, is kept as is
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+
+	// the first digits are consecutive: the first digit of a longer b alone sorts between them,
+	// otherwise the key goes on after the first digit of a
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+	return string(digits[low]) + midpoint(rest, "")
+}
+
+// digitAt returns the digit of a key at a position, "0" past its end.
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+
+	return digits[0]
+}
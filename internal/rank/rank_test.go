@@ -0,0 +1,110 @@
+package rank
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBetween(t *testing.T) {
+	tests := map[string]struct {
+		a, b     string
+		expected string
+		ok       bool
+	}{
+		"empty list":          {expected: "i", ok: true},
+		"start":               {b: "i", expected: "9", ok: true},
+		"end":                 {a: "i", expected: "r", ok: true},
+		"middle":              {a: "a", b: "c", expected: "b", ok: true},
+		"consecutive":         {a: "a", b: "b", expected: "ai", ok: true},
+		"common prefix":       {a: "ab", b: "ad", expected: "ac", ok: true},
+		"shorter a":           {a: "a", b: "a2", expected: "a1", ok: true},
+		"leading zeros":       {b: "01", expected: "00i", ok: true},
+		"longer b":            {a: "a", b: "b5", expected: "b", ok: true},
+		"last digit at end":   {a: "z", expected: "zi", ok: true},
+		"same key":            {a: "a", b: "a"},
+		"keys in wrong order": {a: "b", b: "a"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, ok := Between(test.a, test.b)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, key)
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		expected string
+	}{
+		"empty list":   {expected: "i"},
+		"single digit": {a: "i", expected: "j"},
+		"truncates":    {a: "a5", expected: "b"},
+		"skips z":      {a: "z5", expected: "z6"},
+		"all z":        {a: "zz", expected: "zzi"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, After(test.a))
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 35, 36, 1000} {
+		keys := Spread(n)
+		assert.Len(t, keys, n)
+		assert.True(t, slices.IsSorted(keys))
+		assert.Len(t, slices.Compact(slices.Clone(keys)), n)
+		for _, key := range keys {
+			assert.False(t, strings.HasSuffix(key, "0"), key)
+		}
+		if n > 0 {
+			assert.NotEmpty(t, keys[0])
+			_, ok := Between(keys[n-1], "")
+			assert.True(t, ok)
+		}
+	}
+}
+
+// TestMoves moves items around at random and checks the keys keep the expected order.
+func TestMoves(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	keys := Spread(20)
+
+	for range 2000 {
+		from := random.Intn(len(keys))
+		key := keys[from]
+		keys = slices.Delete(keys, from, from+1)
+
+		to := random.Intn(len(keys) + 1)
+		before, after := "", ""
+		if to > 0 {
+			before = keys[to-1]
+		}
+		if to < len(keys) {
+			after = keys[to]
+		}
+
+		key, ok := Between(before, after)
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.False(t, strings.HasSuffix(key, "0"), key)
+		keys = slices.Insert(keys, to, key)
+		if !assert.True(t, slices.IsSorted(keys)) {
+			return
+		}
+
+		if len(key) > MaxLength {
+			keys = Spread(len(keys))
+		}
+	}
+}
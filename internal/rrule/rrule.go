@@ -0,0 +1,472 @@
+// Package rrule parses and evaluates recurrence rules as defined by RFC 5545 (section 3.3.10).
+//
+// The supported rule parts are FREQ (DAILY, WEEKLY, MONTHLY and YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS and WKST, which cover rules such as:
+//
+//	FREQ=DAILY                              every day
+//	FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR        every weekday
+//	FREQ=MONTHLY;BYDAY=-1FR                 the last Friday of the month
+//	FREQ=YEARLY;BYMONTH=11;BYDAY=4TH        the fourth Thursday of November
+//
+// Sub-daily frequencies and the BYSECOND, BYMINUTE, BYHOUR, BYYEARDAY and BYWEEKNO parts are rejected.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is returned when a rule cannot be parsed or uses unsupported parts.
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency string
+
+const (
+	Daily   = Frequency("DAILY")
+	Weekly  = Frequency("WEEKLY")
+	Monthly = Frequency("MONTHLY")
+	Yearly  = Frequency("YEARLY")
+)
+
+// WeekdayNum is a BYDAY entry: a weekday, optionally preceded by its ordinal within the month
+// or the year. N is 0 for every such weekday, positive counting from the start and negative
+// counting from the end.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// maxPeriods bounds the number of periods evaluated while looking for an occurrence, so rules
+// that can never match (e.g. the 30th of February) do not loop forever.
+const maxPeriods = 10000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE,FR". A leading "RRULE:" is accepted.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicated part %s", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		if err := rule.parsePart(name, strings.ToUpper(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *Rule) parsePart(name, value string) error {
+	var err error
+	switch name {
+	case "FREQ":
+		switch freq := Frequency(value); freq {
+		case Daily, Weekly, Monthly, Yearly:
+			r.Freq = freq
+		case "SECONDLY", "MINUTELY", "HOURLY":
+			return fmt.Errorf("%w: unsupported frequency %s", ErrInvalidRule, value)
+		default:
+			return fmt.Errorf("%w: unknown frequency %s", ErrInvalidRule, value)
+		}
+	case "INTERVAL":
+		r.Interval, err = parseInt(name, value, 1, 0)
+	case "COUNT":
+		r.Count, err = parseInt(name, value, 1, 0)
+	case "UNTIL":
+		var until time.Time
+		until, err = parseUntil(value)
+		r.Until = &until
+	case "BYDAY":
+		for _, item := range strings.Split(value, ",") {
+			var day WeekdayNum
+			if day, err = parseWeekdayNum(item); err != nil {
+				break
+			}
+			r.ByDay = append(r.ByDay, day)
+		}
+	case "BYMONTHDAY":
+		for _, item := range strings.Split(value, ",") {
+			var day int
+			if day, err = parseInt(name, item, -31, 31); err != nil {
+				break
+			}
+			if day == 0 {
+				return fmt.Errorf("%w: BYMONTHDAY cannot be 0", ErrInvalidRule)
+			}
+			r.ByMonthDay = append(r.ByMonthDay, day)
+		}
+	case "BYMONTH":
+		for _, item := range strings.Split(value, ",") {
+			var month int
+			if month, err = parseInt(name, item, 1, 12); err != nil {
+				break
+			}
+			r.ByMonth = append(r.ByMonth, time.Month(month))
+		}
+	case "BYSETPOS":
+		for _, item := range strings.Split(value, ",") {
+			var pos int
+			if pos, err = parseInt(name, item, -366, 366); err != nil {
+				break
+			}
+			if pos == 0 {
+				return fmt.Errorf("%w: BYSETPOS cannot be 0", ErrInvalidRule)
+			}
+			r.BySetPos = append(r.BySetPos, pos)
+		}
+	case "WKST":
+		weekday, ok := weekdays[value]
+		if !ok {
+			return fmt.Errorf("%w: unknown weekday %s", ErrInvalidRule, value)
+		}
+		r.WeekStart = weekday
+	case "BYSECOND", "BYMINUTE", "BYHOUR", "BYYEARDAY", "BYWEEKNO":
+		return fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, name)
+	default:
+		return fmt.Errorf("%w: unknown part %s", ErrInvalidRule, name)
+	}
+
+	return err
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL cannot be used together", ErrInvalidRule)
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY cannot be used with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	if r.Freq == Daily || r.Freq == Weekly {
+		for _, day := range r.ByDay {
+			if day.N != 0 {
+				return fmt.Errorf("%w: BYDAY ordinals require FREQ=MONTHLY or FREQ=YEARLY", ErrInvalidRule)
+			}
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("%w: BYSETPOS requires another BYxxx part", ErrInvalidRule)
+	}
+
+	return nil
+}
+
+func parseInt(name, value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || (max != 0 && n > max) {
+		return 0, fmt.Errorf("%w: invalid %s value %q", ErrInvalidRule, name, value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// a date-only UNTIL includes the whole day
+				until = until.Add(24*time.Hour - time.Nanosecond)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: invalid UNTIL value %q", ErrInvalidRule, value)
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("%w: invalid BYDAY value %q", ErrInvalidRule, value)
+	}
+
+	weekday, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("%w: invalid BYDAY value %q", ErrInvalidRule, value)
+	}
+
+	n := 0
+	if ordinal := value[:len(value)-2]; ordinal != "" {
+		var err error
+		if n, err = strconv.Atoi(ordinal); err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("%w: invalid BYDAY value %q", ErrInvalidRule, value)
+		}
+	}
+
+	return WeekdayNum{N: n, Weekday: weekday}, nil
+}
+
+// String returns the rule in its canonical RFC 5545 form, without the "RRULE:" prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayNames[day.Weekday]
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func joinInts[T ~int](values []T) string {
+	items := make([]string, len(values))
+	for i, value := range values {
+		items[i] = strconv.Itoa(int(value))
+	}
+	return strings.Join(items, ",")
+}
+
+// Next returns the first occurrence of the rule starting at dtstart that is strictly after the
+// given time. The second result is false when the rule has no such occurrence.
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(dtstart, func(occurrence time.Time) bool {
+		if occurrence.After(after) {
+			next, found = occurrence, true
+			return false
+		}
+		return true
+	})
+
+	return next, found
+}
+
+// All returns up to limit occurrences of the rule starting at dtstart.
+func (r *Rule) All(dtstart time.Time, limit int) []time.Time {
+	occurrences := make([]time.Time, 0, limit)
+	r.each(dtstart, func(occurrence time.Time) bool {
+		occurrences = append(occurrences, occurrence)
+		return len(occurrences) < limit
+	})
+
+	return occurrences
+}
+
+// each calls yield with every occurrence at or after dtstart, in chronological order, until
+// yield returns false or the rule is exhausted. Occurrences keep the time of day and the
+// location of dtstart.
+func (r *Rule) each(dtstart time.Time, yield func(time.Time) bool) {
+	count := 0
+	for period := 0; period < maxPeriods; period++ {
+		for _, day := range r.expand(dtstart, period*r.Interval) {
+			occurrence := time.Date(day.Year(), day.Month(), day.Day(),
+				dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+			if occurrence.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return
+			}
+
+			count++
+			if !yield(occurrence) || (r.Count > 0 && count >= r.Count) {
+				return
+			}
+		}
+	}
+}
+
+// expand returns the days matching the rule within the period that is offset periods of the
+// rule frequency away from the one containing dtstart.
+func (r *Rule) expand(dtstart time.Time, offset int) []time.Time {
+	start := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+
+	var first, end time.Time
+	switch r.Freq {
+	case Daily:
+		first = start.AddDate(0, 0, offset)
+		end = first.AddDate(0, 0, 1)
+	case Weekly:
+		shift := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		first = start.AddDate(0, 0, offset*7-shift)
+		end = first.AddDate(0, 0, 7)
+	case Monthly:
+		first = time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(0, 1, 0)
+	case Yearly:
+		first = time.Date(start.Year()+offset, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(1, 0, 0)
+	}
+
+	days := make([]time.Time, 0)
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if r.matches(day, dtstart) {
+			days = append(days, day)
+		}
+	}
+
+	return r.applySetPos(days)
+}
+
+// matches reports whether a day of the current period belongs to the rule.
+func (r *Rule) matches(day, dtstart time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, day.Month()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !matchesMonthDay(day, r.ByMonthDay) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !r.matchesWeekday(day) {
+		return false
+	}
+
+	// without a BYxxx part to expand it, the period produces the day matching dtstart
+	switch r.Freq {
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == dtstart.Weekday()
+		}
+	case Monthly:
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			return day.Day() == dtstart.Day()
+		}
+	case Yearly:
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			if len(r.ByMonth) == 0 && day.Month() != dtstart.Month() {
+				return false
+			}
+			return day.Day() == dtstart.Day()
+		}
+	}
+
+	return true
+}
+
+func matchesMonthDay(day time.Time, monthDays []int) bool {
+	last := daysIn(day.Year(), day.Month())
+	for _, monthDay := range monthDays {
+		if monthDay == day.Day() || (monthDay < 0 && last+1+monthDay == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday checks BYDAY, with ordinals counted within the month, or within the year for
+// yearly rules that do not restrict the months.
+func (r *Rule) matchesWeekday(day time.Time) bool {
+	index, length := day.Day()-1, daysIn(day.Year(), day.Month())
+	if r.Freq == Yearly && len(r.ByMonth) == 0 {
+		index, length = day.YearDay()-1, time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday != day.Weekday() {
+			continue
+		}
+
+		switch {
+		case weekday.N == 0:
+			return true
+		case weekday.N > 0 && index/7+1 == weekday.N:
+			return true
+		case weekday.N < 0 && (length-index-1)/7+1 == -weekday.N:
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos keeps the days at the BYSETPOS positions of the period, in chronological order.
+func (r *Rule) applySetPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+
+	selected := make([]time.Time, 0, len(r.BySetPos))
+	for i, day := range days {
+		for _, pos := range r.BySetPos {
+			if pos == i+1 || pos == i-len(days) {
+				selected = append(selected, day)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
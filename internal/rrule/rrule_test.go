@@ -0,0 +1,337 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	until := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		rule     string
+		expected *Rule
+	}{
+		"daily": {
+			rule:     "FREQ=DAILY",
+			expected: &Rule{Freq: Daily, Interval: 1, WeekStart: time.Monday},
+		},
+		"prefix and lower case": {
+			rule:     "RRULE:freq=weekly;interval=2;byday=mo,fr",
+			expected: &Rule{Freq: Weekly, Interval: 2, WeekStart: time.Monday, ByDay: []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Friday}}},
+		},
+		"ordinals": {
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR,+2MO;COUNT=3",
+			expected: &Rule{Freq: Monthly, Interval: 1, Count: 3, WeekStart: time.Monday, ByDay: []WeekdayNum{{N: -1, Weekday: time.Friday}, {N: 2, Weekday: time.Monday}}},
+		},
+		"until": {
+			rule:     "FREQ=YEARLY;BYMONTH=2,3;BYMONTHDAY=-1;UNTIL=20260301T000000Z;WKST=SU",
+			expected: &Rule{Freq: Yearly, Interval: 1, Until: &until, WeekStart: time.Sunday, ByMonth: []time.Month{time.February, time.March}, ByMonthDay: []int{-1}},
+		},
+		"set position": {
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			expected: &Rule{Freq: Monthly, Interval: 1, WeekStart: time.Monday, BySetPos: []int{-1}, ByDay: []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Tuesday}, {Weekday: time.Wednesday}, {Weekday: time.Thursday}, {Weekday: time.Friday}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rule)
+		})
+	}
+}
+
+func TestParseUntilDate(t *testing.T) {
+	rule, err := Parse("FREQ=DAILY;UNTIL=20260301")
+	require.NoError(t, err)
+
+	// a date-only UNTIL includes the whole day
+	assert.Equal(t, time.Date(2026, time.March, 1, 23, 59, 59, int(time.Second-time.Nanosecond), time.UTC), *rule.Until)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":                  "",
+		"missing frequency":      "INTERVAL=2",
+		"unknown frequency":      "FREQ=FORTNIGHTLY",
+		"unsupported frequency":  "FREQ=HOURLY",
+		"malformed part":         "FREQ=DAILY;INTERVAL",
+		"duplicated part":        "FREQ=DAILY;FREQ=WEEKLY",
+		"unknown part":           "FREQ=DAILY;FOO=1",
+		"unsupported part":       "FREQ=YEARLY;BYWEEKNO=20",
+		"zero interval":          "FREQ=DAILY;INTERVAL=0",
+		"invalid count":          "FREQ=DAILY;COUNT=many",
+		"count and until":        "FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"invalid until":          "FREQ=DAILY;UNTIL=2026-01-01",
+		"invalid weekday":        "FREQ=WEEKLY;BYDAY=XX",
+		"invalid ordinal":        "FREQ=MONTHLY;BYDAY=0FR",
+		"ordinal out of range":   "FREQ=YEARLY;BYDAY=54MO",
+		"ordinal on weekly":      "FREQ=WEEKLY;BYDAY=1MO",
+		"month day zero":         "FREQ=MONTHLY;BYMONTHDAY=0",
+		"month day out of range": "FREQ=MONTHLY;BYMONTHDAY=32",
+		"month day on weekly":    "FREQ=WEEKLY;BYMONTHDAY=1",
+		"invalid month":          "FREQ=YEARLY;BYMONTH=13",
+		"set position zero":      "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=0",
+		"set position alone":     "FREQ=MONTHLY;BYSETPOS=1",
+		"invalid week start":     "FREQ=WEEKLY;WKST=XX",
+	}
+
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(rule)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"daily":     {rule: "FREQ=DAILY", expected: "FREQ=DAILY"},
+		"weekdays":  {rule: "byday=MO,TU,WE,TH,FR;freq=weekly", expected: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		"ordinals":  {rule: "FREQ=MONTHLY;COUNT=5;BYDAY=-1FR;INTERVAL=2", expected: "FREQ=MONTHLY;INTERVAL=2;COUNT=5;BYDAY=-1FR"},
+		"until":     {rule: "FREQ=YEARLY;UNTIL=20300101T100000Z;BYMONTH=6;BYMONTHDAY=15,-1", expected: "FREQ=YEARLY;UNTIL=20300101T100000Z;BYMONTH=6;BYMONTHDAY=15,-1"},
+		"positions": {rule: "FREQ=MONTHLY;BYDAY=SA,SU;BYSETPOS=1,-1;WKST=SU", expected: "FREQ=MONTHLY;BYDAY=SA,SU;BYSETPOS=1,-1;WKST=SU"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rule.String())
+
+			// the canonical form parses back to the same rule
+			reparsed, err := Parse(rule.String())
+			require.NoError(t, err)
+			assert.Equal(t, rule, reparsed)
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	tests := map[string]struct {
+		rule     string
+		dtstart  time.Time
+		limit    int
+		expected []time.Time
+	}{
+		"daily": {
+			rule:     "FREQ=DAILY",
+			dtstart:  date(2026, time.January, 30),
+			limit:    4,
+			expected: []time.Time{date(2026, time.January, 30), date(2026, time.January, 31), date(2026, time.February, 1), date(2026, time.February, 2)},
+		},
+		"every other day": {
+			rule:     "FREQ=DAILY;INTERVAL=2",
+			dtstart:  date(2026, time.February, 27),
+			limit:    3,
+			expected: []time.Time{date(2026, time.February, 27), date(2026, time.March, 1), date(2026, time.March, 3)},
+		},
+		"daily on weekdays": {
+			rule:     "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart:  date(2026, time.January, 9), // Friday
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 9), date(2026, time.January, 12), date(2026, time.January, 13)},
+		},
+		"every weekday": {
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart:  date(2026, time.January, 8), // Thursday
+			limit:    6,
+			expected: []time.Time{date(2026, time.January, 8), date(2026, time.January, 9), date(2026, time.January, 12), date(2026, time.January, 13), date(2026, time.January, 14), date(2026, time.January, 15)},
+		},
+		"weekly on the dtstart weekday": {
+			rule:     "FREQ=WEEKLY",
+			dtstart:  date(2026, time.January, 7),
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 7), date(2026, time.January, 14), date(2026, time.January, 21)},
+		},
+		"every other week": {
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			dtstart:  date(2026, time.January, 6), // Tuesday
+			limit:    4,
+			expected: []time.Time{date(2026, time.January, 6), date(2026, time.January, 8), date(2026, time.January, 20), date(2026, time.January, 22)},
+		},
+		"week start": {
+			// with weeks starting on Sunday the Sunday after dtstart belongs to the skipped week
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU;WKST=SU",
+			dtstart:  date(2026, time.January, 6), // Tuesday
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 6), date(2026, time.January, 18), date(2026, time.January, 20)},
+		},
+		"monthly on the dtstart day": {
+			rule:     "FREQ=MONTHLY",
+			dtstart:  date(2026, time.January, 31),
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 31), date(2026, time.March, 31), date(2026, time.May, 31)},
+		},
+		"last friday of the month": {
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:  date(2026, time.January, 1),
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 30), date(2026, time.February, 27), date(2026, time.March, 27)},
+		},
+		"second monday of the month": {
+			rule:     "FREQ=MONTHLY;BYDAY=2MO",
+			dtstart:  date(2026, time.January, 1),
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 12), date(2026, time.February, 9), date(2026, time.March, 9)},
+		},
+		"last day of the month": {
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart:  date(2028, time.January, 15),
+			limit:    3,
+			expected: []time.Time{date(2028, time.January, 31), date(2028, time.February, 29), date(2028, time.March, 31)},
+		},
+		"friday the 13th": {
+			rule:     "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			dtstart:  date(2026, time.January, 1),
+			limit:    3,
+			expected: []time.Time{date(2026, time.February, 13), date(2026, time.March, 13), date(2026, time.November, 13)},
+		},
+		"last workday of the month": {
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart:  date(2026, time.January, 1),
+			limit:    3,
+			expected: []time.Time{date(2026, time.January, 30), date(2026, time.February, 27), date(2026, time.March, 31)},
+		},
+		"quarterly": {
+			rule:     "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1",
+			dtstart:  date(2026, time.February, 1),
+			limit:    3,
+			expected: []time.Time{date(2026, time.February, 1), date(2026, time.May, 1), date(2026, time.August, 1)},
+		},
+		"yearly on the dtstart date": {
+			rule:     "FREQ=YEARLY",
+			dtstart:  date(2028, time.February, 29),
+			limit:    2,
+			expected: []time.Time{date(2028, time.February, 29), date(2032, time.February, 29)},
+		},
+		"thanksgiving": {
+			rule:     "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart:  date(2026, time.January, 1),
+			limit:    3,
+			expected: []time.Time{date(2026, time.November, 26), date(2027, time.November, 25), date(2028, time.November, 23)},
+		},
+		"yearly in several months": {
+			rule:     "FREQ=YEARLY;BYMONTH=3,9",
+			dtstart:  date(2026, time.January, 15),
+			limit:    3,
+			expected: []time.Time{date(2026, time.March, 15), date(2026, time.September, 15), date(2027, time.March, 15)},
+		},
+		"twentieth monday of the year": {
+			rule:     "FREQ=YEARLY;BYDAY=20MO",
+			dtstart:  date(2026, time.January, 1),
+			limit:    2,
+			expected: []time.Time{date(2026, time.May, 18), date(2027, time.May, 17)},
+		},
+		"last day of the year": {
+			rule:     "FREQ=YEARLY;BYDAY=-1TH",
+			dtstart:  date(2026, time.January, 1),
+			limit:    1,
+			expected: []time.Time{date(2026, time.December, 31)},
+		},
+		"count": {
+			rule:     "FREQ=DAILY;COUNT=2",
+			dtstart:  date(2026, time.January, 1),
+			limit:    5,
+			expected: []time.Time{date(2026, time.January, 1), date(2026, time.January, 2)},
+		},
+		"until": {
+			rule:     "FREQ=WEEKLY;UNTIL=20260115",
+			dtstart:  date(2026, time.January, 1),
+			limit:    5,
+			expected: []time.Time{date(2026, time.January, 1), date(2026, time.January, 8), date(2026, time.January, 15)},
+		},
+		"never matches": {
+			rule:     "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart:  date(2026, time.January, 1),
+			limit:    1,
+			expected: []time.Time{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rule.All(test.dtstart, test.limit))
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := map[string]struct {
+		rule     string
+		dtstart  time.Time
+		after    time.Time
+		expected time.Time
+		found    bool
+	}{
+		"after dtstart": {
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart:  date(2026, time.January, 9),
+			after:    date(2026, time.January, 9),
+			expected: date(2026, time.January, 12),
+			found:    true,
+		},
+		"later in the day": {
+			rule:     "FREQ=DAILY",
+			dtstart:  date(2026, time.January, 1),
+			after:    time.Date(2026, time.January, 5, 12, 0, 0, 0, time.UTC),
+			expected: date(2026, time.January, 6),
+			found:    true,
+		},
+		"dtstart not matching the rule": {
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:  date(2026, time.January, 7),
+			after:    date(2026, time.January, 7),
+			expected: date(2026, time.January, 30),
+			found:    true,
+		},
+		"count exhausted": {
+			rule:    "FREQ=DAILY;COUNT=1",
+			dtstart: date(2026, time.January, 1),
+			after:   date(2026, time.January, 1),
+		},
+		"until passed": {
+			rule:    "FREQ=MONTHLY;UNTIL=20260201T000000Z",
+			dtstart: date(2026, time.January, 10),
+			after:   date(2026, time.January, 10),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			require.NoError(t, err)
+
+			next, found := rule.Next(test.dtstart, test.after)
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.expected, next)
+		})
+	}
+}
+
+func TestNextKeepsLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	rule, err := Parse("FREQ=DAILY")
+	require.NoError(t, err)
+
+	dtstart := time.Date(2026, time.January, 1, 8, 0, 0, 0, location)
+	next, found := rule.Next(dtstart, dtstart)
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2026, time.January, 2, 8, 0, 0, 0, location), next)
+}
@@ -0,0 +1,98 @@
+// Package scheduler runs background jobs at a fixed interval for the lifetime of the process.
+// It is the caller's responsibility to call Stop() before shutting down the application so
+// running jobs are given the chance to finish.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrInvalidInterval is returned when a job is added with an interval that is not positive.
+var ErrInvalidInterval = errors.New("invalid job interval")
+
+// Job is a unit of background work run every Interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context, now time.Time) error
+}
+
+// Config is a struct containing configuration options for a scheduler.
+type Config struct {
+	Logger *zap.Logger
+}
+
+// Scheduler is a struct representing a background job scheduler.
+type Scheduler struct {
+	logger *zap.Logger
+	jobs   []Job
+	wg     *sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// New creates and returns a new scheduler instance.
+func New(cfg Config) *Scheduler {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Scheduler{
+		logger: logger,
+		wg:     &sync.WaitGroup{},
+	}
+}
+
+// Add registers a job. Jobs must be added before Start is called, and must run at a positive
+// interval.
+func (s *Scheduler) Add(job Job) error {
+	if job.Interval <= 0 {
+		return fmt.Errorf("%w: %s for job %q", ErrInvalidInterval, job.Interval, job.Name)
+	}
+
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Start runs every registered job on its own goroutine until ctx is done or Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.run(ctx, job)
+	}
+	s.logger.Info("scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+// Stop cancels all jobs and waits for the running ones to return.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := job.Run(ctx, now); err != nil {
+				s.logger.Error("Error running scheduled job.", zap.String("job", job.Name), zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs int32
+	sched := New(Config{Logger: zaptest.NewLogger(t)})
+	err := sched.Add(Job{
+		Name:     "count",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context, now time.Time) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	sched.Start(context.Background())
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&runs) >= 3
+	}, time.Second, time.Millisecond)
+	sched.Stop()
+
+	// no more runs after stop
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+}
+
+func TestSchedulerJobError(t *testing.T) {
+	core, observed := observer.New(zap.ErrorLevel)
+	logger := zaptest.NewLogger(t, zaptest.WrapOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return core
+	})))
+
+	sched := New(Config{Logger: logger})
+	err := sched.Add(Job{
+		Name:     "failing",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context, now time.Time) error {
+			return errors.New("any error")
+		},
+	})
+	require.NoError(t, err)
+
+	sched.Start(context.Background())
+	assert.Eventually(t, func() bool {
+		return observed.Len() > 0
+	}, time.Second, time.Millisecond)
+	sched.Stop()
+
+	entry := observed.All()[0]
+	assert.Equal(t, "Error running scheduled job.", entry.Message)
+	assert.Equal(t, "failing", entry.ContextMap()["job"])
+}
+
+func TestSchedulerInvalidInterval(t *testing.T) {
+	sched := New(Config{Logger: zaptest.NewLogger(t)})
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := sched.Add(Job{
+			Name:     "never",
+			Interval: interval,
+			Run: func(ctx context.Context, now time.Time) error {
+				return nil
+			},
+		})
+		assert.ErrorIs(t, err, ErrInvalidInterval)
+	}
+
+	// no job was added, so starting does not panic
+	sched.Start(context.Background())
+	sched.Stop()
+	assert.Empty(t, sched.jobs)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/blob"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxAttachmentSize is the attachment size limit used when none is configured.
+	DefaultMaxAttachmentSize = 10 << 20
+	// DefaultAttachmentURLExpiry is how long presigned download URLs stay valid when not configured.
+	DefaultAttachmentURLExpiry = 15 * time.Minute
+
+	// sniffLen is the number of leading bytes http.DetectContentType looks at.
+	sniffLen = 512
+)
+
+// attachmentKey is where the content of an attachment is kept in the blob store.
+func attachmentKey(attachment *common.Attachment) string {
+	return "tasks/" + attachment.TaskId + "/" + attachment.Id
+}
+
+// AddAttachment stores the content of a file uploaded to a task along with its metadata. The content
+// type is sniffed from the content rather than trusted from the client, and its SHA-256 is recorded.
+func (svc *Service) AddAttachment(attachment *common.Attachment, content io.Reader) (*common.Attachment, error) {
+	attachment.Id = uuid.New().String()
+	attachment.Name = strings.TrimSpace(attachment.Name)
+	if attachment.Name == "" {
+		err := fmt.Errorf("%w: file name is required", ErrInvalidAttachment)
+		svc.logger.Error("Unable add attachment.", zap.Error(err))
+		return nil, err
+	}
+
+	task, err := svc.db.GetTask(attachment.TaskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		svc.logger.Error("Unable to read attachment.", zap.Error(err))
+		return nil, err
+	}
+	attachment.ContentType = http.DetectContentType(head[:n])
+
+	hash := sha256.New()
+	body := &sizeLimitedReader{
+		r:   io.TeeReader(io.MultiReader(bytes.NewReader(head[:n]), content), hash),
+		max: svc.attachments.MaxSize,
+	}
+
+	key := attachmentKey(attachment)
+	if err := svc.attachments.Store.Put(key, body, attachment.ContentType); err != nil || body.exceeded {
+		svc.deleteBlob(key)
+		if body.exceeded {
+			err = fmt.Errorf("%w: the limit is %d bytes", ErrAttachmentTooLarge, svc.attachments.MaxSize)
+		}
+		svc.logger.Error("Unable to store attachment.", zap.Error(err))
+		return nil, err
+	}
+
+	attachment.Size = body.n
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+	attachment.CreatedAt = time.Now()
+
+	if err := svc.db.AddAttachment(attachment); err != nil {
+		svc.logger.Error("Unable add attachment.", zap.Error(err))
+		svc.deleteBlob(key)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachment returns an attachment of a task, reporting attachments of other tasks as not found.
+func (svc *Service) GetAttachment(taskId string, id string) (*common.Attachment, error) {
+	attachment, err := svc.db.GetAttachment(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve attachment.", zap.Error(err))
+		return nil, err
+	}
+	if attachment.Id == "" || attachment.TaskId != taskId {
+		return nil, fmt.Errorf("attachment %w", ErrNotFound)
+	}
+
+	return attachment, nil
+}
+
+// DownloadAttachment gives access to the content of an attachment, through a presigned URL when
+// the blob store supports them or by opening the content otherwise.
+func (svc *Service) DownloadAttachment(taskId string, id string) (*common.AttachmentDownload, error) {
+	attachment, err := svc.GetAttachment(taskId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	download := &common.AttachmentDownload{Attachment: attachment}
+	key := attachmentKey(attachment)
+
+	if presigner, ok := svc.attachments.Store.(blob.Presigner); ok {
+		download.URL, err = presigner.PresignGet(key, svc.attachments.URLExpiry)
+		if err != nil {
+			svc.logger.Error("Unable to presign attachment URL.", zap.Error(err))
+			return nil, err
+		}
+		return download, nil
+	}
+
+	download.Content, err = svc.attachments.Store.Get(key)
+	if errors.Is(err, blob.ErrNotFound) {
+		svc.logger.Error("Attachment content is missing.", zap.String("key", key))
+		return nil, fmt.Errorf("attachment content %w", ErrNotFound)
+	}
+	if err != nil {
+		svc.logger.Error("Unable to open attachment.", zap.Error(err))
+		return nil, err
+	}
+
+	return download, nil
+}
+
+// DeleteAttachment deletes an attachment and its content.
+func (svc *Service) DeleteAttachment(taskId string, id string) error {
+	attachment, err := svc.GetAttachment(taskId, id)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.db.DeleteAttachment(id); err != nil {
+		svc.logger.Error("Unable to delete attachment.", zap.Error(err))
+		return err
+	}
+
+	// the metadata is gone, so leftover content is only logged
+	svc.deleteBlob(attachmentKey(attachment))
+
+	return nil
+}
+
+func (svc *Service) ListTaskAttachments(taskId string) ([]common.Attachment, error) {
+	attachments, err := svc.db.ListTaskAttachments(taskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task attachments.", zap.Error(err))
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (svc *Service) deleteBlob(key string) {
+	if err := svc.attachments.Store.Delete(key); err != nil {
+		svc.logger.Warn("Unable to delete attachment content.", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// sizeLimitedReader counts the bytes read through it and stops with an error once more than max were read.
+type sizeLimitedReader struct {
+	r        io.Reader
+	max      int64
+	n        int64
+	exceeded bool
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.max {
+		l.exceeded = true
+		return n, ErrAttachmentTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/blob"
+	mock_blob "github.com/aborgesrodrigues/to-do-api/internal/blob/mock"
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestAddAttachment() {
+	errStore := errors.New("error storing content")
+	errAddAttachment := errors.New("error inserting attachment")
+	task := &common.Task{Id: "00001", UserId: "000001", Description: "description", State: "to_do"}
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 32)
+
+	tests := map[string]struct {
+		name             string
+		content          string
+		maxSize          int64
+		task             *common.Task
+		storeError       error
+		dbError          error
+		expectedType     string
+		expectedChecksum string
+		expectedErr      error
+	}{
+		"success": {
+			name:             "notes.txt",
+			content:          "buy milk",
+			task:             task,
+			expectedType:     "text/plain; charset=utf-8",
+			expectedChecksum: "933260194ce59178528d37861b7a69a5a7c221c81e8d7035474fd56acf895525",
+		},
+		"sniffed content type": {
+			name:         "photo.txt",
+			content:      png,
+			task:         task,
+			expectedType: "image/png",
+		},
+		"larger than the sniffed bytes": {
+			name:         "notes.txt",
+			content:      strings.Repeat("a", 2*sniffLen),
+			task:         task,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		"empty name": {
+			name:        " ",
+			content:     "buy milk",
+			expectedErr: ErrInvalidAttachment,
+		},
+		"task not found": {
+			name:        "notes.txt",
+			content:     "buy milk",
+			task:        &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"too large": {
+			name:        "notes.txt",
+			content:     "buy milk",
+			maxSize:     4,
+			task:        task,
+			expectedErr: ErrAttachmentTooLarge,
+		},
+		"store fail": {
+			name:        "notes.txt",
+			content:     "buy milk",
+			task:        task,
+			storeError:  errStore,
+			expectedErr: errStore,
+		},
+		"fail": {
+			name:        "notes.txt",
+			content:     "buy milk",
+			task:        task,
+			dbError:     errAddAttachment,
+			expectedErr: errAddAttachment,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			s.svc.attachments.MaxSize = DefaultMaxAttachmentSize
+			if test.maxSize > 0 {
+				s.svc.attachments.MaxSize = test.maxSize
+			}
+			attachment := &common.Attachment{TaskId: task.Id, Name: test.name}
+			stored := ""
+
+			// set up dao and store mocks
+			if test.task != nil {
+				s.getDB().
+					GetTask(task.Id).
+					Return(test.task, nil)
+			}
+			if test.task != nil && test.task.Id != "" {
+				s.getBlobs().
+					Put(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(key string, r io.Reader, contentType string) error {
+						s.Assert().Equal("tasks/00001/"+attachment.Id, key)
+						content, err := io.ReadAll(r)
+						stored = string(content)
+						if err != nil {
+							return err
+						}
+						return test.storeError
+					})
+			}
+			if test.storeError != nil || test.dbError != nil || test.expectedErr == ErrAttachmentTooLarge {
+				s.getBlobs().
+					Delete(gomock.Any()).
+					Return(nil)
+			}
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					AddAttachment(attachment).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.AddAttachment(attachment, strings.NewReader(test.content))
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().NotEmpty(resp.Id)
+				s.Assert().Equal(test.content, stored)
+				s.Assert().Equal(int64(len(test.content)), resp.Size)
+				s.Assert().Equal(test.expectedType, resp.ContentType)
+				s.Assert().Len(resp.Checksum, 64)
+				if test.expectedChecksum != "" {
+					s.Assert().Equal(test.expectedChecksum, resp.Checksum)
+				}
+				s.Assert().False(resp.CreatedAt.IsZero())
+			}
+		})
+	}
+}
+
+// presigningStore is a blob store handing out presigned URLs.
+type presigningStore struct {
+	*mock_blob.MockStore
+	*mock_blob.MockPresigner
+}
+
+func (s *svcTestSuite) TestDownloadAttachment() {
+	attachment := &common.Attachment{Id: "0001", TaskId: "00001", Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 8}
+
+	tests := map[string]struct {
+		attachment  *common.Attachment
+		presign     bool
+		storeError  error
+		expectedURL string
+		expectedErr error
+	}{
+		"content": {
+			attachment: attachment,
+		},
+		"presigned url": {
+			attachment:  attachment,
+			presign:     true,
+			expectedURL: "https://bucket.s3.amazonaws.com/tasks/00001/0001?X-Amz-Signature=abc",
+		},
+		"another task": {
+			attachment:  &common.Attachment{Id: "0001", TaskId: "00002"},
+			expectedErr: ErrNotFound,
+		},
+		"missing content": {
+			attachment:  attachment,
+			storeError:  blob.ErrNotFound,
+			expectedErr: ErrNotFound,
+		},
+	}
+
+	store := s.svc.attachments.Store
+	for index, test := range tests {
+		s.Run(index, func() {
+			s.svc.attachments.Store = store
+
+			// set up dao and store mocks
+			s.getDB().
+				GetAttachment(attachment.Id).
+				Return(test.attachment, nil)
+			if test.presign {
+				presigner := mock_blob.NewMockPresigner(s.ctrl)
+				presigner.EXPECT().
+					PresignGet("tasks/00001/0001", DefaultAttachmentURLExpiry).
+					Return(test.expectedURL, nil)
+				s.svc.attachments.Store = presigningStore{
+					MockStore:     store.(*mock_blob.MockStore),
+					MockPresigner: presigner,
+				}
+			} else if test.expectedErr == nil || test.storeError != nil {
+				var content io.ReadCloser
+				if test.storeError == nil {
+					content = io.NopCloser(strings.NewReader("buy milk"))
+				}
+				s.getBlobs().
+					Get("tasks/00001/0001").
+					Return(content, test.storeError)
+			}
+
+			resp, err := s.svc.DownloadAttachment(attachment.TaskId, attachment.Id)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(attachment, resp.Attachment)
+				s.Assert().Equal(test.expectedURL, resp.URL)
+				if !test.presign {
+					content, err := io.ReadAll(resp.Content)
+					s.Assert().NoError(err)
+					s.Assert().Equal("buy milk", string(content))
+				}
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestDeleteAttachment() {
+	errDeleteAttachment := errors.New("error deleting attachment")
+	attachment := &common.Attachment{Id: "0001", TaskId: "00001", Name: "notes.txt", CreatedAt: time.Now()}
+
+	tests := map[string]struct {
+		attachment  *common.Attachment
+		storeError  error
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			attachment: attachment,
+		},
+		"content already gone": {
+			attachment: attachment,
+			storeError: errors.New("error deleting content"),
+		},
+		"not found": {
+			attachment:  &common.Attachment{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			attachment:  attachment,
+			dbError:     errDeleteAttachment,
+			expectedErr: errDeleteAttachment,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao and store mocks
+			s.getDB().
+				GetAttachment(attachment.Id).
+				Return(test.attachment, nil)
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					DeleteAttachment(attachment.Id).
+					Return(test.dbError)
+			}
+			if test.expectedErr == nil {
+				s.getBlobs().
+					Delete("tasks/00001/0001").
+					Return(test.storeError)
+			}
+
+			err := s.svc.DeleteAttachment(attachment.TaskId, attachment.Id)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"go.uber.org/zap"
+)
+
+// MaxBatchOperations caps the number of operations of a task batch.
+const MaxBatchOperations = 100
+
+// errBatchFailed rolls back an atomic batch once one of its operations failed.
+var errBatchFailed = errors.New("batch operation failed")
+
+// BatchTasks runs the operations of a batch in a single transaction and returns the result of
+// each one, in order. An atomic batch is rolled back as a whole when an operation fails, the
+// other operations then resulting in ErrBatchAborted. A best-effort batch keeps the operations
+// that succeed. Updates are recorded as made by actorId.
+func (svc *Service) BatchTasks(batch common.TaskBatch, actorId string) ([]common.BatchResult, error) {
+	if err := validateBatch(batch); err != nil {
+		svc.logger.Error("Unable to run task batch.", zap.Error(err))
+		return nil, err
+	}
+
+	results := make([]common.BatchResult, len(batch.Operations))
+	err := svc.db.InTx(func(tx db.DBInterface) error {
+		for i, operation := range batch.Operations {
+			if batch.Mode == common.BatchModeBestEffort {
+				// a savepoint per operation, so a failing one leaves the others in place
+				err := tx.InTx(func(op db.DBInterface) error {
+					results[i] = svc.withDB(op).runBatchOperation(operation, actorId)
+					return results[i].Err
+				})
+				if err != nil && results[i].Err == nil {
+					return err
+				}
+				continue
+			}
+
+			results[i] = svc.withDB(tx).runBatchOperation(operation, actorId)
+			if results[i].Err != nil {
+				return errBatchFailed
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, errBatchFailed) {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i] = common.BatchResult{Err: ErrBatchAborted}
+			}
+		}
+		return results, nil
+	}
+	if err != nil {
+		svc.logger.Error("Unable to run task batch.", zap.Error(err))
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// validateBatch checks the mode and the shape of the operations of a batch before any is run.
+func validateBatch(batch common.TaskBatch) error {
+	switch batch.Mode {
+	case "", common.BatchModeAtomic, common.BatchModeBestEffort:
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidBatch, batch.Mode)
+	}
+
+	if len(batch.Operations) == 0 {
+		return fmt.Errorf("%w: no operations", ErrInvalidBatch)
+	}
+	if len(batch.Operations) > MaxBatchOperations {
+		return fmt.Errorf("%w: more than %d operations", ErrInvalidBatch, MaxBatchOperations)
+	}
+
+	for i, operation := range batch.Operations {
+		switch operation.Op {
+		case common.BatchOpCreate:
+			if operation.Task == nil {
+				return fmt.Errorf("%w: operation %d has no task", ErrInvalidBatch, i)
+			}
+		case common.BatchOpUpdate:
+			if operation.Id == "" || operation.Task == nil {
+				return fmt.Errorf("%w: operation %d needs an id and a task", ErrInvalidBatch, i)
+			}
+		case common.BatchOpDelete:
+			if operation.Id == "" {
+				return fmt.Errorf("%w: operation %d has no id", ErrInvalidBatch, i)
+			}
+		default:
+			return fmt.Errorf("%w: operation %d has an unknown op %q", ErrInvalidBatch, i, operation.Op)
+		}
+	}
+
+	return nil
+}
+
+// runBatchOperation runs an operation of a batch through the same method as its single request.
+func (svc *Service) runBatchOperation(operation common.BatchOperation, actorId string) common.BatchResult {
+	var (
+		task *common.Task
+		err  error
+	)
+	switch operation.Op {
+	case common.BatchOpCreate:
+		request := *operation.Task
+		task, err = svc.addTask(&request)
+	case common.BatchOpUpdate:
+		request := *operation.Task
+		request.Id = operation.Id
+		// the batch transaction already holds the update together
+		err = retryUpdate(request.Version, func() error {
+			attempt := request
+			task, err = svc.updateTask(&attempt, actorId, operation.Force)
+			return err
+		})
+	case common.BatchOpDelete:
+		err = svc.DeleteTask(operation.Id, operation.Cascade)
+	}
+
+	return common.BatchResult{Task: task, Err: err}
+}
+
+// withDB returns a copy of the service running its statements on the given database,
+// such as a transaction.
+func (svc *Service) withDB(conn db.DBInterface) *Service {
+	scoped := *svc
+	scoped.db = conn
+	return &scoped
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestBatchTasks() {
+	errCommit := errors.New("error committing")
+	operations := []common.BatchOperation{
+		{Op: common.BatchOpDelete, Id: "0001"},
+		{Op: common.BatchOpUpdate, Id: "0002", Task: &common.Task{UserId: "00001", State: "done"}},
+		{Op: common.BatchOpDelete, Id: "0003", Cascade: true},
+	}
+
+	tests := map[string]struct {
+		batch        common.TaskBatch
+		txError      error
+		expectedErrs []error
+		expectedErr  error
+	}{
+		"atomic": {
+			batch:        common.TaskBatch{Operations: operations},
+			expectedErrs: []error{ErrBatchAborted, ErrNotFound, ErrBatchAborted},
+		},
+		"best effort": {
+			batch:        common.TaskBatch{Mode: common.BatchModeBestEffort, Operations: operations},
+			expectedErrs: []error{nil, ErrNotFound, nil},
+		},
+		"no operations": {
+			batch:       common.TaskBatch{},
+			expectedErr: ErrInvalidBatch,
+		},
+		"unknown mode": {
+			batch:       common.TaskBatch{Mode: "some", Operations: operations},
+			expectedErr: ErrInvalidBatch,
+		},
+		"unknown op": {
+			batch:       common.TaskBatch{Operations: []common.BatchOperation{{Op: "move", Id: "0001"}}},
+			expectedErr: ErrInvalidBatch,
+		},
+		"update without task": {
+			batch:       common.TaskBatch{Operations: []common.BatchOperation{{Op: common.BatchOpUpdate, Id: "0001"}}},
+			expectedErr: ErrInvalidBatch,
+		},
+		"too many operations": {
+			batch:       common.TaskBatch{Operations: make([]common.BatchOperation, MaxBatchOperations+1)},
+			expectedErr: ErrInvalidBatch,
+		},
+		"fail": {
+			batch:       common.TaskBatch{Mode: common.BatchModeBestEffort, Operations: operations},
+			txError:     errCommit,
+			expectedErr: errCommit,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.expectedErrs != nil || test.txError != nil {
+				// the transaction, and the savepoint of each operation of a best-effort batch,
+				// run on the same mock
+				s.getDB().
+					InTx(gomock.Any()).
+					DoAndReturn(func(fn func(tx db.DBInterface) error) error {
+						if err := fn(s.svc.db); err != nil {
+							return err
+						}
+						return test.txError
+					})
+				if test.batch.Mode == common.BatchModeBestEffort {
+					s.getDB().
+						InTx(gomock.Any()).
+						DoAndReturn(func(fn func(tx db.DBInterface) error) error {
+							return fn(s.svc.db)
+						}).
+						Times(len(test.batch.Operations))
+				}
+				s.getDB().
+					DeleteTask("0001", gomock.Any()).
+					Return(nil)
+				s.getDB().
+					GetTask("0002").
+					Return(&common.Task{}, nil)
+				if test.batch.Mode == common.BatchModeBestEffort {
+					s.getDB().
+						DeleteTaskTree("0003", gomock.Any()).
+						Return(nil)
+				}
+			}
+
+			resp, err := s.svc.BatchTasks(test.batch, "00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				s.Assert().Nil(resp)
+				return
+			}
+
+			s.Assert().Len(resp, len(test.expectedErrs))
+			for i, expectedErr := range test.expectedErrs {
+				s.Assert().ErrorIs(resp[i].Err, expectedErr)
+			}
+		})
+	}
+}
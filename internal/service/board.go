@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// Board returns the tasks of a user matching the filter as a kanban board, with a column per state.
+func (svc *Service) Board(userId string, filter common.TaskFilter) (*common.Board, error) {
+	user, err := svc.db.GetUser(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	result, err := svc.ListUserTasks(userId, filter, common.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := svc.db.ListWIPLimits(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve wip limits.", zap.Error(err))
+		return nil, err
+	}
+
+	board := &common.Board{UserId: userId, Columns: make([]common.BoardColumn, len(common.TaskStates))}
+	columns := make(map[common.TaskState]*common.BoardColumn, len(common.TaskStates))
+	for i, state := range common.TaskStates {
+		board.Columns[i] = common.BoardColumn{State: state, Tasks: make([]common.Task, 0)}
+		if limit, ok := limits[state]; ok {
+			board.Columns[i].Limit = &limit
+		}
+		columns[state] = &board.Columns[i]
+	}
+
+	// the tasks come in their custom order, which each column keeps
+	for _, task := range result.Items {
+		if column, ok := columns[task.State]; ok {
+			column.Tasks = append(column.Tasks, task)
+			column.Count++
+		}
+	}
+
+	return board, nil
+}
+
+// SetWIPLimits replaces the work-in-progress limits of a user. Each limit must be positive.
+func (svc *Service) SetWIPLimits(userId string, limits common.WIPLimits) (common.WIPLimits, error) {
+	for state, limit := range limits {
+		if !state.Valid() {
+			return nil, fmt.Errorf("%w: unknown state %q", ErrInvalidWIPLimit, state)
+		}
+		if limit <= 0 {
+			return nil, fmt.Errorf("%w: the limit of %s must be positive", ErrInvalidWIPLimit, state)
+		}
+	}
+
+	user, err := svc.db.GetUser(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	if limits == nil {
+		limits = common.WIPLimits{}
+	}
+	if err := svc.db.SetWIPLimits(userId, limits); err != nil {
+		svc.logger.Error("Unable to set wip limits.", zap.Error(err))
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+// validateWIPLimit checks that the column of a state still has room for another task of a user.
+func (svc *Service) validateWIPLimit(userId string, state common.TaskState) error {
+	limits, err := svc.db.ListWIPLimits(userId)
+	if err != nil {
+		return err
+	}
+	limit, ok := limits[state]
+	if !ok {
+		return nil
+	}
+
+	count, err := svc.db.CountStateTasks(userId, state)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return fmt.Errorf("%w: %d of %d tasks are %s", ErrWIPLimitReached, count, limit, state)
+	}
+
+	return nil
+}
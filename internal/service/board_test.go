@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (s *svcTestSuite) TestBoard() {
+	errLimits := errors.New("error retrieving wip limits")
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	tasks := []common.Task{
+		{Id: "0001", UserId: user.Id, Description: "description 1", State: common.TaskStateInProgress, Position: "a"},
+		{Id: "0002", UserId: user.Id, Description: "description 2", State: common.TaskStateToDo, Position: "b"},
+		{Id: "0003", UserId: user.Id, Description: "description 3", State: common.TaskStateInProgress, Position: "c"},
+	}
+	limit := 2
+
+	tests := map[string]struct {
+		dbUser       *common.User
+		dbError      error
+		expectedResp *common.Board
+		expectedErr  error
+	}{
+		"success": {
+			dbUser: user,
+			expectedResp: &common.Board{UserId: user.Id, Columns: []common.BoardColumn{
+				{State: common.TaskStateToDo, Count: 1, Tasks: []common.Task{tasks[1]}},
+				{State: common.TaskStateInProgress, Count: 2, Limit: &limit, Tasks: []common.Task{tasks[0], tasks[2]}},
+				{State: common.TaskStateBlocked, Tasks: []common.Task{}},
+				{State: common.TaskStateDone, Tasks: []common.Task{}},
+				{State: common.TaskStateCancelled, Tasks: []common.Task{}},
+			}},
+		},
+		"user not found": {
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbUser:      user,
+			dbError:     errLimits,
+			expectedErr: errLimits,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetUser(user.Id).
+				Return(test.dbUser, nil)
+			if test.dbUser.Id != "" {
+				s.getDB().
+					ListUserTasks(user.Id, common.TaskFilter{}, common.PageRequest{}).
+					Return(&common.TaskPage{Items: append([]common.Task(nil), tasks...)}, nil)
+				s.getDB().
+					ListTaskLabels([]string{"0001", "0002", "0003"}).
+					Return(map[string][]string{}, nil)
+				s.getDB().
+					ListChecklistItems([]string{"0001", "0002", "0003"}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+				s.getDB().
+					ListWIPLimits(user.Id).
+					Return(common.WIPLimits{common.TaskStateInProgress: limit}, test.dbError)
+			}
+
+			resp, err := s.svc.Board(user.Id, common.TaskFilter{})
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestSetWIPLimits() {
+	errSetLimits := errors.New("error inserting wip limits")
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+
+	tests := map[string]struct {
+		limits      common.WIPLimits
+		dbUser      *common.User
+		dbLimits    common.WIPLimits
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			limits:   common.WIPLimits{common.TaskStateInProgress: 3, common.TaskStateBlocked: 1},
+			dbUser:   user,
+			dbLimits: common.WIPLimits{common.TaskStateInProgress: 3, common.TaskStateBlocked: 1},
+		},
+		"no limits": {
+			dbUser:   user,
+			dbLimits: common.WIPLimits{},
+		},
+		"unknown state": {
+			limits:      common.WIPLimits{"sleeping": 3},
+			expectedErr: ErrInvalidWIPLimit,
+		},
+		"zero limit": {
+			limits:      common.WIPLimits{common.TaskStateInProgress: 0},
+			expectedErr: ErrInvalidWIPLimit,
+		},
+		"user not found": {
+			limits:      common.WIPLimits{common.TaskStateInProgress: 3},
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			limits:      common.WIPLimits{common.TaskStateInProgress: 3},
+			dbUser:      user,
+			dbLimits:    common.WIPLimits{common.TaskStateInProgress: 3},
+			dbError:     errSetLimits,
+			expectedErr: errSetLimits,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbUser != nil {
+				s.getDB().
+					GetUser(user.Id).
+					Return(test.dbUser, nil)
+			}
+			if test.dbLimits != nil {
+				s.getDB().
+					SetWIPLimits(user.Id, test.dbLimits).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.SetWIPLimits(user.Id, test.limits)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.dbLimits, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AddChecklistItem adds an unchecked item at the end of the checklist of a task.
+func (svc *Service) AddChecklistItem(item *common.ChecklistItem) (*common.ChecklistItem, error) {
+	item.Id = uuid.New().String()
+	item.Text = strings.TrimSpace(item.Text)
+	item.Checked = false
+	if item.Text == "" {
+		return nil, fmt.Errorf("%w: text is required", ErrInvalidChecklist)
+	}
+
+	task, err := svc.db.GetTask(item.TaskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	last, err := svc.db.LastChecklistPosition(item.TaskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve checklist position.", zap.Error(err))
+		return nil, err
+	}
+	item.Position, err = appendItem(last, item.Id, func() ([]common.Position, error) {
+		return svc.db.ListChecklistPositions(item.TaskId)
+	}, svc.db.SetChecklistPositions)
+	if err != nil {
+		svc.logger.Error("Unable to rebalance checklist.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.db.AddChecklistItem(item); err != nil {
+		svc.logger.Error("Unable to add checklist item.", zap.Error(err))
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ToggleChecklistItem checks an unchecked item of the checklist of a task, or unchecks a checked one.
+func (svc *Service) ToggleChecklistItem(taskId string, id string) (*common.ChecklistItem, error) {
+	item, err := svc.db.ToggleChecklistItem(taskId, id)
+	if err != nil {
+		svc.logger.Error("Unable to update checklist item.", zap.Error(err))
+		return nil, err
+	}
+	if item.Id == "" {
+		return nil, fmt.Errorf("checklist item %w", ErrNotFound)
+	}
+
+	return item, nil
+}
+
+// MoveChecklistItem places an item of the checklist of a task right before or right after another
+// item of the same checklist.
+func (svc *Service) MoveChecklistItem(taskId string, id string, move common.Move) (*common.ChecklistItem, error) {
+	anchorId, err := moveAnchor(id, move)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := svc.getChecklistItem(taskId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := svc.db.ListChecklistPositions(taskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve checklist positions.", zap.Error(err))
+		return nil, err
+	}
+
+	key, ok, err := placeItem(positions, id, move, svc.db.SetChecklistPositions)
+	if err != nil {
+		svc.logger.Error("Unable to move checklist item.", zap.Error(err))
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: item %s is not an item of the same checklist", ErrInvalidMove, anchorId)
+	}
+	item.Position = key
+
+	return item, nil
+}
+
+// DeleteChecklistItem deletes an item of the checklist of a task.
+func (svc *Service) DeleteChecklistItem(taskId string, id string) error {
+	if _, err := svc.getChecklistItem(taskId, id); err != nil {
+		return err
+	}
+
+	if err := svc.db.DeleteChecklistItem(id); err != nil {
+		svc.logger.Error("Unable to delete checklist item.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// getChecklistItem returns an item of the checklist of a task, reporting items of other tasks as not found.
+func (svc *Service) getChecklistItem(taskId string, id string) (*common.ChecklistItem, error) {
+	item, err := svc.db.GetChecklistItem(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve checklist item.", zap.Error(err))
+		return nil, err
+	}
+	if item.Id == "" || item.TaskId != taskId {
+		return nil, fmt.Errorf("checklist item %w", ErrNotFound)
+	}
+
+	return item, nil
+}
+
+// loadChecklists fills in the checklist of the given tasks and counts its checked items.
+func (svc *Service) loadChecklists(tasks []common.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.Id
+	}
+
+	checklists, err := svc.db.ListChecklistItems(ids)
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		items := checklists[tasks[i].Id]
+		if len(items) == 0 {
+			continue
+		}
+
+		progress := &common.ChecklistProgress{Total: len(items)}
+		for _, item := range items {
+			if item.Checked {
+				progress.Done++
+			}
+		}
+		tasks[i].Checklist = items
+		tasks[i].ChecklistProgress = progress
+	}
+
+	return nil
+}
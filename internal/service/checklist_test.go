@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestAddChecklistItem() {
+	errAddItem := errors.New("error inserting checklist item")
+	task := &common.Task{Id: "0001", UserId: "00001", Description: "pack", State: "to_do"}
+
+	tests := map[string]struct {
+		text             string
+		dbTask           *common.Task
+		dbLast           string
+		dbError          error
+		expectedPosition string
+		expectedErr      error
+	}{
+		"success": {
+			text:             " toothbrush ",
+			dbTask:           task,
+			dbLast:           "i",
+			expectedPosition: "j",
+		},
+		"empty checklist": {
+			text:             "toothbrush",
+			dbTask:           task,
+			expectedPosition: "i",
+		},
+		"empty text": {
+			text:        " ",
+			expectedErr: ErrInvalidChecklist,
+		},
+		"task not found": {
+			text:        "toothbrush",
+			dbTask:      &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			text:             "toothbrush",
+			dbTask:           task,
+			dbError:          errAddItem,
+			expectedPosition: "i",
+			expectedErr:      errAddItem,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			item := &common.ChecklistItem{TaskId: task.Id, Text: test.text, Checked: true}
+
+			// set up dao mock
+			if test.dbTask != nil {
+				s.getDB().
+					GetTask(task.Id).
+					Return(test.dbTask, nil)
+			}
+			if test.expectedPosition != "" {
+				s.getDB().
+					LastChecklistPosition(task.Id).
+					Return(test.dbLast, nil)
+				s.getDB().
+					AddChecklistItem(gomock.Any()).
+					Do(func(item *common.ChecklistItem) {
+						s.Assert().NotEmpty(item.Id)
+						s.Assert().Equal("toothbrush", item.Text)
+						s.Assert().False(item.Checked)
+						s.Assert().Equal(test.expectedPosition, item.Position)
+					}).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.AddChecklistItem(item)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedPosition, resp.Position)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestToggleChecklistItem() {
+	errSetChecked := errors.New("error updating checklist item")
+
+	tests := map[string]struct {
+		dbItem          *common.ChecklistItem
+		dbError         error
+		expectedChecked bool
+		expectedErr     error
+	}{
+		"check": {
+			dbItem:          &common.ChecklistItem{Id: "1001", TaskId: "0001", Text: "toothbrush", Checked: true},
+			expectedChecked: true,
+		},
+		"uncheck": {
+			dbItem:          &common.ChecklistItem{Id: "1001", TaskId: "0001", Text: "toothbrush"},
+			expectedChecked: false,
+		},
+		"not found": {
+			dbItem:      &common.ChecklistItem{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbError:     errSetChecked,
+			expectedErr: errSetChecked,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock, the database flipping the item itself
+			s.getDB().
+				ToggleChecklistItem("0001", "1001").
+				Return(test.dbItem, test.dbError)
+
+			resp, err := s.svc.ToggleChecklistItem("0001", "1001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedChecked, resp.Checked)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestMoveChecklistItem() {
+	positions := []common.Position{{Id: "1001", Position: "a"}, {Id: "1002", Position: "c"}, {Id: "1003", Position: "e"}}
+
+	tests := map[string]struct {
+		move             common.Move
+		dbItem           *common.ChecklistItem
+		expectedPosition string
+		expectedErr      error
+	}{
+		"before": {
+			move:             common.Move{Before: "1002"},
+			dbItem:           &common.ChecklistItem{Id: "1003", TaskId: "0001"},
+			expectedPosition: "b",
+		},
+		"anchor of another checklist": {
+			move:        common.Move{After: "1004"},
+			dbItem:      &common.ChecklistItem{Id: "1003", TaskId: "0001"},
+			expectedErr: ErrInvalidMove,
+		},
+		"no anchor": {
+			expectedErr: ErrInvalidMove,
+		},
+		"item of another task": {
+			move:        common.Move{Before: "1002"},
+			dbItem:      &common.ChecklistItem{Id: "1003", TaskId: "0002"},
+			expectedErr: ErrNotFound,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbItem != nil {
+				s.getDB().
+					GetChecklistItem("1003").
+					Return(test.dbItem, nil)
+			}
+			if test.dbItem != nil && test.dbItem.TaskId == "0001" {
+				s.getDB().
+					ListChecklistPositions("0001").
+					Return(append([]common.Position(nil), positions...), nil)
+			}
+			if test.expectedPosition != "" {
+				s.getDB().
+					SetChecklistPositions([]common.Position{{Id: "1003", Position: test.expectedPosition}}).
+					Return(nil)
+			}
+
+			resp, err := s.svc.MoveChecklistItem("0001", "1003", test.move)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedPosition, resp.Position)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestDeleteChecklistItem() {
+	errDeleteItem := errors.New("error deleting checklist item")
+
+	tests := map[string]struct {
+		dbItem      *common.ChecklistItem
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			dbItem: &common.ChecklistItem{Id: "1001", TaskId: "0001"},
+		},
+		"not found": {
+			dbItem:      &common.ChecklistItem{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbItem:      &common.ChecklistItem{Id: "1001", TaskId: "0001"},
+			dbError:     errDeleteItem,
+			expectedErr: errDeleteItem,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetChecklistItem("1001").
+				Return(test.dbItem, nil)
+			if test.dbItem.Id != "" {
+				s.getDB().
+					DeleteChecklistItem("1001").
+					Return(test.dbError)
+			}
+
+			err := s.svc.DeleteChecklistItem("0001", "1001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
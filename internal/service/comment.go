@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AddComment adds a comment to a task, optionally replying to a top-level comment of the same task.
+func (svc *Service) AddComment(comment *common.Comment) (*common.Comment, error) {
+	comment.Id = uuid.New().String()
+	comment.Body = strings.TrimSpace(comment.Body)
+
+	task, err := svc.db.GetTask(comment.TaskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	if err := svc.validateComment(comment); err != nil {
+		svc.logger.Error("Unable add comment.", zap.Error(err))
+		return nil, err
+	}
+
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	if err := svc.db.AddComment(comment); err != nil {
+		svc.logger.Error("Unable add comment.", zap.Error(err))
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment changes the body of a comment. Only its author may do so.
+func (svc *Service) UpdateComment(comment *common.Comment) (*common.Comment, error) {
+	current, err := svc.GetComment(comment.TaskId, comment.Id)
+	if err != nil {
+		return nil, err
+	}
+	if current.AuthorId != comment.AuthorId {
+		return nil, fmt.Errorf("%w: only the author can edit a comment", ErrForbidden)
+	}
+
+	current.Body = strings.TrimSpace(comment.Body)
+	if current.Body == "" {
+		err := fmt.Errorf("%w: body is required", ErrInvalidComment)
+		svc.logger.Error("Unable update comment.", zap.Error(err))
+		return nil, err
+	}
+	current.UpdatedAt = time.Now()
+
+	if err := svc.db.UpdateComment(current); err != nil {
+		svc.logger.Error("Unable update comment.", zap.Error(err))
+		return nil, err
+	}
+
+	return current, nil
+}
+
+// GetComment returns a comment of a task, reporting comments of other tasks as not found.
+func (svc *Service) GetComment(taskId string, id string) (*common.Comment, error) {
+	comment, err := svc.db.GetComment(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve comment.", zap.Error(err))
+		return nil, err
+	}
+	if comment.Id == "" || comment.TaskId != taskId {
+		return nil, fmt.Errorf("comment %w", ErrNotFound)
+	}
+
+	return comment, nil
+}
+
+// DeleteComment deletes a comment and its replies. Only its author may do so.
+func (svc *Service) DeleteComment(taskId string, id string, authorId string) error {
+	comment, err := svc.GetComment(taskId, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorId != authorId {
+		return fmt.Errorf("%w: only the author can delete a comment", ErrForbidden)
+	}
+
+	if err := svc.db.DeleteComment(id); err != nil {
+		svc.logger.Error("Unable to delete comment.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (svc *Service) ListTaskComments(taskId string) ([]common.Comment, error) {
+	comments, err := svc.db.ListTaskComments(taskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task comments.", zap.Error(err))
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// validateComment checks that a new comment has a body and only replies to a top-level comment of its task.
+func (svc *Service) validateComment(comment *common.Comment) error {
+	if comment.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidComment)
+	}
+
+	if comment.ReplyTo == nil {
+		return nil
+	}
+
+	parent, err := svc.db.GetComment(*comment.ReplyTo)
+	if err != nil {
+		return err
+	}
+	if parent.Id == "" || parent.TaskId != comment.TaskId {
+		return fmt.Errorf("%w: comment %s not found on task %s", ErrInvalidComment, *comment.ReplyTo, comment.TaskId)
+	}
+	if parent.ReplyTo != nil {
+		return fmt.Errorf("%w: replies cannot be replied to", ErrInvalidComment)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestAddComment() {
+	errAddComment := errors.New("error inserting comment")
+	task := &common.Task{Id: "00001", UserId: "000001", Description: "description", State: "to_do"}
+	topLevel := "0002"
+	reply := "0003"
+	otherTask := "0004"
+	parents := map[string]*common.Comment{
+		topLevel:  {Id: topLevel, TaskId: task.Id, AuthorId: "000002", Body: "who takes it?"},
+		reply:     {Id: reply, TaskId: task.Id, AuthorId: "000001", Body: "me", ReplyTo: &topLevel},
+		otherTask: {Id: otherTask, TaskId: "00002", AuthorId: "000001", Body: "elsewhere"},
+	}
+
+	tests := map[string]struct {
+		task        *common.Task
+		body        string
+		replyTo     *string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			task: task,
+			body: " on it ",
+		},
+		"reply": {
+			task:    task,
+			body:    "me",
+			replyTo: &topLevel,
+		},
+		"task not found": {
+			task:        &common.Task{},
+			body:        "on it",
+			expectedErr: ErrNotFound,
+		},
+		"empty body": {
+			task:        task,
+			body:        " ",
+			expectedErr: ErrInvalidComment,
+		},
+		"reply to a reply": {
+			task:        task,
+			body:        "me too",
+			replyTo:     &reply,
+			expectedErr: ErrInvalidComment,
+		},
+		"reply to another task": {
+			task:        task,
+			body:        "me",
+			replyTo:     &otherTask,
+			expectedErr: ErrInvalidComment,
+		},
+		"fail": {
+			task:        task,
+			body:        "on it",
+			dbError:     errAddComment,
+			expectedErr: errAddComment,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			comment := &common.Comment{TaskId: task.Id, AuthorId: "000001", Body: test.body, ReplyTo: test.replyTo}
+
+			// set up dao mock
+			s.getDB().
+				GetTask(task.Id).
+				Return(test.task, nil)
+			if test.replyTo != nil {
+				s.getDB().
+					GetComment(*test.replyTo).
+					Return(parents[*test.replyTo], nil)
+			}
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					AddComment(gomock.Any()).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.AddComment(comment)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().NotEmpty(resp.Id)
+				s.Assert().Equal(resp.CreatedAt, resp.UpdatedAt)
+				s.Assert().False(resp.CreatedAt.IsZero())
+				s.Assert().Equal(strings.TrimSpace(test.body), resp.Body)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestUpdateComment() {
+	errUpdateComment := errors.New("error updating comment")
+
+	tests := map[string]struct {
+		current     *common.Comment
+		authorId    string
+		body        string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			current:  &common.Comment{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "on it"},
+			authorId: "000001",
+			body:     "done",
+		},
+		"not the author": {
+			current:     &common.Comment{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "on it"},
+			authorId:    "000002",
+			body:        "done",
+			expectedErr: ErrForbidden,
+		},
+		"another task": {
+			current:     &common.Comment{Id: "0001", TaskId: "00002", AuthorId: "000001", Body: "on it"},
+			authorId:    "000001",
+			body:        "done",
+			expectedErr: ErrNotFound,
+		},
+		"empty body": {
+			current:     &common.Comment{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "on it"},
+			authorId:    "000001",
+			body:        "",
+			expectedErr: ErrInvalidComment,
+		},
+		"fail": {
+			current:     &common.Comment{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "on it"},
+			authorId:    "000001",
+			body:        "done",
+			dbError:     errUpdateComment,
+			expectedErr: errUpdateComment,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			comment := &common.Comment{Id: "0001", TaskId: "00001", AuthorId: test.authorId, Body: test.body}
+
+			// set up dao mock
+			s.getDB().
+				GetComment(comment.Id).
+				Return(test.current, nil)
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					UpdateComment(test.current).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.UpdateComment(comment)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal("done", resp.Body)
+				s.Assert().False(resp.UpdatedAt.IsZero())
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestDeleteComment() {
+	errDeleteComment := errors.New("error deleting comment")
+	comment := &common.Comment{Id: "0001", TaskId: "00001", AuthorId: "000001", Body: "on it"}
+
+	tests := map[string]struct {
+		current     *common.Comment
+		authorId    string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			current:  comment,
+			authorId: "000001",
+		},
+		"not found": {
+			current:     &common.Comment{},
+			authorId:    "000001",
+			expectedErr: ErrNotFound,
+		},
+		"not the author": {
+			current:     comment,
+			authorId:    "000002",
+			expectedErr: ErrForbidden,
+		},
+		"fail": {
+			current:     comment,
+			authorId:    "000001",
+			dbError:     errDeleteComment,
+			expectedErr: errDeleteComment,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetComment(comment.Id).
+				Return(test.current, nil)
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					DeleteComment(comment.Id).
+					Return(test.dbError)
+			}
+
+			err := s.svc.DeleteComment(comment.TaskId, comment.Id, test.authorId)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
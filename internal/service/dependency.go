@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// AddTaskDependency makes a task depend on another one of the same user, refusing dependencies that
+// would create a cycle. The task of another user is not found, so neither its existence nor its
+// state is revealed.
+func (svc *Service) AddTaskDependency(dependency *common.TaskDependency) error {
+	if err := svc.validateDependency(dependency); err != nil {
+		svc.logger.Error("Unable add task dependency.", zap.Error(err))
+		return err
+	}
+
+	if err := svc.db.AddTaskDependency(dependency); err != nil {
+		svc.logger.Error("Unable add task dependency.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (svc *Service) DeleteTaskDependency(dependency *common.TaskDependency) error {
+	if err := svc.db.DeleteTaskDependency(dependency); err != nil {
+		svc.logger.Error("Unable to delete task dependency.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (svc *Service) ListTaskDependencies(id string) ([]common.Task, error) {
+	tasks, err := svc.db.ListTaskDependencies(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task dependencies.", zap.Error(err))
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (svc *Service) validateDependency(dependency *common.TaskDependency) error {
+	if dependency.DependsOnId == "" || dependency.TaskId == dependency.DependsOnId {
+		return fmt.Errorf("%w: task %s cannot depend on itself", ErrInvalidDependency, dependency.TaskId)
+	}
+
+	userId := ""
+	for _, id := range []string{dependency.TaskId, dependency.DependsOnId} {
+		task, err := svc.db.GetTask(id)
+		if err != nil {
+			return err
+		}
+		// the task of another user is not found either, so its existence is not revealed
+		if task.Id == "" || (userId != "" && task.UserId != userId) {
+			return fmt.Errorf("task %s %w", id, ErrNotFound)
+		}
+		userId = task.UserId
+	}
+
+	// the new dependency closes a cycle when the task it depends on already depends on the task
+	dependencies, err := svc.db.ListTransitiveDependencies(dependency.DependsOnId)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(dependencies, dependency.TaskId) {
+		return fmt.Errorf("%w: task %s already depends on task %s", ErrInvalidDependency, dependency.DependsOnId, dependency.TaskId)
+	}
+
+	return nil
+}
+
+// validateDependenciesDone checks that every task the task depends on is done or cancelled.
+func (svc *Service) validateDependenciesDone(id string) error {
+	open, err := svc.db.CountOpenDependencies(id)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("%w: %d dependencies of task %s are not done", ErrTaskBlocked, open, id)
+	}
+
+	return nil
+}
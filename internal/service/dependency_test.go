@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (s *svcTestSuite) TestAddTaskDependency() {
+	errAddDependency := errors.New("error inserting task dependency")
+
+	tests := map[string]struct {
+		dependency   *common.TaskDependency
+		dbTask       *common.Task
+		dbDependsOn  *common.Task
+		dbTransitive []string
+		dbError      error
+		expectedErr  error
+	}{
+		"success": {
+			dependency:   &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"},
+			dbTask:       &common.Task{Id: "0001", UserId: "00001"},
+			dbDependsOn:  &common.Task{Id: "0002", UserId: "00001"},
+			dbTransitive: []string{"0003"},
+		},
+		"itself": {
+			dependency:  &common.TaskDependency{TaskId: "0001", DependsOnId: "0001"},
+			expectedErr: ErrInvalidDependency,
+		},
+		"not found": {
+			dependency:  &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"},
+			dbTask:      &common.Task{Id: "0001", UserId: "00001"},
+			dbDependsOn: &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"other user": {
+			dependency:  &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"},
+			dbTask:      &common.Task{Id: "0001", UserId: "00001"},
+			dbDependsOn: &common.Task{Id: "0002", UserId: "00002"},
+			expectedErr: ErrNotFound,
+		},
+		"cycle": {
+			dependency:   &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"},
+			dbTask:       &common.Task{Id: "0001", UserId: "00001"},
+			dbDependsOn:  &common.Task{Id: "0002", UserId: "00001"},
+			dbTransitive: []string{"0003", "0001"},
+			expectedErr:  ErrInvalidDependency,
+		},
+		"fail": {
+			dependency:   &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"},
+			dbTask:       &common.Task{Id: "0001", UserId: "00001"},
+			dbDependsOn:  &common.Task{Id: "0002", UserId: "00001"},
+			dbTransitive: []string{},
+			dbError:      errAddDependency,
+			expectedErr:  errAddDependency,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbTask != nil {
+				s.getDB().
+					GetTask(test.dependency.TaskId).
+					Return(test.dbTask, nil)
+				s.getDB().
+					GetTask(test.dependency.DependsOnId).
+					Return(test.dbDependsOn, nil)
+			}
+
+			if test.dbTransitive != nil {
+				s.getDB().
+					ListTransitiveDependencies(test.dependency.DependsOnId).
+					Return(test.dbTransitive, nil)
+			}
+
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					AddTaskDependency(test.dependency).
+					Return(test.dbError)
+			}
+
+			err := s.svc.AddTaskDependency(test.dependency)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestAddTaskDependencyOtherUser() {
+	dependency := &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"}
+
+	// a missing task and a task of another user are answered alike
+	errs := []error{}
+	for _, dbDependsOn := range []*common.Task{{}, {Id: "0002", UserId: "00002"}} {
+		s.getDB().
+			GetTask(dependency.TaskId).
+			Return(&common.Task{Id: "0001", UserId: "00001"}, nil)
+		s.getDB().
+			GetTask(dependency.DependsOnId).
+			Return(dbDependsOn, nil)
+
+		errs = append(errs, s.svc.AddTaskDependency(dependency))
+	}
+
+	s.Assert().ErrorIs(errs[0], ErrNotFound)
+	s.Assert().Equal(errs[0], errs[1])
+}
+
+func (s *svcTestSuite) TestDeleteTaskDependency() {
+	errDeleteDependency := errors.New("error deleting task dependency")
+	dependency := &common.TaskDependency{TaskId: "0001", DependsOnId: "0002"}
+
+	tests := map[string]struct {
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			dbError:     nil,
+			expectedErr: nil,
+		},
+		"fail": {
+			dbError:     errDeleteDependency,
+			expectedErr: errDeleteDependency,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				DeleteTaskDependency(dependency).
+				Return(test.dbError)
+
+			err := s.svc.DeleteTaskDependency(dependency)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestListTaskDependencies() {
+	errGetTasks := errors.New("any error")
+	tasks := []common.Task{
+		{
+			Id:          "0002",
+			UserId:      "00001",
+			Description: "description 2",
+			State:       "to_do",
+		},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbTasks      []common.Task
+		expectedResp []common.Task
+		expectedErr  error
+	}{
+		"success": {
+			dbError:      nil,
+			dbTasks:      tasks,
+			expectedResp: tasks,
+			expectedErr:  nil,
+		},
+		"fail": {
+			dbError:      errGetTasks,
+			dbTasks:      nil,
+			expectedResp: nil,
+			expectedErr:  errGetTasks,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				ListTaskDependencies("0001").
+				Return(test.dbTasks, test.dbError)
+
+			tasks, err := s.svc.ListTaskDependencies("0001")
+			s.Assert().Equal(test.expectedResp, tasks)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
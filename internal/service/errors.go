@@ -0,0 +1,79 @@
+package service
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidState is returned when a task carries a state that is not one of common.TaskStates.
+	ErrInvalidState = errors.New("invalid task state")
+	// ErrInvalidTransition is returned when a task cannot move from its current state to the requested one.
+	ErrInvalidTransition = errors.New("invalid task state transition")
+	// ErrInvalidParent is returned when a task cannot be made a subtask of the requested parent.
+	ErrInvalidParent = errors.New("invalid parent task")
+	// ErrInvalidDependency is returned when a task cannot depend on the requested task.
+	ErrInvalidDependency = errors.New("invalid task dependency")
+	// ErrInvalidRecurrence is returned when a task carries a recurrence rule that cannot be evaluated.
+	ErrInvalidRecurrence = errors.New("invalid task recurrence")
+	// ErrInvalidProject is returned when a project has no name or a task refers to a project it cannot belong to.
+	ErrInvalidProject = errors.New("invalid project")
+	// ErrInvalidLabel is returned when a label has no name.
+	ErrInvalidLabel = errors.New("invalid label")
+	// ErrLabelExists is returned when a user already has a label with the requested name.
+	ErrLabelExists = errors.New("label already exists")
+	// ErrInvalidComment is returned when a comment has no body or replies to a comment it cannot reply to.
+	ErrInvalidComment = errors.New("invalid comment")
+	// ErrForbidden is returned when a user acts on a resource only its owner may change.
+	ErrForbidden = errors.New("forbidden")
+	// ErrInvalidAttachment is returned when an upload carries no file name.
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	// ErrAttachmentTooLarge is returned when an upload exceeds the configured attachment size limit.
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	// ErrInvalidSearch is returned when a task search has no query.
+	ErrInvalidSearch = errors.New("invalid search")
+	// ErrInvalidRestore is returned when a task is restored while its parent task or its user is still in the trash.
+	ErrInvalidRestore = errors.New("invalid restore")
+	// ErrInvalidBatch is returned when a task batch is empty, too large or has a malformed operation.
+	ErrInvalidBatch = errors.New("invalid batch")
+	// ErrBatchAborted is the result of the operations of an atomic batch left unapplied because
+	// another operation of the batch failed.
+	ErrBatchAborted = errors.New("batch aborted")
+	// ErrInvalidMove is returned when a task or a checklist item is moved without exactly one anchor,
+	// or next to an item that is not another item of the same list.
+	ErrInvalidMove = errors.New("invalid move")
+	// ErrTimerRunning is returned when a user starts a timer while another one of theirs is running.
+	ErrTimerRunning = errors.New("timer already running")
+	// ErrInvalidTimesheet is returned when a timesheet is requested for a period ending before it starts.
+	ErrInvalidTimesheet = errors.New("invalid timesheet")
+	// ErrInvalidChecklist is returned when a checklist item has no text.
+	ErrInvalidChecklist = errors.New("invalid checklist item")
+	// ErrInvalidTemplate is returned when a template has no name, no tasks or too many, or a task
+	// without a description or with an invalid due offset.
+	ErrInvalidTemplate = errors.New("invalid template")
+	// ErrInvalidWIPLimit is returned when a work-in-progress limit is set on an unknown state or is not positive.
+	ErrInvalidWIPLimit = errors.New("invalid wip limit")
+	// ErrWIPLimitReached is returned when a task is moved into a board column already holding as many
+	// tasks as its work-in-progress limit allows.
+	ErrWIPLimitReached = errors.New("wip limit reached")
+	// ErrInvalidImport is returned when an imported file is too large, holds too many rows, or
+	// cannot be read in its format at all.
+	ErrInvalidImport = errors.New("invalid import")
+	// ErrInvalidFeedToken is returned when a calendar feed is requested without the feed token of its user.
+	ErrInvalidFeedToken = errors.New("invalid feed token")
+	// ErrInvalidSnooze is returned when a task is snoozed without exactly one of a time and a duration,
+	// until a time already past, or while it is done or cancelled.
+	ErrInvalidSnooze = errors.New("invalid snooze")
+	// ErrInvalidFilter is returned when a task list is filtered with an expression comparing an unknown
+	// field, or a field with an operator or a value it does not support.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrInvalidPage is returned when a list is requested with a cursor that was not returned with a page of it.
+	ErrInvalidPage = errors.New("invalid page")
+	// ErrVersionMismatch is returned when a task or a user is updated from a version it is no longer at,
+	// because it changed since the client read it.
+	ErrVersionMismatch = errors.New("version mismatch")
+	// ErrConcurrentUpdate is returned when an update without a version keeps losing the race against
+	// concurrent updates of the same task or user.
+	ErrConcurrentUpdate = errors.New("concurrent update")
+	// ErrTaskBlocked is returned when a task is completed while some of its dependencies are still open.
+	ErrTaskBlocked = errors.New("task is blocked by open dependencies")
+)
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// exportBatchSize is the number of tasks whose labels, checklists, comments and revisions are
+// loaded together during an export.
+const exportBatchSize = 100
+
+// ExportIter yields the tasks of an export one at a time. A failure ends it with an error.
+type ExportIter = iter.Seq2[common.ExportedTask, error]
+
+// ExportTasks returns every task of a user, archived and snoozed ones included, with their labels,
+// checklists, comments and revisions. The tasks are read from the database while the iterator is
+// ranged over, a batch at a time, so that an export never holds all the tasks of the user in memory.
+func (svc *Service) ExportTasks(userId string) (ExportIter, error) {
+	user, err := svc.db.GetUser(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	return func(yield func(common.ExportedTask, error) bool) {
+		batch := make([]common.Task, 0, exportBatchSize)
+		// flush loads the details of the batch and yields its tasks, reporting whether to go on.
+		flush := func() bool {
+			exported, err := svc.exportBatch(batch)
+			if err != nil {
+				yield(common.ExportedTask{}, err)
+				return false
+			}
+			for _, task := range exported {
+				if !yield(task, nil) {
+					return false
+				}
+			}
+			batch = batch[:0]
+			return true
+		}
+
+		for task, err := range svc.db.IterUserTasks(userId, common.TaskFilter{IncludeArchived: true, IncludeSnoozed: true}) {
+			if err != nil {
+				svc.logger.Error("Unable to retrieve user tasks.", zap.Error(err))
+				yield(common.ExportedTask{}, err)
+				return
+			}
+
+			batch = append(batch, task)
+			if len(batch) == exportBatchSize && !flush() {
+				return
+			}
+		}
+		if len(batch) > 0 {
+			flush()
+		}
+	}, nil
+}
+
+// exportBatch loads the labels, checklists, comments and revisions of a batch of exported tasks.
+func (svc *Service) exportBatch(tasks []common.Task) ([]common.ExportedTask, error) {
+	if err := svc.loadLabels(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task labels.", zap.Error(err))
+		return nil, err
+	}
+	if err := svc.loadChecklists(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task checklists.", zap.Error(err))
+		return nil, err
+	}
+
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.Id
+	}
+	comments, err := svc.db.ListCommentsOfTasks(ids)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task comments.", zap.Error(err))
+		return nil, err
+	}
+	revisions, err := svc.db.ListRevisionsOfTasks(ids)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task revisions.", zap.Error(err))
+		return nil, err
+	}
+
+	exported := make([]common.ExportedTask, len(tasks))
+	for i, task := range tasks {
+		exported[i] = common.ExportedTask{
+			Task:      task,
+			Comments:  comments[task.Id],
+			Revisions: revisions[task.Id],
+		}
+	}
+
+	return exported, nil
+}
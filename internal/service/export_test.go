@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/golang/mock/gomock"
+)
+
+// taskIter returns a db.TaskIter yielding tasks, then err if any.
+func taskIter(tasks []common.Task, err error) db.TaskIter {
+	return func(yield func(common.Task, error) bool) {
+		for _, task := range tasks {
+			if !yield(task, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(common.Task{}, err)
+		}
+	}
+}
+
+func (s *svcTestSuite) TestExportTasks() {
+	errIter := errors.New("error reading tasks")
+	errComments := errors.New("error retrieving comments")
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	tasks := []common.Task{
+		{Id: "0001", UserId: user.Id, Description: "description 1", State: common.TaskStateToDo, Position: "a"},
+		{Id: "0002", UserId: user.Id, Description: "description 2", State: common.TaskStateDone, Position: "b"},
+	}
+	comment := common.Comment{Id: "c001", TaskId: "0002", AuthorId: user.Id, Body: "done"}
+	revision := common.TaskRevision{Id: "r001", TaskId: "0001", Revision: 1, Changes: []common.FieldChange{}}
+
+	tests := map[string]struct {
+		dbUser        *common.User
+		dbIterError   error
+		dbCommentsErr error
+		expectedResp  []common.ExportedTask
+		expectedErr   error
+	}{
+		"success": {
+			dbUser: user,
+			expectedResp: []common.ExportedTask{
+				{Task: common.Task{Id: "0001", UserId: user.Id, Description: "description 1", State: common.TaskStateToDo, Position: "a", Labels: []string{"home"}}, Revisions: []common.TaskRevision{revision}},
+				{Task: tasks[1], Comments: []common.Comment{comment}},
+			},
+		},
+		"user not found": {
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"tasks fail": {
+			dbUser:      user,
+			dbIterError: errIter,
+			expectedErr: errIter,
+		},
+		"comments fail": {
+			dbUser:        user,
+			dbCommentsErr: errComments,
+			expectedErr:   errComments,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetUser(user.Id).
+				Return(test.dbUser, nil)
+			if test.dbUser.Id != "" {
+				if test.dbIterError != nil {
+					s.getDB().
+						IterUserTasks(user.Id, common.TaskFilter{IncludeArchived: true, IncludeSnoozed: true}).
+						Return(taskIter(nil, test.dbIterError))
+				} else {
+					s.getDB().
+						IterUserTasks(user.Id, common.TaskFilter{IncludeArchived: true, IncludeSnoozed: true}).
+						Return(taskIter(tasks, nil))
+					s.getDB().
+						ListTaskLabels([]string{"0001", "0002"}).
+						Return(map[string][]string{"0001": {"home"}}, nil)
+					s.getDB().
+						ListChecklistItems([]string{"0001", "0002"}).
+						Return(map[string][]common.ChecklistItem{}, nil)
+					s.getDB().
+						ListCommentsOfTasks([]string{"0001", "0002"}).
+						Return(map[string][]common.Comment{"0002": {comment}}, test.dbCommentsErr)
+				}
+				if test.expectedErr == nil {
+					s.getDB().
+						ListRevisionsOfTasks([]string{"0001", "0002"}).
+						Return(map[string][]common.TaskRevision{"0001": {revision}}, nil)
+				}
+			}
+
+			export, err := s.svc.ExportTasks(user.Id)
+			if test.dbUser.Id == "" {
+				s.Assert().ErrorIs(err, test.expectedErr)
+				return
+			}
+			s.Require().NoError(err)
+
+			var resp []common.ExportedTask
+			for task, err := range export {
+				if err != nil {
+					s.Assert().ErrorIs(err, test.expectedErr)
+					return
+				}
+				resp = append(resp, task)
+			}
+			s.Assert().Nil(test.expectedErr)
+			s.Assert().Equal(test.expectedResp, resp)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestExportTasksBatches() {
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	tasks := make([]common.Task, exportBatchSize+1)
+	for i := range tasks {
+		tasks[i] = common.Task{Id: fmt.Sprintf("%04d", i), UserId: user.Id, Description: "description", State: common.TaskStateToDo}
+	}
+
+	// set up dao mock
+	s.getDB().
+		GetUser(user.Id).
+		Return(user, nil)
+	s.getDB().
+		IterUserTasks(user.Id, common.TaskFilter{IncludeArchived: true, IncludeSnoozed: true}).
+		Return(taskIter(tasks, nil))
+	// the details of the tasks are loaded a full batch, then the last task
+	for _, size := range []int{exportBatchSize, 1} {
+		s.getDB().
+			ListTaskLabels(gomock.Len(size)).
+			Return(map[string][]string{}, nil)
+		s.getDB().
+			ListChecklistItems(gomock.Len(size)).
+			Return(map[string][]common.ChecklistItem{}, nil)
+		s.getDB().
+			ListCommentsOfTasks(gomock.Len(size)).
+			Return(map[string][]common.Comment{}, nil)
+		s.getDB().
+			ListRevisionsOfTasks(gomock.Len(size)).
+			Return(map[string][]common.TaskRevision{}, nil)
+	}
+
+	export, err := s.svc.ExportTasks(user.Id)
+	s.Require().NoError(err)
+
+	count := 0
+	for task, err := range export {
+		s.Require().NoError(err)
+		s.Assert().Equal(tasks[count], task.Task)
+		count++
+	}
+	s.Assert().Equal(len(tasks), count)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// feedTokenSize is the number of random bytes of a feed token.
+const feedTokenSize = 32
+
+// CreateFeedToken creates the token a user subscribes to the calendar feed of their tasks with.
+// It replaces the user's earlier token, if any. Only the user may create it.
+func (svc *Service) CreateFeedToken(userId string, actorId string) (*common.FeedToken, error) {
+	if actorId != userId {
+		return nil, fmt.Errorf("%w: only the user can create their feed token", ErrForbidden)
+	}
+
+	user, err := svc.db.GetUser(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	secret := make([]byte, feedTokenSize)
+	if _, err := rand.Read(secret); err != nil {
+		svc.logger.Error("Unable to generate feed token.", zap.Error(err))
+		return nil, err
+	}
+
+	token := &common.FeedToken{
+		UserId:    userId,
+		Token:     base64.RawURLEncoding.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+	if err := svc.db.SetFeedToken(userId, hashFeedToken(token.Token), token.CreatedAt); err != nil {
+		svc.logger.Error("Unable to add feed token.", zap.Error(err))
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// RevokeFeedToken revokes the feed token of a user, cutting off the calendar apps subscribed with it.
+// Only the user may revoke it.
+func (svc *Service) RevokeFeedToken(userId string, actorId string) error {
+	if actorId != userId {
+		return fmt.Errorf("%w: only the user can revoke their feed token", ErrForbidden)
+	}
+
+	if err := svc.db.DeleteFeedToken(userId); err != nil {
+		svc.logger.Error("Unable to delete feed token.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// TaskFeed returns the tasks shown in the calendar feed of a user, once token is checked to be
+// the user's feed token.
+func (svc *Service) TaskFeed(userId string, token string) ([]common.Task, error) {
+	tokenHash, err := svc.db.GetFeedTokenHash(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve feed token.", zap.Error(err))
+		return nil, err
+	}
+	if tokenHash == "" || subtle.ConstantTimeCompare([]byte(tokenHash), []byte(hashFeedToken(token))) != 1 {
+		return nil, ErrInvalidFeedToken
+	}
+
+	// the token outlives a user in the trash, but their feed does not
+	user, err := svc.db.GetUser(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, ErrInvalidFeedToken
+	}
+
+	result, err := svc.ListUserTasks(userId, common.TaskFilter{}, common.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+func hashFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
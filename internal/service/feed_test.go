@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestCreateFeedToken() {
+	errSetToken := errors.New("error inserting feed token")
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+
+	tests := map[string]struct {
+		actorId     string
+		dbUser      *common.User
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			actorId: user.Id,
+			dbUser:  user,
+		},
+		"another user": {
+			actorId:     "00002",
+			expectedErr: ErrForbidden,
+		},
+		"user not found": {
+			actorId:     user.Id,
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			actorId:     user.Id,
+			dbUser:      user,
+			dbError:     errSetToken,
+			expectedErr: errSetToken,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			var storedHash string
+
+			// set up dao mock
+			if test.dbUser != nil {
+				s.getDB().
+					GetUser(user.Id).
+					Return(test.dbUser, nil)
+			}
+			if test.dbUser != nil && test.dbUser.Id != "" {
+				s.getDB().
+					SetFeedToken(user.Id, gomock.Any(), gomock.Any()).
+					Do(func(userId string, tokenHash string, _ any) {
+						storedHash = tokenHash
+					}).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.CreateFeedToken(user.Id, test.actorId)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(user.Id, resp.UserId)
+				s.Assert().Len(resp.Token, 43)
+				// only the hash of the token is stored
+				s.Assert().Equal(hashFeedToken(resp.Token), storedHash)
+				s.Assert().NotEqual(resp.Token, storedHash)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestRevokeFeedToken() {
+	errDeleteToken := errors.New("error deleting feed token")
+
+	tests := map[string]struct {
+		actorId     string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			actorId: "00001",
+		},
+		"another user": {
+			actorId:     "00002",
+			expectedErr: ErrForbidden,
+		},
+		"fail": {
+			actorId:     "00001",
+			dbError:     errDeleteToken,
+			expectedErr: errDeleteToken,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.actorId == "00001" {
+				s.getDB().
+					DeleteFeedToken("00001").
+					Return(test.dbError)
+			}
+
+			err := s.svc.RevokeFeedToken("00001", test.actorId)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestTaskFeed() {
+	errGetToken := errors.New("error retrieving feed token")
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	tasks := []common.Task{{Id: "0001", UserId: user.Id, Description: "description 1", State: common.TaskStateToDo}}
+
+	tests := map[string]struct {
+		token        string
+		dbHash       string
+		dbError      error
+		dbUser       *common.User
+		expectedResp []common.Task
+		expectedErr  error
+	}{
+		"success": {
+			token:        "secret",
+			dbHash:       hashFeedToken("secret"),
+			dbUser:       user,
+			expectedResp: tasks,
+		},
+		"wrong token": {
+			token:       "guess",
+			dbHash:      hashFeedToken("secret"),
+			expectedErr: ErrInvalidFeedToken,
+		},
+		"no token": {
+			dbHash:      hashFeedToken("secret"),
+			expectedErr: ErrInvalidFeedToken,
+		},
+		"revoked token": {
+			token:       "secret",
+			expectedErr: ErrInvalidFeedToken,
+		},
+		"user in the trash": {
+			token:       "secret",
+			dbHash:      hashFeedToken("secret"),
+			dbUser:      &common.User{},
+			expectedErr: ErrInvalidFeedToken,
+		},
+		"fail": {
+			token:       "secret",
+			dbError:     errGetToken,
+			expectedErr: errGetToken,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetFeedTokenHash(user.Id).
+				Return(test.dbHash, test.dbError)
+			if test.dbUser != nil {
+				s.getDB().
+					GetUser(user.Id).
+					Return(test.dbUser, nil)
+			}
+			if test.expectedResp != nil {
+				s.getDB().
+					ListUserTasks(user.Id, common.TaskFilter{}, common.PageRequest{}).
+					Return(&common.TaskPage{Items: append([]common.Task(nil), tasks...)}, nil)
+				s.getDB().
+					ListTaskLabels([]string{"0001"}).
+					Return(map[string][]string{}, nil)
+				s.getDB().
+					ListChecklistItems([]string{"0001"}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+			}
+
+			resp, err := s.svc.TaskFeed(user.Id, test.token)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			s.Assert().Equal(test.expectedResp, resp)
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/todotxt"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxImportSize is the largest file accepted by an import, in bytes.
+	MaxImportSize = 10 << 20
+	// MaxImportTasks is the most rows a file may hold for an import.
+	MaxImportTasks = 1000
+)
+
+// importFields are the task fields read from the columns of a CSV file. Labels hold a
+// comma-separated list of label names and project the name of a project of the user.
+var importFields = []string{"description", "state", "due_at", "remind_at", "recurrence", "labels", "project"}
+
+// importRowErrors are the errors of AddTask caused by the content of a row, which leave out
+// the row rather than fail the whole import.
+var importRowErrors = []error{ErrInvalidState, ErrInvalidParent, ErrInvalidProject, ErrInvalidRecurrence, ErrInvalidLabel}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// importRow is a row of an imported file: the task it holds, or why it could not be read.
+type importRow struct {
+	line int
+	task common.Task
+	// project is the name of the project of the task, created on import when the user has none by that name.
+	project string
+	err     error
+}
+
+// ImportTasks creates tasks for a user from a file in the format of the request. Every row is
+// validated: the valid ones are created in one transaction and the others are reported with
+// their line. A file that cannot be read at all, or holds too many rows, fails the whole import.
+//
+// From todo.txt files, done tasks are imported as such, contexts as labels, priorities as
+// "priority:X" labels, the project as the task project and the due tag as the due time.
+func (svc *Service) ImportTasks(request common.TaskImport, content io.Reader) (*common.ImportResult, error) {
+	user, err := svc.db.GetUser(request.UserId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, MaxImportSize+1))
+	if err != nil {
+		svc.logger.Error("Unable to read import.", zap.Error(err))
+		return nil, err
+	}
+	if len(data) > MaxImportSize {
+		return nil, fmt.Errorf("%w: the file is larger than %d bytes", ErrInvalidImport, MaxImportSize)
+	}
+
+	rows, err := parseImport(request, data)
+	if err != nil {
+		svc.logger.Error("Unable to parse import.", zap.Error(err))
+		return nil, err
+	}
+	if len(rows) > MaxImportTasks {
+		return nil, fmt.Errorf("%w: %d rows, the limit is %d", ErrInvalidImport, len(rows), MaxImportTasks)
+	}
+
+	var result *common.ImportResult
+	err = svc.db.InTx(func(tx db.DBInterface) error {
+		result = &common.ImportResult{
+			DryRun: request.DryRun,
+			Tasks:  make([]common.Task, 0, len(rows)),
+			Errors: make([]common.ImportError, 0),
+		}
+		if err := svc.withDB(tx).importRows(user.Id, rows, result); err != nil {
+			return err
+		}
+
+		// a dry run goes through the very same steps, only to roll them back
+		if request.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		svc.logger.Error("Unable to import tasks.", zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importRows creates the tasks of the valid rows for a user, adding the errors of the others to result.
+func (svc *Service) importRows(userId string, rows []importRow, result *common.ImportResult) error {
+	var projects map[string]string
+	for _, row := range rows {
+		if row.err != nil {
+			result.Errors = append(result.Errors, common.ImportError{Line: row.line, Message: row.err.Error()})
+			continue
+		}
+
+		task := row.task
+		task.UserId = userId
+		if row.project != "" {
+			if projects == nil {
+				userProjects, err := svc.db.ListUserProjects(userId)
+				if err != nil {
+					return err
+				}
+				projects = make(map[string]string, len(userProjects))
+				for _, project := range userProjects {
+					projects[project.Name] = project.Id
+				}
+			}
+
+			projectId, ok := projects[row.project]
+			if !ok {
+				project, err := svc.AddProject(&common.Project{UserId: userId, Name: row.project})
+				if err != nil {
+					return err
+				}
+				projectId = project.Id
+				projects[project.Name] = project.Id
+			}
+			task.ProjectId = &projectId
+		}
+
+		created, err := svc.addTask(&task)
+		if err != nil {
+			if slices.ContainsFunc(importRowErrors, func(target error) bool { return errors.Is(err, target) }) {
+				result.Errors = append(result.Errors, common.ImportError{Line: row.line, Message: err.Error()})
+				continue
+			}
+			return err
+		}
+		result.Tasks = append(result.Tasks, *created)
+	}
+
+	return nil
+}
+
+// parseImport reads the rows of a file in the format of the request.
+func parseImport(request common.TaskImport, data []byte) ([]importRow, error) {
+	var rows []importRow
+	var err error
+	switch request.Format {
+	case common.ImportFormatCSV:
+		rows, err = parseCSVImport(data, request.Mapping)
+	case common.ImportFormatJSON:
+		rows, err = parseJSONImport(data)
+	case common.ImportFormatTodoTxt:
+		rows, err = parseTodoTxtImport(data)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidImport, request.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// rejected before the task is written, unlike by AddTask
+	for i := range rows {
+		if rows[i].err == nil {
+			rows[i].err = validateImportTask(&rows[i].task)
+		}
+	}
+
+	return rows, nil
+}
+
+// parseCSVImport reads the rows of a CSV file, whose first line names its columns.
+func parseCSVImport(data []byte, mapping map[string]string) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: the file has no header", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	named := make(map[string]int, len(header))
+	for i, name := range header {
+		named[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int, len(importFields))
+	for field, column := range mapping {
+		if !slices.Contains(importFields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidImport, field)
+		}
+		i, ok := named[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, fmt.Errorf("%w: no column %q for field %s", ErrInvalidImport, column, field)
+		}
+		columns[field] = i
+	}
+	for _, field := range importFields {
+		if _, ok := columns[field]; !ok {
+			if i, ok := named[field]; ok {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["description"]; !ok {
+		return nil, fmt.Errorf("%w: no column for field description", ErrInvalidImport)
+	}
+
+	rows := make([]importRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+				rows = append(rows, importRow{line: parseErr.StartLine, err: fmt.Errorf("%d fields, the header has %d", len(record), len(header))})
+				continue
+			}
+			return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(field string) string {
+			if i, ok := columns[field]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := importRow{line: line, project: value("project")}
+		row.task = common.Task{
+			Description: value("description"),
+			State:       common.TaskState(value("state")),
+			Recurrence:  value("recurrence"),
+		}
+		if labels := value("labels"); labels != "" {
+			for _, label := range strings.Split(labels, ",") {
+				row.task.Labels = append(row.task.Labels, strings.TrimSpace(label))
+			}
+		}
+		if row.task.DueAt, err = parseImportTime("due_at", value("due_at")); err != nil {
+			row.err = err
+		} else if row.task.RemindAt, err = parseImportTime("remind_at", value("remind_at")); err != nil {
+			row.err = err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseJSONImport reads the rows of a JSON array of tasks.
+func parseJSONImport(data []byte) ([]importRow, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%w: the file is not a JSON array of tasks", ErrInvalidImport)
+	}
+
+	rows := make([]importRow, 0)
+	for decoder.More() {
+		row := importRow{line: lineAt(data, decoder.InputOffset())}
+
+		var task common.Task
+		if err := decoder.Decode(&task); err != nil {
+			// the decoder cannot go past a syntax error, but it can past a value of the wrong type
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidImport, lineAt(data, syntaxErr.Offset-1), err)
+			}
+			row.err = err
+		}
+
+		// only the fields a client sets when adding a task are imported
+		row.task = common.Task{
+			Description: strings.TrimSpace(task.Description),
+			State:       task.State,
+			DueAt:       task.DueAt,
+			RemindAt:    task.RemindAt,
+			ParentId:    task.ParentId,
+			ProjectId:   task.ProjectId,
+			Recurrence:  task.Recurrence,
+			Labels:      task.Labels,
+		}
+		rows = append(rows, row)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	return rows, nil
+}
+
+// parseTodoTxtImport reads the rows of a todo.txt file, one task per line, skipping blank lines.
+func parseTodoTxtImport(data []byte) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, MaxImportSize)
+
+	rows := make([]importRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		row := importRow{line: line}
+		item, err := todotxt.Parse(scanner.Text())
+		if err != nil {
+			row.err = err
+			rows = append(rows, row)
+			continue
+		}
+
+		row.task = common.Task{Description: item.Description, State: common.TaskStateToDo, Labels: item.Contexts}
+		if item.Done {
+			row.task.State = common.TaskStateDone
+		}
+		if item.Priority != "" {
+			row.task.Labels = append(row.task.Labels, "priority:"+item.Priority)
+		}
+		switch len(item.Projects) {
+		case 0:
+		case 1:
+			row.project = item.Projects[0]
+		default:
+			row.err = fmt.Errorf("a task belongs to one project, not %d", len(item.Projects))
+		}
+		if due, ok := item.Tags["due"]; ok && row.err == nil {
+			row.task.DueAt, row.err = parseImportTime("due", due)
+		}
+
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	return rows, nil
+}
+
+// validateImportTask checks the parts of an imported task that AddTask only checks once the task
+// is written, or not at all.
+func validateImportTask(task *common.Task) error {
+	if task.Description == "" {
+		return errors.New("description is required")
+	}
+	for _, label := range task.Labels {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("%w: name is required", ErrInvalidLabel)
+		}
+	}
+	return nil
+}
+
+// parseImportTime parses the value of a time field of an imported task, either an RFC 3339 time
+// or a date. An empty value is no time.
+func parseImportTime(field string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid %s %q", field, value)
+}
+
+// lineAt returns the line of the first value at or after offset in data, skipping the blanks
+// and commas separating values.
+func lineAt(data []byte, offset int64) int {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,", data[offset]) >= 0 {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
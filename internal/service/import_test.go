@@ -0,0 +1,287 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestImportTasks() {
+	errAddTask := errors.New("error inserting task")
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	family := common.Project{Id: "p001", UserId: user.Id, Name: "family"}
+
+	tests := map[string]struct {
+		request        common.TaskImport
+		data           string
+		dbUser         *common.User
+		dbProjects     []common.Project
+		dbError        error
+		expectedTasks  []string
+		expectedErrors []common.ImportError
+		expectedErr    error
+	}{
+		"csv": {
+			request:        common.TaskImport{Format: common.ImportFormatCSV},
+			data:           "description,state\nbuy milk,\n,to_do\nwalk the dog,sleeping\n",
+			dbUser:         user,
+			expectedTasks:  []string{"buy milk"},
+			expectedErrors: []common.ImportError{{Line: 3, Message: "description is required"}, {Line: 4, Message: `invalid task state: "sleeping"`}},
+		},
+		"dry run": {
+			request:        common.TaskImport{Format: common.ImportFormatCSV, DryRun: true},
+			data:           "description\nbuy milk\n",
+			dbUser:         user,
+			expectedTasks:  []string{"buy milk"},
+			expectedErrors: []common.ImportError{},
+		},
+		"todo.txt with a project": {
+			request:        common.TaskImport{Format: common.ImportFormatTodoTxt},
+			data:           "call mom +family\n\ncall dad +family +work\n",
+			dbUser:         user,
+			dbProjects:     []common.Project{family},
+			expectedTasks:  []string{"call mom"},
+			expectedErrors: []common.ImportError{{Line: 3, Message: "a task belongs to one project, not 2"}},
+		},
+		"unreadable file": {
+			request:     common.TaskImport{Format: common.ImportFormatJSON},
+			data:        `{"description": "buy milk"}`,
+			dbUser:      user,
+			expectedErr: ErrInvalidImport,
+		},
+		"user not found": {
+			request:     common.TaskImport{Format: common.ImportFormatCSV},
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			request:     common.TaskImport{Format: common.ImportFormatCSV},
+			data:        "description\nbuy milk\n",
+			dbUser:      user,
+			dbError:     errAddTask,
+			expectedErr: errAddTask,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			test.request.UserId = user.Id
+
+			// set up dao mock
+			s.getDB().
+				GetUser(user.Id).
+				Return(test.dbUser, nil)
+			if test.expectedTasks != nil || test.dbError != nil {
+				s.getDB().
+					InTx(gomock.Any()).
+					DoAndReturn(func(fn func(tx db.DBInterface) error) error {
+						return fn(s.svc.db)
+					})
+				s.getDB().
+					LastTaskPosition(user.Id).
+					Return("", nil)
+				s.getDB().
+					AddTask(gomock.Any()).
+					Return(test.dbError)
+			}
+			if test.dbProjects != nil {
+				s.getDB().
+					ListUserProjects(user.Id).
+					Return(test.dbProjects, nil)
+				s.getDB().
+					GetProject(family.Id).
+					Return(&family, nil)
+			}
+
+			resp, err := s.svc.ImportTasks(test.request, strings.NewReader(test.data))
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.request.DryRun, resp.DryRun)
+				s.Assert().Equal(test.expectedErrors, resp.Errors)
+				s.Require().Len(resp.Tasks, len(test.expectedTasks))
+				for i, description := range test.expectedTasks {
+					s.Assert().Equal(description, resp.Tasks[i].Description)
+					s.Assert().Equal(user.Id, resp.Tasks[i].UserId)
+				}
+				if test.dbProjects != nil {
+					s.Assert().Equal(&family.Id, resp.Tasks[0].ProjectId)
+				}
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestImportTasksNewProject() {
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	var projectId string
+
+	// set up dao mock
+	s.getDB().
+		GetUser(user.Id).
+		Return(user, nil)
+	s.getDB().
+		InTx(gomock.Any()).
+		DoAndReturn(func(fn func(tx db.DBInterface) error) error {
+			return fn(s.svc.db)
+		})
+	s.getDB().
+		ListUserProjects(user.Id).
+		Return([]common.Project{}, nil)
+	// the project is created once, for both tasks
+	s.getDB().
+		AddProject(gomock.Any()).
+		Do(func(project *common.Project) {
+			s.Assert().Equal(common.Project{Id: project.Id, UserId: user.Id, Name: "home"}, *project)
+			projectId = project.Id
+		}).
+		Return(nil)
+	s.getDB().
+		GetProject(gomock.Any()).
+		DoAndReturn(func(id string) (*common.Project, error) {
+			return &common.Project{Id: id, UserId: user.Id, Name: "home"}, nil
+		}).
+		Times(2)
+	s.getDB().
+		LastTaskPosition(user.Id).
+		Return("", nil).
+		Times(2)
+	s.getDB().
+		AddTask(gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	resp, err := s.svc.ImportTasks(common.TaskImport{UserId: user.Id, Format: common.ImportFormatTodoTxt}, strings.NewReader("pay the rent +home\nfix the sink +home\n"))
+	s.Require().NoError(err)
+	s.Require().Len(resp.Tasks, 2)
+	s.Assert().Equal(projectId, *resp.Tasks[0].ProjectId)
+	s.Assert().Equal(projectId, *resp.Tasks[1].ProjectId)
+}
+
+// parsedRow is an importRow with its error as text, for comparisons.
+type parsedRow struct {
+	line    int
+	task    common.Task
+	project string
+	err     string
+}
+
+func parsedRows(rows []importRow) []parsedRow {
+	parsed := make([]parsedRow, len(rows))
+	for i, row := range rows {
+		parsed[i] = parsedRow{line: row.line, task: row.task, project: row.project}
+		if row.err != nil {
+			parsed[i].err = row.err.Error()
+		}
+	}
+	return parsed
+}
+
+func (s *svcTestSuite) TestParseImport() {
+	due := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	remind := time.Date(2026, time.January, 30, 9, 0, 0, 0, time.UTC)
+	parentId := "0001"
+
+	tests := map[string]struct {
+		request      common.TaskImport
+		data         string
+		expectedRows []parsedRow
+		expectedErr  string
+	}{
+		"csv": {
+			request: common.TaskImport{Format: common.ImportFormatCSV},
+			data: "Description,State,Due_At,Remind_At,Labels,Project,Notes\n" +
+				"buy milk,in_progress,2026-01-31,2026-01-30T09:00:00Z,\"home, errands\",house,fresh\n" +
+				"\"walk\nthe dog\",,,,,,\n" +
+				"pay,,tomorrow,,,,\n" +
+				"too,few\n" +
+				"ok,,,,\" , \",,\n",
+			expectedRows: []parsedRow{
+				{line: 2, task: common.Task{Description: "buy milk", State: common.TaskStateInProgress, DueAt: &due, RemindAt: &remind, Labels: []string{"home", "errands"}}, project: "house"},
+				{line: 3, task: common.Task{Description: "walk\nthe dog"}},
+				{line: 5, task: common.Task{Description: "pay"}, err: `invalid due_at "tomorrow"`},
+				{line: 6, err: "2 fields, the header has 7"},
+				{line: 7, task: common.Task{Description: "ok", Labels: []string{"", ""}}, err: "invalid label: name is required"},
+			},
+		},
+		"csv with a mapping": {
+			request: common.TaskImport{Format: common.ImportFormatCSV, Mapping: map[string]string{"description": "Title", "due_at": "deadline"}},
+			data:    "title,Deadline,description\nbuy milk,2026-01-31,ignored\n",
+			expectedRows: []parsedRow{
+				{line: 2, task: common.Task{Description: "buy milk", DueAt: &due}},
+			},
+		},
+		"csv without a description": {
+			request:     common.TaskImport{Format: common.ImportFormatCSV},
+			data:        "title\nbuy milk\n",
+			expectedErr: "invalid import: no column for field description",
+		},
+		"csv mapped to an unknown column": {
+			request:     common.TaskImport{Format: common.ImportFormatCSV, Mapping: map[string]string{"description": "Title"}},
+			data:        "description\nbuy milk\n",
+			expectedErr: `invalid import: no column "Title" for field description`,
+		},
+		"csv mapping an unknown field": {
+			request:     common.TaskImport{Format: common.ImportFormatCSV, Mapping: map[string]string{"priority": "Priority"}},
+			data:        "description,priority\nbuy milk,high\n",
+			expectedErr: `invalid import: unknown field "priority"`,
+		},
+		"empty csv": {
+			request:     common.TaskImport{Format: common.ImportFormatCSV},
+			expectedErr: "invalid import: the file has no header",
+		},
+		"json": {
+			request: common.TaskImport{Format: common.ImportFormatJSON},
+			data: "[\n" +
+				"  {\"id\": \"0009\", \"description\": \"buy milk\", \"due_at\": \"2026-01-31T00:00:00Z\", \"parent_id\": \"0001\", \"position\": \"a\"},\n" +
+				"  {\"description\": 3},\n" +
+				"  {\n    \"description\": \"\"\n  }\n" +
+				"]",
+			expectedRows: []parsedRow{
+				{line: 2, task: common.Task{Description: "buy milk", DueAt: &due, ParentId: &parentId}},
+				{line: 3, err: "json: cannot unmarshal number into Go struct field Task.description of type string"},
+				{line: 4, err: "description is required"},
+			},
+		},
+		"json syntax error": {
+			request:     common.TaskImport{Format: common.ImportFormatJSON},
+			data:        "[\n  {\"description\": \"buy milk\"},\n  {\"description\" \"walk the dog\"}\n]",
+			expectedErr: "invalid import: line 3: invalid character '\"' after object key",
+		},
+		"json object": {
+			request:     common.TaskImport{Format: common.ImportFormatJSON},
+			data:        `{"description": "buy milk"}`,
+			expectedErr: "invalid import: the file is not a JSON array of tasks",
+		},
+		"todo.txt": {
+			request: common.TaskImport{Format: common.ImportFormatTodoTxt},
+			data:    "(A) call mom +family @phone @home due:2026-01-31\n\nx 2026-01-11 2026-01-10 pay the rent\n+home\nfix sink due:someday\n",
+			expectedRows: []parsedRow{
+				{line: 1, task: common.Task{Description: "call mom", State: common.TaskStateToDo, DueAt: &due, Labels: []string{"phone", "home", "priority:A"}}, project: "family"},
+				{line: 3, task: common.Task{Description: "pay the rent", State: common.TaskStateDone}},
+				{line: 4, err: "invalid todo.txt line: no description"},
+				{line: 5, task: common.Task{Description: "fix sink", State: common.TaskStateToDo}, err: `invalid due "someday"`},
+			},
+		},
+		"unknown format": {
+			request:     common.TaskImport{Format: "xlsx"},
+			expectedErr: `invalid import: unknown format "xlsx"`,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			rows, err := parseImport(test.request, []byte(test.data))
+			if test.expectedErr != "" {
+				s.Assert().ErrorIs(err, ErrInvalidImport)
+				s.Assert().EqualError(err, test.expectedErr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Assert().Equal(test.expectedRows, parsedRows(rows))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (svc *Service) AddLabel(label *common.Label) (*common.Label, error) {
+	label.Id = uuid.New().String()
+	label.Name = strings.TrimSpace(label.Name)
+
+	if err := svc.validateLabel(label); err != nil {
+		svc.logger.Error("Unable add label.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.db.AddLabel(label); err != nil {
+		svc.logger.Error("Unable add label.", zap.Error(err))
+		return nil, labelError(label, err)
+	}
+
+	return label, nil
+}
+
+// UpdateLabel renames a label of a user.
+func (svc *Service) UpdateLabel(label *common.Label) (*common.Label, error) {
+	if _, err := svc.GetLabel(label.UserId, label.Id); err != nil {
+		return nil, err
+	}
+
+	label.Name = strings.TrimSpace(label.Name)
+	if err := svc.validateLabel(label); err != nil {
+		svc.logger.Error("Unable update label.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.db.UpdateLabel(label); err != nil {
+		svc.logger.Error("Unable update label.", zap.Error(err))
+		return nil, labelError(label, err)
+	}
+
+	return label, nil
+}
+
+// GetLabel returns a label of a user, reporting labels of other users as not found.
+func (svc *Service) GetLabel(userId string, id string) (*common.Label, error) {
+	label, err := svc.db.GetLabel(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve label.", zap.Error(err))
+		return nil, err
+	}
+	if label.Id == "" || label.UserId != userId {
+		return nil, fmt.Errorf("label %w", ErrNotFound)
+	}
+
+	return label, nil
+}
+
+// DeleteLabel deletes a label of a user, detaching it from every task.
+func (svc *Service) DeleteLabel(userId string, id string) error {
+	if _, err := svc.GetLabel(userId, id); err != nil {
+		return err
+	}
+
+	if err := svc.db.DeleteLabel(id); err != nil {
+		svc.logger.Error("Unable to delete label.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (svc *Service) ListUserLabels(userId string) ([]common.Label, error) {
+	labels, err := svc.db.ListUserLabels(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user labels.", zap.Error(err))
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// validateLabel checks that a label has a name not used by another label of the same user.
+func (svc *Service) validateLabel(label *common.Label) error {
+	if label.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidLabel)
+	}
+
+	labels, err := svc.db.ListUserLabels(label.UserId)
+	if err != nil {
+		return err
+	}
+	for _, existing := range labels {
+		if existing.Name == label.Name && existing.Id != label.Id {
+			return fmt.Errorf("%w: %q", ErrLabelExists, label.Name)
+		}
+	}
+
+	return nil
+}
+
+// labelError reports a name taken by a concurrent request, which validateLabel could not see, as
+// the same ErrLabelExists.
+func labelError(label *common.Label, err error) error {
+	if errors.Is(err, db.ErrLabelNameTaken) {
+		return fmt.Errorf("%w: %q", ErrLabelExists, label.Name)
+	}
+	return err
+}
+
+// setTaskLabels attaches the labels named by a task to it, creating the labels the user does not have yet.
+func (svc *Service) setTaskLabels(task *common.Task) error {
+	names := make([]string, 0, len(task.Labels))
+	for _, name := range task.Labels {
+		if name = strings.TrimSpace(name); name == "" {
+			return fmt.Errorf("%w: name is required", ErrInvalidLabel)
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	task.Labels = slices.Compact(names)
+
+	ids := make([]string, 0, len(task.Labels))
+	if len(task.Labels) > 0 {
+		labels, err := svc.db.ListUserLabels(task.UserId)
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[string]string, len(labels))
+		for _, label := range labels {
+			existing[label.Name] = label.Id
+		}
+
+		for _, name := range task.Labels {
+			id, ok := existing[name]
+			if !ok {
+				label := &common.Label{Id: uuid.New().String(), UserId: task.UserId, Name: name}
+				if err := svc.db.AddLabel(label); err != nil {
+					return err
+				}
+				id = label.Id
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	return svc.db.SetTaskLabels(task.Id, ids)
+}
+
+// loadLabels fills in the labels of the given tasks.
+func (svc *Service) loadLabels(tasks []common.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.Id
+	}
+
+	labels, err := svc.db.ListTaskLabels(ids)
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		tasks[i].Labels = labels[tasks[i].Id]
+	}
+
+	return nil
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestAddLabel() {
+	errAddLabel := errors.New("error inserting label")
+	existing := []common.Label{{Id: "0001", UserId: "00001", Name: "home"}}
+
+	tests := map[string]struct {
+		name        string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			name: " work ",
+		},
+		"empty name": {
+			name:        "  ",
+			expectedErr: ErrInvalidLabel,
+		},
+		"duplicated name": {
+			name:        "home",
+			expectedErr: ErrLabelExists,
+		},
+		"name taken concurrently": {
+			name:        "work",
+			dbError:     db.ErrLabelNameTaken,
+			expectedErr: ErrLabelExists,
+		},
+		"fail": {
+			name:        "work",
+			dbError:     errAddLabel,
+			expectedErr: errAddLabel,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			label := &common.Label{UserId: "00001", Name: test.name}
+
+			// set up dao mock
+			if test.expectedErr != ErrInvalidLabel {
+				s.getDB().
+					ListUserLabels(label.UserId).
+					Return(existing, nil)
+			}
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					AddLabel(label).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.AddLabel(label)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().NotEmpty(resp.Id)
+				s.Assert().Equal("work", resp.Name)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestUpdateLabel() {
+	errUpdateLabel := errors.New("error updating label")
+	current := &common.Label{Id: "0001", UserId: "00001", Name: "home"}
+
+	tests := map[string]struct {
+		userId      string
+		name        string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			userId: "00001",
+			name:   "house",
+		},
+		"same name": {
+			userId: "00001",
+			name:   "home",
+		},
+		"other user": {
+			userId:      "00002",
+			name:        "house",
+			expectedErr: ErrNotFound,
+		},
+		"name taken concurrently": {
+			userId:      "00001",
+			name:        "house",
+			dbError:     db.ErrLabelNameTaken,
+			expectedErr: ErrLabelExists,
+		},
+		"fail": {
+			userId:      "00001",
+			name:        "house",
+			dbError:     errUpdateLabel,
+			expectedErr: errUpdateLabel,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			label := &common.Label{Id: current.Id, UserId: test.userId, Name: test.name}
+
+			// set up dao mock
+			s.getDB().
+				GetLabel(label.Id).
+				Return(current, nil)
+
+			if test.expectedErr != ErrNotFound {
+				s.getDB().
+					ListUserLabels(label.UserId).
+					Return([]common.Label{*current}, nil)
+				s.getDB().
+					UpdateLabel(label).
+					Return(test.dbError)
+			}
+
+			_, err := s.svc.UpdateLabel(label)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestGetLabel() {
+	errGetLabel := errors.New("any error")
+	label := &common.Label{Id: "0001", UserId: "00001", Name: "home"}
+
+	tests := map[string]struct {
+		userId       string
+		dbLabel      *common.Label
+		dbError      error
+		expectedResp *common.Label
+		expectedErr  error
+	}{
+		"success": {
+			userId:       "00001",
+			dbLabel:      label,
+			expectedResp: label,
+		},
+		"not found": {
+			userId:      "00001",
+			dbLabel:     &common.Label{},
+			expectedErr: ErrNotFound,
+		},
+		"other user": {
+			userId:      "00002",
+			dbLabel:     label,
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			userId:      "00001",
+			dbError:     errGetLabel,
+			expectedErr: errGetLabel,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetLabel(label.Id).
+				Return(test.dbLabel, test.dbError)
+
+			resp, err := s.svc.GetLabel(test.userId, label.Id)
+			s.Assert().Equal(test.expectedResp, resp)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestDeleteLabel() {
+	errDeleteLabel := errors.New("error deleting label")
+	label := &common.Label{Id: "0001", UserId: "00001", Name: "home"}
+
+	tests := map[string]struct {
+		userId      string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			userId: "00001",
+		},
+		"other user": {
+			userId:      "00002",
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			userId:      "00001",
+			dbError:     errDeleteLabel,
+			expectedErr: errDeleteLabel,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetLabel(label.Id).
+				Return(label, nil)
+
+			if test.expectedErr != ErrNotFound {
+				s.getDB().
+					DeleteLabel(label.Id).
+					Return(test.dbError)
+			}
+
+			err := s.svc.DeleteLabel(test.userId, label.Id)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestListUserLabels() {
+	errListLabels := errors.New("any error")
+	labels := []common.Label{
+		{Id: "0001", UserId: "00001", Name: "home"},
+		{Id: "0002", UserId: "00001", Name: "work"},
+	}
+
+	tests := map[string]struct {
+		dbLabels     []common.Label
+		dbError      error
+		expectedResp []common.Label
+		expectedErr  error
+	}{
+		"success": {
+			dbLabels:     labels,
+			expectedResp: labels,
+		},
+		"fail": {
+			dbError:     errListLabels,
+			expectedErr: errListLabels,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				ListUserLabels("00001").
+				Return(test.dbLabels, test.dbError)
+
+			resp, err := s.svc.ListUserLabels("00001")
+			s.Assert().Equal(test.expectedResp, resp)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestAddTaskWithLabels() {
+	task := &common.Task{
+		UserId:      "00001",
+		Description: "description 1",
+		Labels:      []string{"work", " home", "work"},
+	}
+
+	// set up dao mock
+	s.getDB().
+		LastTaskPosition(task.UserId).
+		Return("", nil)
+	s.getDB().
+		AddTask(task).
+		Return(nil)
+	s.getDB().
+		ListUserLabels(task.UserId).
+		Return([]common.Label{{Id: "0001", UserId: task.UserId, Name: "home"}}, nil)
+
+	var created *common.Label
+	s.getDB().
+		AddLabel(gomock.Any()).
+		Do(func(label *common.Label) { created = label }).
+		Return(nil)
+	s.getDB().
+		SetTaskLabels(gomock.Any(), gomock.Any()).
+		Do(func(taskId string, labelIds []string) {
+			s.Assert().Equal(task.Id, taskId)
+			s.Assert().Equal([]string{"0001", created.Id}, labelIds)
+		}).
+		Return(nil)
+
+	s.expectTx()
+	resp, err := s.svc.AddTask(task)
+	s.Assert().NoError(err)
+	s.Assert().Equal([]string{"home", "work"}, resp.Labels)
+	s.Assert().Equal("work", created.Name)
+}
+
+func (s *svcTestSuite) TestUpdateTaskLabels() {
+	tests := map[string]struct {
+		labels        []string
+		expectedCalls bool
+	}{
+		"keep labels": {
+			labels: nil,
+		},
+		"clear labels": {
+			labels:        []string{},
+			expectedCalls: true,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			task := &common.Task{
+				Id:          "0001",
+				UserId:      "00001",
+				Description: "description 1",
+				State:       common.TaskStateToDo,
+				Labels:      test.labels,
+			}
+
+			// set up dao mock
+			s.getDB().
+				GetTask(task.Id).
+				Return(&common.Task{Id: task.Id, UserId: task.UserId, State: common.TaskStateToDo}, nil)
+			s.getDB().
+				UpdateTask(task).
+				Return(nil)
+			s.getDB().
+				AddTaskRevision(gomock.Any()).
+				Return(nil)
+
+			if test.expectedCalls {
+				s.getDB().
+					SetTaskLabels(task.Id, []string{}).
+					Return(nil)
+			}
+
+			s.expectTx()
+			_, err := s.svc.UpdateTask(task, "00001", false)
+			s.Assert().NoError(err)
+		})
+	}
+}
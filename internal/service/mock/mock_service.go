@@ -5,9 +5,12 @@
 package mock_service
 
 import (
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	common "github.com/aborgesrodrigues/to-do-api/internal/common"
+	service "github.com/aborgesrodrigues/to-do-api/internal/service"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,6 +37,81 @@ func (m *MockSVCInterface) EXPECT() *MockSVCInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddAttachment mocks base method.
+func (m *MockSVCInterface) AddAttachment(attachment *common.Attachment, content io.Reader) (*common.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", attachment, content)
+	ret0, _ := ret[0].(*common.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockSVCInterfaceMockRecorder) AddAttachment(attachment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockSVCInterface)(nil).AddAttachment), attachment, content)
+}
+
+// AddChecklistItem mocks base method.
+func (m *MockSVCInterface) AddChecklistItem(item *common.ChecklistItem) (*common.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", item)
+	ret0, _ := ret[0].(*common.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockSVCInterfaceMockRecorder) AddChecklistItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockSVCInterface)(nil).AddChecklistItem), item)
+}
+
+// AddComment mocks base method.
+func (m *MockSVCInterface) AddComment(comment *common.Comment) (*common.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", comment)
+	ret0, _ := ret[0].(*common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockSVCInterfaceMockRecorder) AddComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockSVCInterface)(nil).AddComment), comment)
+}
+
+// AddLabel mocks base method.
+func (m *MockSVCInterface) AddLabel(label *common.Label) (*common.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLabel", label)
+	ret0, _ := ret[0].(*common.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLabel indicates an expected call of AddLabel.
+func (mr *MockSVCInterfaceMockRecorder) AddLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLabel", reflect.TypeOf((*MockSVCInterface)(nil).AddLabel), label)
+}
+
+// AddProject mocks base method.
+func (m *MockSVCInterface) AddProject(project *common.Project) (*common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProject", project)
+	ret0, _ := ret[0].(*common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProject indicates an expected call of AddProject.
+func (mr *MockSVCInterfaceMockRecorder) AddProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProject", reflect.TypeOf((*MockSVCInterface)(nil).AddProject), project)
+}
+
 // AddTask mocks base method.
 func (m *MockSVCInterface) AddTask(task *common.Task) (*common.Task, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +127,35 @@ func (mr *MockSVCInterfaceMockRecorder) AddTask(task interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockSVCInterface)(nil).AddTask), task)
 }
 
+// AddTaskDependency mocks base method.
+func (m *MockSVCInterface) AddTaskDependency(dependency *common.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskDependency", dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskDependency indicates an expected call of AddTaskDependency.
+func (mr *MockSVCInterfaceMockRecorder) AddTaskDependency(dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskDependency", reflect.TypeOf((*MockSVCInterface)(nil).AddTaskDependency), dependency)
+}
+
+// AddTemplate mocks base method.
+func (m *MockSVCInterface) AddTemplate(template *common.Template) (*common.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTemplate", template)
+	ret0, _ := ret[0].(*common.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTemplate indicates an expected call of AddTemplate.
+func (mr *MockSVCInterfaceMockRecorder) AddTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTemplate", reflect.TypeOf((*MockSVCInterface)(nil).AddTemplate), template)
+}
+
 // AddUser mocks base method.
 func (m *MockSVCInterface) AddUser(user *common.User) (*common.User, error) {
 	m.ctrl.T.Helper()
@@ -64,18 +171,161 @@ func (mr *MockSVCInterfaceMockRecorder) AddUser(user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockSVCInterface)(nil).AddUser), user)
 }
 
+// BatchTasks mocks base method.
+func (m *MockSVCInterface) BatchTasks(batch common.TaskBatch, actorId string) ([]common.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchTasks", batch, actorId)
+	ret0, _ := ret[0].([]common.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchTasks indicates an expected call of BatchTasks.
+func (mr *MockSVCInterfaceMockRecorder) BatchTasks(batch, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchTasks", reflect.TypeOf((*MockSVCInterface)(nil).BatchTasks), batch, actorId)
+}
+
+// Board mocks base method.
+func (m *MockSVCInterface) Board(userId string, filter common.TaskFilter) (*common.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Board", userId, filter)
+	ret0, _ := ret[0].(*common.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Board indicates an expected call of Board.
+func (mr *MockSVCInterfaceMockRecorder) Board(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Board", reflect.TypeOf((*MockSVCInterface)(nil).Board), userId, filter)
+}
+
+// CreateFeedToken mocks base method.
+func (m *MockSVCInterface) CreateFeedToken(userId, actorId string) (*common.FeedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeedToken", userId, actorId)
+	ret0, _ := ret[0].(*common.FeedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeedToken indicates an expected call of CreateFeedToken.
+func (mr *MockSVCInterfaceMockRecorder) CreateFeedToken(userId, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeedToken", reflect.TypeOf((*MockSVCInterface)(nil).CreateFeedToken), userId, actorId)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockSVCInterface) DeleteAttachment(taskId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", taskId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockSVCInterfaceMockRecorder) DeleteAttachment(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockSVCInterface)(nil).DeleteAttachment), taskId, id)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockSVCInterface) DeleteChecklistItem(taskId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", taskId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockSVCInterfaceMockRecorder) DeleteChecklistItem(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockSVCInterface)(nil).DeleteChecklistItem), taskId, id)
+}
+
+// DeleteComment mocks base method.
+func (m *MockSVCInterface) DeleteComment(taskId, id, authorId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", taskId, id, authorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockSVCInterfaceMockRecorder) DeleteComment(taskId, id, authorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockSVCInterface)(nil).DeleteComment), taskId, id, authorId)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockSVCInterface) DeleteLabel(userId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockSVCInterfaceMockRecorder) DeleteLabel(userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockSVCInterface)(nil).DeleteLabel), userId, id)
+}
+
+// DeleteProject mocks base method.
+func (m *MockSVCInterface) DeleteProject(id string, deletion common.ProjectDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", id, deletion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockSVCInterfaceMockRecorder) DeleteProject(id, deletion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockSVCInterface)(nil).DeleteProject), id, deletion)
+}
+
 // DeleteTask mocks base method.
-func (m *MockSVCInterface) DeleteTask(id string) error {
+func (m *MockSVCInterface) DeleteTask(id string, cascade bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", id)
+	ret := m.ctrl.Call(m, "DeleteTask", id, cascade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockSVCInterfaceMockRecorder) DeleteTask(id interface{}) *gomock.Call {
+func (mr *MockSVCInterfaceMockRecorder) DeleteTask(id, cascade interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockSVCInterface)(nil).DeleteTask), id, cascade)
+}
+
+// DeleteTaskDependency mocks base method.
+func (m *MockSVCInterface) DeleteTaskDependency(dependency *common.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskDependency", dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskDependency indicates an expected call of DeleteTaskDependency.
+func (mr *MockSVCInterfaceMockRecorder) DeleteTaskDependency(dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskDependency", reflect.TypeOf((*MockSVCInterface)(nil).DeleteTaskDependency), dependency)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockSVCInterface) DeleteTemplate(id, actorId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", id, actorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockSVCInterfaceMockRecorder) DeleteTemplate(id, actorId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockSVCInterface)(nil).DeleteTask), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockSVCInterface)(nil).DeleteTemplate), id, actorId)
 }
 
 // DeleteUser mocks base method.
@@ -92,6 +342,96 @@ func (mr *MockSVCInterfaceMockRecorder) DeleteUser(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockSVCInterface)(nil).DeleteUser), id)
 }
 
+// DownloadAttachment mocks base method.
+func (m *MockSVCInterface) DownloadAttachment(taskId, id string) (*common.AttachmentDownload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadAttachment", taskId, id)
+	ret0, _ := ret[0].(*common.AttachmentDownload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAttachment indicates an expected call of DownloadAttachment.
+func (mr *MockSVCInterfaceMockRecorder) DownloadAttachment(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAttachment", reflect.TypeOf((*MockSVCInterface)(nil).DownloadAttachment), taskId, id)
+}
+
+// ExportTasks mocks base method.
+func (m *MockSVCInterface) ExportTasks(userId string) (service.ExportIter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTasks", userId)
+	ret0, _ := ret[0].(service.ExportIter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockSVCInterfaceMockRecorder) ExportTasks(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockSVCInterface)(nil).ExportTasks), userId)
+}
+
+// GetAttachment mocks base method.
+func (m *MockSVCInterface) GetAttachment(taskId, id string) (*common.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", taskId, id)
+	ret0, _ := ret[0].(*common.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockSVCInterfaceMockRecorder) GetAttachment(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockSVCInterface)(nil).GetAttachment), taskId, id)
+}
+
+// GetComment mocks base method.
+func (m *MockSVCInterface) GetComment(taskId, id string) (*common.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", taskId, id)
+	ret0, _ := ret[0].(*common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockSVCInterfaceMockRecorder) GetComment(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockSVCInterface)(nil).GetComment), taskId, id)
+}
+
+// GetLabel mocks base method.
+func (m *MockSVCInterface) GetLabel(userId, id string) (*common.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabel", userId, id)
+	ret0, _ := ret[0].(*common.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabel indicates an expected call of GetLabel.
+func (mr *MockSVCInterfaceMockRecorder) GetLabel(userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabel", reflect.TypeOf((*MockSVCInterface)(nil).GetLabel), userId, id)
+}
+
+// GetProject mocks base method.
+func (m *MockSVCInterface) GetProject(id string) (*common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", id)
+	ret0, _ := ret[0].(*common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockSVCInterfaceMockRecorder) GetProject(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockSVCInterface)(nil).GetProject), id)
+}
+
 // GetTask mocks base method.
 func (m *MockSVCInterface) GetTask(id string) (*common.Task, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +447,21 @@ func (mr *MockSVCInterfaceMockRecorder) GetTask(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockSVCInterface)(nil).GetTask), id)
 }
 
+// GetTemplate mocks base method.
+func (m *MockSVCInterface) GetTemplate(id, actorId string) (*common.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", id, actorId)
+	ret0, _ := ret[0].(*common.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockSVCInterfaceMockRecorder) GetTemplate(id, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockSVCInterface)(nil).GetTemplate), id, actorId)
+}
+
 // GetUser mocks base method.
 func (m *MockSVCInterface) GetUser(id string) (*common.User, error) {
 	m.ctrl.T.Helper()
@@ -122,64 +477,558 @@ func (mr *MockSVCInterfaceMockRecorder) GetUser(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockSVCInterface)(nil).GetUser), id)
 }
 
-// ListTasks mocks base method.
-func (m *MockSVCInterface) ListTasks() ([]common.Task, error) {
+// ImportTasks mocks base method.
+func (m *MockSVCInterface) ImportTasks(request common.TaskImport, content io.Reader) (*common.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", request, content)
+	ret0, _ := ret[0].(*common.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockSVCInterfaceMockRecorder) ImportTasks(request, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockSVCInterface)(nil).ImportTasks), request, content)
+}
+
+// InstantiateTemplate mocks base method.
+func (m *MockSVCInterface) InstantiateTemplate(id string, instantiation common.TemplateInstantiation, actorId string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstantiateTemplate", id, instantiation, actorId)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstantiateTemplate indicates an expected call of InstantiateTemplate.
+func (mr *MockSVCInterfaceMockRecorder) InstantiateTemplate(id, instantiation, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantiateTemplate", reflect.TypeOf((*MockSVCInterface)(nil).InstantiateTemplate), id, instantiation, actorId)
+}
+
+// ListProjectTasks mocks base method.
+func (m *MockSVCInterface) ListProjectTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectTasks", id, filter, page)
+	ret0, _ := ret[0].(*common.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjectTasks indicates an expected call of ListProjectTasks.
+func (mr *MockSVCInterfaceMockRecorder) ListProjectTasks(id, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectTasks", reflect.TypeOf((*MockSVCInterface)(nil).ListProjectTasks), id, filter, page)
+}
+
+// ListProjects mocks base method.
+func (m *MockSVCInterface) ListProjects() ([]common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects")
+	ret0, _ := ret[0].([]common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockSVCInterfaceMockRecorder) ListProjects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockSVCInterface)(nil).ListProjects))
+}
+
+// ListSubtasks mocks base method.
+func (m *MockSVCInterface) ListSubtasks(id string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubtasks", id)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubtasks indicates an expected call of ListSubtasks.
+func (mr *MockSVCInterfaceMockRecorder) ListSubtasks(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubtasks", reflect.TypeOf((*MockSVCInterface)(nil).ListSubtasks), id)
+}
+
+// ListTaskAttachments mocks base method.
+func (m *MockSVCInterface) ListTaskAttachments(taskId string) ([]common.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAttachments", taskId)
+	ret0, _ := ret[0].([]common.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAttachments indicates an expected call of ListTaskAttachments.
+func (mr *MockSVCInterfaceMockRecorder) ListTaskAttachments(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAttachments", reflect.TypeOf((*MockSVCInterface)(nil).ListTaskAttachments), taskId)
+}
+
+// ListTaskComments mocks base method.
+func (m *MockSVCInterface) ListTaskComments(taskId string) ([]common.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTasks")
+	ret := m.ctrl.Call(m, "ListTaskComments", taskId)
+	ret0, _ := ret[0].([]common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskComments indicates an expected call of ListTaskComments.
+func (mr *MockSVCInterfaceMockRecorder) ListTaskComments(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskComments", reflect.TypeOf((*MockSVCInterface)(nil).ListTaskComments), taskId)
+}
+
+// ListTaskDependencies mocks base method.
+func (m *MockSVCInterface) ListTaskDependencies(id string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskDependencies", id)
 	ret0, _ := ret[0].([]common.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListTaskDependencies indicates an expected call of ListTaskDependencies.
+func (mr *MockSVCInterfaceMockRecorder) ListTaskDependencies(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskDependencies", reflect.TypeOf((*MockSVCInterface)(nil).ListTaskDependencies), id)
+}
+
+// ListTaskRevisions mocks base method.
+func (m *MockSVCInterface) ListTaskRevisions(taskId string) ([]common.TaskRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskRevisions", taskId)
+	ret0, _ := ret[0].([]common.TaskRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskRevisions indicates an expected call of ListTaskRevisions.
+func (mr *MockSVCInterfaceMockRecorder) ListTaskRevisions(taskId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskRevisions", reflect.TypeOf((*MockSVCInterface)(nil).ListTaskRevisions), taskId)
+}
+
+// ListTasks mocks base method.
+func (m *MockSVCInterface) ListTasks(filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", filter, page)
+	ret0, _ := ret[0].(*common.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // ListTasks indicates an expected call of ListTasks.
-func (mr *MockSVCInterfaceMockRecorder) ListTasks() *gomock.Call {
+func (mr *MockSVCInterfaceMockRecorder) ListTasks(filter, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockSVCInterface)(nil).ListTasks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockSVCInterface)(nil).ListTasks), filter, page)
+}
+
+// ListTemplates mocks base method.
+func (m *MockSVCInterface) ListTemplates(userId string) ([]common.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", userId)
+	ret0, _ := ret[0].([]common.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockSVCInterfaceMockRecorder) ListTemplates(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockSVCInterface)(nil).ListTemplates), userId)
+}
+
+// ListTrash mocks base method.
+func (m *MockSVCInterface) ListTrash(userId string) (*common.Trash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", userId)
+	ret0, _ := ret[0].(*common.Trash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockSVCInterfaceMockRecorder) ListTrash(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockSVCInterface)(nil).ListTrash), userId)
+}
+
+// ListUserLabels mocks base method.
+func (m *MockSVCInterface) ListUserLabels(userId string) ([]common.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLabels", userId)
+	ret0, _ := ret[0].([]common.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLabels indicates an expected call of ListUserLabels.
+func (mr *MockSVCInterfaceMockRecorder) ListUserLabels(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLabels", reflect.TypeOf((*MockSVCInterface)(nil).ListUserLabels), userId)
+}
+
+// ListUserProjects mocks base method.
+func (m *MockSVCInterface) ListUserProjects(userId string) ([]common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserProjects", userId)
+	ret0, _ := ret[0].([]common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserProjects indicates an expected call of ListUserProjects.
+func (mr *MockSVCInterfaceMockRecorder) ListUserProjects(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProjects", reflect.TypeOf((*MockSVCInterface)(nil).ListUserProjects), userId)
 }
 
 // ListUserTasks mocks base method.
-func (m *MockSVCInterface) ListUserTasks(id string) ([]common.Task, error) {
+func (m *MockSVCInterface) ListUserTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserTasks", id)
-	ret0, _ := ret[0].([]common.Task)
+	ret := m.ctrl.Call(m, "ListUserTasks", id, filter, page)
+	ret0, _ := ret[0].(*common.TaskPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUserTasks indicates an expected call of ListUserTasks.
-func (mr *MockSVCInterfaceMockRecorder) ListUserTasks(id interface{}) *gomock.Call {
+func (mr *MockSVCInterfaceMockRecorder) ListUserTasks(id, filter, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTasks", reflect.TypeOf((*MockSVCInterface)(nil).ListUserTasks), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTasks", reflect.TypeOf((*MockSVCInterface)(nil).ListUserTasks), id, filter, page)
 }
 
 // ListUsers mocks base method.
-func (m *MockSVCInterface) ListUsers() ([]common.User, error) {
+func (m *MockSVCInterface) ListUsers(page common.PageRequest) (*common.UserPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers")
-	ret0, _ := ret[0].([]common.User)
+	ret := m.ctrl.Call(m, "ListUsers", page)
+	ret0, _ := ret[0].(*common.UserPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockSVCInterfaceMockRecorder) ListUsers() *gomock.Call {
+func (mr *MockSVCInterfaceMockRecorder) ListUsers(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockSVCInterface)(nil).ListUsers), page)
+}
+
+// MoveChecklistItem mocks base method.
+func (m *MockSVCInterface) MoveChecklistItem(taskId, id string, move common.Move) (*common.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveChecklistItem", taskId, id, move)
+	ret0, _ := ret[0].(*common.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveChecklistItem indicates an expected call of MoveChecklistItem.
+func (mr *MockSVCInterfaceMockRecorder) MoveChecklistItem(taskId, id, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveChecklistItem", reflect.TypeOf((*MockSVCInterface)(nil).MoveChecklistItem), taskId, id, move)
+}
+
+// MoveTask mocks base method.
+func (m *MockSVCInterface) MoveTask(id string, move common.Move) (*common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", id, move)
+	ret0, _ := ret[0].(*common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockSVCInterfaceMockRecorder) MoveTask(id, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockSVCInterface)(nil).MoveTask), id, move)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockSVCInterface) PurgeTrash(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockSVCInterfaceMockRecorder) PurgeTrash(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockSVCInterface)(nil).PurgeTrash), before)
+}
+
+// RestoreTask mocks base method.
+func (m *MockSVCInterface) RestoreTask(id, actorId string) (*common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", id, actorId)
+	ret0, _ := ret[0].(*common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockSVCInterfaceMockRecorder) RestoreTask(id, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockSVCInterface)(nil).RestoreTask), id, actorId)
+}
+
+// RestoreUser mocks base method.
+func (m *MockSVCInterface) RestoreUser(id, actorId string) (*common.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", id, actorId)
+	ret0, _ := ret[0].(*common.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockSVCInterfaceMockRecorder) RestoreUser(id, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockSVCInterface)(nil).RestoreUser), id, actorId)
+}
+
+// RevertTask mocks base method.
+func (m *MockSVCInterface) RevertTask(id string, revision int, actorId string) (*common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertTask", id, revision, actorId)
+	ret0, _ := ret[0].(*common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertTask indicates an expected call of RevertTask.
+func (mr *MockSVCInterfaceMockRecorder) RevertTask(id, revision, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTask", reflect.TypeOf((*MockSVCInterface)(nil).RevertTask), id, revision, actorId)
+}
+
+// RevokeFeedToken mocks base method.
+func (m *MockSVCInterface) RevokeFeedToken(userId, actorId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFeedToken", userId, actorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFeedToken indicates an expected call of RevokeFeedToken.
+func (mr *MockSVCInterfaceMockRecorder) RevokeFeedToken(userId, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFeedToken", reflect.TypeOf((*MockSVCInterface)(nil).RevokeFeedToken), userId, actorId)
+}
+
+// SearchTasks mocks base method.
+func (m *MockSVCInterface) SearchTasks(search common.TaskSearch) ([]common.TaskSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", search)
+	ret0, _ := ret[0].([]common.TaskSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockSVCInterfaceMockRecorder) SearchTasks(search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockSVCInterface)(nil).SearchTasks), search)
+}
+
+// SendDueReminders mocks base method.
+func (m *MockSVCInterface) SendDueReminders(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDueReminders", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDueReminders indicates an expected call of SendDueReminders.
+func (mr *MockSVCInterfaceMockRecorder) SendDueReminders(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDueReminders", reflect.TypeOf((*MockSVCInterface)(nil).SendDueReminders), now)
+}
+
+// SetWIPLimits mocks base method.
+func (m *MockSVCInterface) SetWIPLimits(userId string, limits common.WIPLimits) (common.WIPLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWIPLimits", userId, limits)
+	ret0, _ := ret[0].(common.WIPLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWIPLimits indicates an expected call of SetWIPLimits.
+func (mr *MockSVCInterfaceMockRecorder) SetWIPLimits(userId, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWIPLimits", reflect.TypeOf((*MockSVCInterface)(nil).SetWIPLimits), userId, limits)
+}
+
+// SnoozeTask mocks base method.
+func (m *MockSVCInterface) SnoozeTask(id string, snooze common.Snooze) (*common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeTask", id, snooze)
+	ret0, _ := ret[0].(*common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeTask indicates an expected call of SnoozeTask.
+func (mr *MockSVCInterfaceMockRecorder) SnoozeTask(id, snooze interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeTask", reflect.TypeOf((*MockSVCInterface)(nil).SnoozeTask), id, snooze)
+}
+
+// StartTimer mocks base method.
+func (m *MockSVCInterface) StartTimer(taskId, userId string) (*common.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", taskId, userId)
+	ret0, _ := ret[0].(*common.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockSVCInterfaceMockRecorder) StartTimer(taskId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockSVCInterface)(nil).ListUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockSVCInterface)(nil).StartTimer), taskId, userId)
+}
+
+// StopTimer mocks base method.
+func (m *MockSVCInterface) StopTimer(taskId, userId string) (*common.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", taskId, userId)
+	ret0, _ := ret[0].(*common.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockSVCInterfaceMockRecorder) StopTimer(taskId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockSVCInterface)(nil).StopTimer), taskId, userId)
+}
+
+// TaskFeed mocks base method.
+func (m *MockSVCInterface) TaskFeed(userId, token string) ([]common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskFeed", userId, token)
+	ret0, _ := ret[0].([]common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaskFeed indicates an expected call of TaskFeed.
+func (mr *MockSVCInterfaceMockRecorder) TaskFeed(userId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskFeed", reflect.TypeOf((*MockSVCInterface)(nil).TaskFeed), userId, token)
+}
+
+// Timesheet mocks base method.
+func (m *MockSVCInterface) Timesheet(userId string, from, to time.Time) (*common.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timesheet", userId, from, to)
+	ret0, _ := ret[0].(*common.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timesheet indicates an expected call of Timesheet.
+func (mr *MockSVCInterfaceMockRecorder) Timesheet(userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timesheet", reflect.TypeOf((*MockSVCInterface)(nil).Timesheet), userId, from, to)
+}
+
+// ToggleChecklistItem mocks base method.
+func (m *MockSVCInterface) ToggleChecklistItem(taskId, id string) (*common.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistItem", taskId, id)
+	ret0, _ := ret[0].(*common.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleChecklistItem indicates an expected call of ToggleChecklistItem.
+func (mr *MockSVCInterfaceMockRecorder) ToggleChecklistItem(taskId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistItem", reflect.TypeOf((*MockSVCInterface)(nil).ToggleChecklistItem), taskId, id)
+}
+
+// UnsnoozeTask mocks base method.
+func (m *MockSVCInterface) UnsnoozeTask(id string) (*common.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsnoozeTask", id)
+	ret0, _ := ret[0].(*common.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsnoozeTask indicates an expected call of UnsnoozeTask.
+func (mr *MockSVCInterfaceMockRecorder) UnsnoozeTask(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeTask", reflect.TypeOf((*MockSVCInterface)(nil).UnsnoozeTask), id)
+}
+
+// UpdateComment mocks base method.
+func (m *MockSVCInterface) UpdateComment(comment *common.Comment) (*common.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", comment)
+	ret0, _ := ret[0].(*common.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockSVCInterfaceMockRecorder) UpdateComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockSVCInterface)(nil).UpdateComment), comment)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockSVCInterface) UpdateLabel(label *common.Label) (*common.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", label)
+	ret0, _ := ret[0].(*common.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockSVCInterfaceMockRecorder) UpdateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockSVCInterface)(nil).UpdateLabel), label)
+}
+
+// UpdateProject mocks base method.
+func (m *MockSVCInterface) UpdateProject(project *common.Project) (*common.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(*common.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockSVCInterfaceMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockSVCInterface)(nil).UpdateProject), project)
 }
 
 // UpdateTask mocks base method.
-func (m *MockSVCInterface) UpdateTask(task *common.Task) (*common.Task, error) {
+func (m *MockSVCInterface) UpdateTask(task *common.Task, actorId string, force bool) (*common.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", task)
+	ret := m.ctrl.Call(m, "UpdateTask", task, actorId, force)
 	ret0, _ := ret[0].(*common.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockSVCInterfaceMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+func (mr *MockSVCInterfaceMockRecorder) UpdateTask(task, actorId, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockSVCInterface)(nil).UpdateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockSVCInterface)(nil).UpdateTask), task, actorId, force)
 }
 
 // UpdateUser mocks base method.
@@ -196,3 +1045,18 @@ func (mr *MockSVCInterfaceMockRecorder) UpdateUser(user interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockSVCInterface)(nil).UpdateUser), user)
 }
+
+// WakeSnoozedTasks mocks base method.
+func (m *MockSVCInterface) WakeSnoozedTasks(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WakeSnoozedTasks", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WakeSnoozedTasks indicates an expected call of WakeSnoozedTasks.
+func (mr *MockSVCInterfaceMockRecorder) WakeSnoozedTasks(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WakeSnoozedTasks", reflect.TypeOf((*MockSVCInterface)(nil).WakeSnoozedTasks), now)
+}
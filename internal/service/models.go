@@ -1,31 +1,126 @@
 package service
 
 import (
+	"io"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/blob"
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
 	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/events"
 	"go.uber.org/zap"
 )
 
 type SVCInterface interface {
 	AddTask(task *common.Task) (*common.Task, error)
-	UpdateTask(task *common.Task) (*common.Task, error)
+	UpdateTask(task *common.Task, actorId string, force bool) (*common.Task, error)
 	GetTask(id string) (*common.Task, error)
-	DeleteTask(id string) error
-	ListTasks() ([]common.Task, error)
-	ListUserTasks(id string) ([]common.Task, error)
+	DeleteTask(id string, cascade bool) error
+	ListTasks(filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error)
+	ListUserTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error)
+	SearchTasks(search common.TaskSearch) ([]common.TaskSearchResult, error)
+	ListSubtasks(id string) ([]common.Task, error)
+	SendDueReminders(now time.Time) (int, error)
+	ListTaskRevisions(taskId string) ([]common.TaskRevision, error)
+	RevertTask(id string, revision int, actorId string) (*common.Task, error)
+	BatchTasks(batch common.TaskBatch, actorId string) ([]common.BatchResult, error)
+	MoveTask(id string, move common.Move) (*common.Task, error)
+	SnoozeTask(id string, snooze common.Snooze) (*common.Task, error)
+	UnsnoozeTask(id string) (*common.Task, error)
+	WakeSnoozedTasks(now time.Time) (int, error)
+
+	AddTaskDependency(dependency *common.TaskDependency) error
+	DeleteTaskDependency(dependency *common.TaskDependency) error
+	ListTaskDependencies(id string) ([]common.Task, error)
+
+	AddProject(project *common.Project) (*common.Project, error)
+	UpdateProject(project *common.Project) (*common.Project, error)
+	GetProject(id string) (*common.Project, error)
+	DeleteProject(id string, deletion common.ProjectDeletion) error
+	ListProjects() ([]common.Project, error)
+	ListUserProjects(userId string) ([]common.Project, error)
+	ListProjectTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error)
+
+	AddLabel(label *common.Label) (*common.Label, error)
+	UpdateLabel(label *common.Label) (*common.Label, error)
+	GetLabel(userId string, id string) (*common.Label, error)
+	DeleteLabel(userId string, id string) error
+	ListUserLabels(userId string) ([]common.Label, error)
+
+	AddComment(comment *common.Comment) (*common.Comment, error)
+	UpdateComment(comment *common.Comment) (*common.Comment, error)
+	GetComment(taskId string, id string) (*common.Comment, error)
+	DeleteComment(taskId string, id string, authorId string) error
+	ListTaskComments(taskId string) ([]common.Comment, error)
+
+	AddAttachment(attachment *common.Attachment, content io.Reader) (*common.Attachment, error)
+	GetAttachment(taskId string, id string) (*common.Attachment, error)
+	DownloadAttachment(taskId string, id string) (*common.AttachmentDownload, error)
+	DeleteAttachment(taskId string, id string) error
+	ListTaskAttachments(taskId string) ([]common.Attachment, error)
+
+	StartTimer(taskId string, userId string) (*common.TimeEntry, error)
+	StopTimer(taskId string, userId string) (*common.TimeEntry, error)
+	Timesheet(userId string, from time.Time, to time.Time) (*common.Timesheet, error)
+
+	AddChecklistItem(item *common.ChecklistItem) (*common.ChecklistItem, error)
+	ToggleChecklistItem(taskId string, id string) (*common.ChecklistItem, error)
+	MoveChecklistItem(taskId string, id string, move common.Move) (*common.ChecklistItem, error)
+	DeleteChecklistItem(taskId string, id string) error
+
+	AddTemplate(template *common.Template) (*common.Template, error)
+	GetTemplate(id string, actorId string) (*common.Template, error)
+	DeleteTemplate(id string, actorId string) error
+	ListTemplates(userId string) ([]common.Template, error)
+	InstantiateTemplate(id string, instantiation common.TemplateInstantiation, actorId string) ([]common.Task, error)
+
+	Board(userId string, filter common.TaskFilter) (*common.Board, error)
+	SetWIPLimits(userId string, limits common.WIPLimits) (common.WIPLimits, error)
+
+	ImportTasks(request common.TaskImport, content io.Reader) (*common.ImportResult, error)
+	ExportTasks(userId string) (ExportIter, error)
+
+	CreateFeedToken(userId string, actorId string) (*common.FeedToken, error)
+	RevokeFeedToken(userId string, actorId string) error
+	TaskFeed(userId string, token string) ([]common.Task, error)
 
 	AddUser(user *common.User) (*common.User, error)
 	UpdateUser(user *common.User) (*common.User, error)
 	GetUser(id string) (*common.User, error)
 	DeleteUser(id string) error
-	ListUsers() ([]common.User, error)
+	ListUsers(page common.PageRequest) (*common.UserPage, error)
+
+	ListTrash(userId string) (*common.Trash, error)
+	RestoreTask(id string, actorId string) (*common.Task, error)
+	RestoreUser(id string, actorId string) (*common.User, error)
+	PurgeTrash(before time.Time) (int, error)
 }
 
 type Config struct {
 	Logger *zap.Logger
+	// Emitter receives the task events produced by the service. Defaults to a zap emitter.
+	Emitter events.Emitter
+	// Transitions is the task state machine enforced on every update. Defaults to DefaultTransitions.
+	Transitions Transitions
+	// Attachments configures where and how the files uploaded to tasks are stored.
+	Attachments AttachmentConfig
+}
+
+// AttachmentConfig configures the storage of task attachments.
+type AttachmentConfig struct {
+	// Store keeps the content of the attachments. Defaults to a filesystem store in the temporary directory.
+	Store blob.Store
+	// MaxSize is the largest accepted attachment, in bytes. Defaults to DefaultMaxAttachmentSize.
+	MaxSize int64
+	// URLExpiry is how long the download URLs handed out by stores implementing blob.Presigner
+	// stay valid. Defaults to DefaultAttachmentURLExpiry.
+	URLExpiry time.Duration
 }
 
 type Service struct {
-	logger *zap.Logger
-	db     db.DBInterface
+	logger      *zap.Logger
+	db          db.DBInterface
+	emitter     events.Emitter
+	transitions Transitions
+	attachments AttachmentConfig
 }
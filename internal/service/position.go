@@ -0,0 +1,167 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/rank"
+	"go.uber.org/zap"
+)
+
+// MoveTask places a task of a user right before or right after another task of the same user. The
+// positions are read and saved, rebalance included, in one transaction that locks the tasks of the
+// user, so concurrent moves neither get the same key nor interleave with a rebalance.
+func (svc *Service) MoveTask(id string, move common.Move) (*common.Task, error) {
+	anchorId, err := moveAnchor(id, move)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved *common.Task
+	err = svc.db.InTx(func(tx db.DBInterface) error {
+		var err error
+		moved, err = svc.withDB(tx).moveTask(id, anchorId, move)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// moveTask runs a move of a task on the database of the service, a transaction of MoveTask.
+func (svc *Service) moveTask(id string, anchorId string, move common.Move) (*common.Task, error) {
+	task, err := svc.db.GetTask(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	positions, err := svc.db.ListTaskPositions(task.UserId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task positions.", zap.Error(err))
+		return nil, err
+	}
+
+	key, ok, err := placeItem(positions, id, move, svc.db.SetTaskPositions)
+	if err != nil {
+		svc.logger.Error("Unable to move task.", zap.Error(err))
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: task %s is not a task of the same user", ErrInvalidMove, anchorId)
+	}
+	task.Position = key
+
+	return task, nil
+}
+
+// moveAnchor checks that a move has exactly one anchor, other than the moved item, and returns it.
+func moveAnchor(id string, move common.Move) (string, error) {
+	if (move.Before == "") == (move.After == "") {
+		return "", fmt.Errorf("%w: exactly one of before and after is required", ErrInvalidMove)
+	}
+	anchorId := move.Before + move.After
+	if anchorId == id {
+		return "", fmt.Errorf("%w: an item cannot be moved next to itself", ErrInvalidMove)
+	}
+
+	return anchorId, nil
+}
+
+// placeItem gives the item id of an ordered list a position right before or right after the anchor
+// of a move, saving it with save, and returns the new position. Only the moved item gets a new
+// position, unless the positions around its new place leave no short enough key, in which case the
+// whole list is given fresh positions. ok is false when the anchor is not in the list.
+func placeItem(positions []common.Position, id string, move common.Move, save func([]common.Position) error) (key string, ok bool, err error) {
+	anchorId := move.Before + move.After
+	positions = slices.DeleteFunc(positions, func(position common.Position) bool {
+		return position.Id == id
+	})
+
+	at := slices.IndexFunc(positions, func(position common.Position) bool {
+		return position.Id == anchorId
+	})
+	if at < 0 {
+		return "", false, nil
+	}
+	if move.After != "" {
+		at++
+	}
+
+	if key, ok := positionAt(positions, at); ok {
+		return key, true, save([]common.Position{{Id: id, Position: key}})
+	}
+
+	positions = slices.Insert(positions, at, common.Position{Id: id})
+	spread(positions)
+	return positions[at].Position, true, save(positions)
+}
+
+// positionAt returns a key for an item inserted at index i of a list of positions. ok is false
+// when the list must be rebalanced instead: the keys around i are the same, the new key would be
+// too long, or a neighbour has no position, as the tasks added before positions existed.
+func positionAt(positions []common.Position, i int) (key string, ok bool) {
+	before, after := "", ""
+	if i > 0 {
+		if before = positions[i-1].Position; before == "" {
+			return "", false
+		}
+	}
+	if i < len(positions) {
+		if after = positions[i].Position; after == "" {
+			return "", false
+		}
+	}
+
+	key, ok = rank.Between(before, after)
+	return key, ok && len(key) <= rank.MaxLength
+}
+
+// setLastPosition gives a new task a position after the other tasks of its user.
+func (svc *Service) setLastPosition(task *common.Task) error {
+	last, err := svc.db.LastTaskPosition(task.UserId)
+	if err != nil {
+		return err
+	}
+
+	task.Position, err = appendItem(last, task.Id, func() ([]common.Position, error) {
+		return svc.db.ListTaskPositions(task.UserId)
+	}, svc.db.SetTaskPositions)
+	return err
+}
+
+// appendItem returns the position of a new item added after the last position of an ordered
+// list. When that position would be too long, the list, given by list, is rebalanced first and
+// saved with save; the new item, not saved yet, only gets its position.
+func appendItem(last string, id string, list func() ([]common.Position, error), save func([]common.Position) error) (string, error) {
+	if key := rank.After(last); len(key) <= rank.MaxLength {
+		return key, nil
+	}
+
+	positions, err := list()
+	if err != nil {
+		return "", err
+	}
+	positions = append(positions, common.Position{Id: id})
+	spread(positions)
+	if err := save(positions); err != nil {
+		return "", err
+	}
+
+	return positions[len(positions)-1].Position, nil
+}
+
+// spread gives fresh, evenly spaced positions to a list of items, keeping their order.
+func spread(positions []common.Position) {
+	keys := rank.Spread(len(positions))
+	for i := range positions {
+		positions[i].Position = keys[i]
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/rank"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestMoveTask() {
+	errSetPositions := errors.New("error updating task positions")
+	positions := []common.Position{{Id: "0001", Position: "a"}, {Id: "0002", Position: "c"}, {Id: "0003", Position: "e"}}
+	ties := []common.Position{{Id: "0001", Position: "a"}, {Id: "0002", Position: "a"}, {Id: "0003", Position: "b"}}
+	unpositioned := []common.Position{{Id: "0001"}, {Id: "0002"}, {Id: "0003"}}
+	spread := rank.Spread(3)
+
+	tests := map[string]struct {
+		id                string
+		move              common.Move
+		dbTask            *common.Task
+		dbPositions       []common.Position
+		dbError           error
+		expectedPositions []common.Position
+		expectedPosition  string
+		expectedErr       error
+	}{
+		"before": {
+			id:                "0003",
+			move:              common.Move{Before: "0002"},
+			dbTask:            &common.Task{Id: "0003", UserId: "00001"},
+			dbPositions:       positions,
+			expectedPositions: []common.Position{{Id: "0003", Position: "b"}},
+			expectedPosition:  "b",
+		},
+		"after the last task": {
+			id:                "0001",
+			move:              common.Move{After: "0003"},
+			dbTask:            &common.Task{Id: "0001", UserId: "00001"},
+			dbPositions:       positions,
+			expectedPositions: []common.Position{{Id: "0001", Position: "p"}},
+			expectedPosition:  "p",
+		},
+		"same positions": {
+			id:                "0003",
+			move:              common.Move{Before: "0002"},
+			dbTask:            &common.Task{Id: "0003", UserId: "00001"},
+			dbPositions:       ties,
+			expectedPositions: []common.Position{{Id: "0001", Position: spread[0]}, {Id: "0003", Position: spread[1]}, {Id: "0002", Position: spread[2]}},
+			expectedPosition:  spread[1],
+		},
+		"no positions": {
+			id:                "0001",
+			move:              common.Move{After: "0002"},
+			dbTask:            &common.Task{Id: "0001", UserId: "00001"},
+			dbPositions:       unpositioned,
+			expectedPositions: []common.Position{{Id: "0002", Position: spread[0]}, {Id: "0001", Position: spread[1]}, {Id: "0003", Position: spread[2]}},
+			expectedPosition:  spread[1],
+		},
+		"no anchor": {
+			id:          "0001",
+			expectedErr: ErrInvalidMove,
+		},
+		"two anchors": {
+			id:          "0001",
+			move:        common.Move{Before: "0002", After: "0003"},
+			expectedErr: ErrInvalidMove,
+		},
+		"next to itself": {
+			id:          "0001",
+			move:        common.Move{Before: "0001"},
+			expectedErr: ErrInvalidMove,
+		},
+		"anchor of another user": {
+			id:          "0001",
+			move:        common.Move{Before: "0004"},
+			dbTask:      &common.Task{Id: "0001", UserId: "00001"},
+			dbPositions: positions,
+			expectedErr: ErrInvalidMove,
+		},
+		"not found": {
+			id:          "0001",
+			move:        common.Move{Before: "0002"},
+			dbTask:      &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			id:                "0003",
+			move:              common.Move{Before: "0002"},
+			dbTask:            &common.Task{Id: "0003", UserId: "00001"},
+			dbPositions:       positions,
+			dbError:           errSetPositions,
+			expectedPositions: []common.Position{{Id: "0003", Position: "b"}},
+			expectedErr:       errSetPositions,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbTask != nil {
+				s.expectTx()
+				s.getDB().
+					GetTask(test.id).
+					Return(test.dbTask, nil)
+			}
+			if test.dbPositions != nil {
+				// the service works on its own copy of the list
+				dbPositions := append([]common.Position(nil), test.dbPositions...)
+				s.getDB().
+					ListTaskPositions("00001").
+					Return(dbPositions, nil)
+			}
+			if test.expectedPositions != nil {
+				s.getDB().
+					SetTaskPositions(gomock.Any()).
+					Do(func(positions []common.Position) {
+						s.Assert().Equal(test.expectedPositions, positions)
+					}).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.MoveTask(test.id, test.move)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedPosition, resp.Position)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestAddTaskRebalancesPositions() {
+	task := &common.Task{UserId: "00001", Description: "description 1"}
+	last := strings.Repeat("z", rank.MaxLength)
+	spread := rank.Spread(2)
+
+	// set up dao mock
+	s.getDB().
+		LastTaskPosition(task.UserId).
+		Return(last, nil)
+	s.getDB().
+		ListTaskPositions(task.UserId).
+		Return([]common.Position{{Id: "0001", Position: last}}, nil)
+	s.getDB().
+		SetTaskPositions(gomock.Any()).
+		Do(func(positions []common.Position) {
+			s.Assert().Equal([]common.Position{{Id: "0001", Position: spread[0]}, {Id: task.Id, Position: spread[1]}}, positions)
+		}).
+		Return(nil)
+	s.getDB().
+		AddTask(task).
+		Return(nil)
+
+	s.expectTx()
+	resp, err := s.svc.AddTask(task)
+	s.Assert().NoError(err)
+	s.Assert().Equal(spread[1], resp.Position)
+}
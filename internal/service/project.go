@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (svc *Service) AddProject(project *common.Project) (*common.Project, error) {
+	project.Id = uuid.New().String()
+
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		err := fmt.Errorf("%w: name is required", ErrInvalidProject)
+		svc.logger.Error("Unable add project.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.db.AddProject(project); err != nil {
+		svc.logger.Error("Unable add project.", zap.Error(err))
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// UpdateProject renames a project. Projects cannot change hands, so the user is kept.
+func (svc *Service) UpdateProject(project *common.Project) (*common.Project, error) {
+	current, err := svc.GetProject(project.Id)
+	if err != nil {
+		return nil, err
+	}
+	project.UserId = current.UserId
+
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		err := fmt.Errorf("%w: name is required", ErrInvalidProject)
+		svc.logger.Error("Unable update project.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.db.UpdateProject(project); err != nil {
+		svc.logger.Error("Unable update project.", zap.Error(err))
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (svc *Service) GetProject(id string) (*common.Project, error) {
+	project, err := svc.db.GetProject(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve project.", zap.Error(err))
+		return nil, err
+	}
+	if project.Id == "" {
+		return nil, fmt.Errorf("project %w", ErrNotFound)
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes a project, archiving its tasks or moving them to another project of the same user.
+func (svc *Service) DeleteProject(id string, deletion common.ProjectDeletion) error {
+	project, err := svc.GetProject(id)
+	if err != nil {
+		return err
+	}
+
+	if !deletion.Archive && deletion.MoveTo != nil {
+		if *deletion.MoveTo == id {
+			err := fmt.Errorf("%w: tasks cannot be moved to the deleted project", ErrInvalidProject)
+			svc.logger.Error("Unable to delete project.", zap.Error(err))
+			return err
+		}
+
+		if err := svc.validateProject(project.UserId, deletion.MoveTo); err != nil {
+			svc.logger.Error("Unable to delete project.", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := svc.db.DeleteProject(id, deletion); err != nil {
+		svc.logger.Error("Unable to delete project.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (svc *Service) ListProjects() ([]common.Project, error) {
+	projects, err := svc.db.ListProjects()
+	if err != nil {
+		svc.logger.Error("Unable to retrieve projects.", zap.Error(err))
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (svc *Service) ListUserProjects(userId string) ([]common.Project, error) {
+	projects, err := svc.db.ListUserProjects(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user projects.", zap.Error(err))
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// ListProjectTasks returns a page of the tasks of a project matching the filter.
+func (svc *Service) ListProjectTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	if _, err := svc.GetProject(id); err != nil {
+		return nil, err
+	}
+
+	filter.ProjectId = id
+	return svc.ListTasks(filter, page)
+}
+
+// validateProject checks that a project exists and belongs to the given user.
+func (svc *Service) validateProject(userId string, projectId *string) error {
+	if projectId == nil {
+		return nil
+	}
+
+	project, err := svc.db.GetProject(*projectId)
+	if err != nil {
+		return err
+	}
+	if project.Id == "" {
+		return fmt.Errorf("%w: project %s not found", ErrInvalidProject, *projectId)
+	}
+	if project.UserId != userId {
+		return fmt.Errorf("%w: project %s belongs to another user", ErrInvalidProject, project.Id)
+	}
+
+	return nil
+}
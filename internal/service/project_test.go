@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (s *svcTestSuite) TestAddProject() {
+	errAddProject := errors.New("error inserting project")
+
+	tests := map[string]struct {
+		name        string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			name: " house ",
+		},
+		"empty name": {
+			name:        "  ",
+			expectedErr: ErrInvalidProject,
+		},
+		"fail": {
+			name:        "house",
+			dbError:     errAddProject,
+			expectedErr: errAddProject,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			project := &common.Project{UserId: "00001", Name: test.name}
+
+			// set up dao mock
+			if test.expectedErr != ErrInvalidProject {
+				s.getDB().
+					AddProject(project).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.AddProject(project)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().NotEmpty(resp.Id)
+				s.Assert().Equal("house", resp.Name)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestUpdateProject() {
+	errUpdateProject := errors.New("error updating project")
+	current := &common.Project{Id: "0001", UserId: "00001", Name: "house"}
+
+	tests := map[string]struct {
+		current     *common.Project
+		name        string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			current: current,
+			name:    "garden",
+		},
+		"not found": {
+			current:     &common.Project{},
+			name:        "garden",
+			expectedErr: ErrNotFound,
+		},
+		"empty name": {
+			current:     current,
+			name:        "",
+			expectedErr: ErrInvalidProject,
+		},
+		"fail": {
+			current:     current,
+			name:        "garden",
+			dbError:     errUpdateProject,
+			expectedErr: errUpdateProject,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// the user cannot be changed
+			project := &common.Project{Id: "0001", UserId: "00002", Name: test.name}
+
+			// set up dao mock
+			s.getDB().
+				GetProject(project.Id).
+				Return(test.current, nil)
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					UpdateProject(&common.Project{Id: "0001", UserId: "00001", Name: test.name}).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.UpdateProject(project)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal("00001", resp.UserId)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestDeleteProject() {
+	errDeleteProject := errors.New("error deleting project")
+	project := &common.Project{Id: "0001", UserId: "00001", Name: "house"}
+	otherUser := "0003"
+	same := "0001"
+	target := "0002"
+
+	tests := map[string]struct {
+		deletion    common.ProjectDeletion
+		target      *common.Project
+		dbError     error
+		expectedErr error
+	}{
+		"archive": {
+			deletion: common.ProjectDeletion{Archive: true},
+		},
+		"move": {
+			deletion: common.ProjectDeletion{MoveTo: &target},
+			target:   &common.Project{Id: target, UserId: "00001", Name: "garden"},
+		},
+		"move out of projects": {
+			deletion: common.ProjectDeletion{},
+		},
+		"move to itself": {
+			deletion:    common.ProjectDeletion{MoveTo: &same},
+			expectedErr: ErrInvalidProject,
+		},
+		"move to another user": {
+			deletion:    common.ProjectDeletion{MoveTo: &otherUser},
+			target:      &common.Project{Id: otherUser, UserId: "00002", Name: "garden"},
+			expectedErr: ErrInvalidProject,
+		},
+		"fail": {
+			deletion:    common.ProjectDeletion{Archive: true},
+			dbError:     errDeleteProject,
+			expectedErr: errDeleteProject,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetProject(project.Id).
+				Return(project, nil)
+			if test.target != nil {
+				s.getDB().
+					GetProject(test.target.Id).
+					Return(test.target, nil)
+			}
+			if test.expectedErr == nil || test.dbError != nil {
+				s.getDB().
+					DeleteProject(project.Id, test.deletion).
+					Return(test.dbError)
+			}
+
+			err := s.svc.DeleteProject(project.Id, test.deletion)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestListProjectTasks() {
+	tasks := []common.Task{
+		{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do"},
+	}
+
+	tests := map[string]struct {
+		project      *common.Project
+		expectedResp *common.TaskPage
+		expectedErr  error
+	}{
+		"success": {
+			project:      &common.Project{Id: "0001", UserId: "00001", Name: "house"},
+			expectedResp: &common.TaskPage{Items: tasks},
+		},
+		"not found": {
+			project:     &common.Project{},
+			expectedErr: ErrNotFound,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetProject("0001").
+				Return(test.project, nil)
+			if test.expectedErr == nil {
+				s.getDB().
+					ListTasks(common.TaskFilter{ProjectId: "0001", Ready: true}, common.PageRequest{Limit: 10}).
+					Return(&common.TaskPage{Items: tasks}, nil)
+				s.getDB().
+					ListTaskLabels([]string{"0001"}).
+					Return(map[string][]string{}, nil)
+				s.getDB().
+					ListChecklistItems([]string{"0001"}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+			}
+
+			resp, err := s.svc.ListProjectTasks("0001", common.TaskFilter{Ready: true}, common.PageRequest{Limit: 10})
+			s.Assert().ErrorIs(err, test.expectedErr)
+			s.Assert().Equal(test.expectedResp, resp)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/rrule"
+	"github.com/google/uuid"
+)
+
+// validateRecurrence checks that the recurrence rule of a task, if any, can be evaluated.
+func validateRecurrence(task *common.Task) error {
+	if task.Recurrence == "" {
+		return nil
+	}
+
+	if _, err := rrule.Parse(task.Recurrence); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+
+	return nil
+}
+
+// nextOccurrence builds the task following a recurring task that has just been completed, or
+// returns nil when the task does not recur or its rule has no further occurrence.
+//
+// The next due date follows the current one, or the completion time for tasks without a due
+// date, and the reminder keeps its offset to the due date. A COUNT in the rule holds the number
+// of occurrences left, so it is decremented on every new occurrence.
+func nextOccurrence(task *common.Task) (*common.Task, error) {
+	if task.Recurrence == "" {
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(task.Recurrence)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+
+	remaining := rule.Count
+	if remaining == 1 {
+		return nil, nil
+	}
+
+	start := *task.CompletedAt
+	if task.DueAt != nil {
+		start = *task.DueAt
+	}
+
+	rule.Count = 0
+	dueAt, ok := rule.Next(start, start)
+	if !ok {
+		return nil, nil
+	}
+
+	recurrence := task.Recurrence
+	if remaining > 0 {
+		rule.Count = remaining - 1
+		recurrence = rule.String()
+	}
+
+	next := &common.Task{
+		Id:          uuid.New().String(),
+		UserId:      task.UserId,
+		Description: task.Description,
+		State:       common.TaskStateToDo,
+		DueAt:       &dueAt,
+		ParentId:    task.ParentId,
+		ProjectId:   task.ProjectId,
+		Recurrence:  recurrence,
+	}
+	if task.DueAt != nil && task.RemindAt != nil {
+		remindAt := dueAt.Add(task.RemindAt.Sub(*task.DueAt))
+		next.RemindAt = &remindAt
+	}
+
+	return next, nil
+}
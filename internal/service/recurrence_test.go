@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	parentId := "0000"
+	dueAt := time.Date(2026, time.January, 9, 9, 0, 0, 0, time.UTC) // Friday
+	remindAt := dueAt.Add(-time.Hour)
+	completedAt := time.Date(2026, time.January, 8, 18, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		task               common.Task
+		expectedNext       bool
+		expectedDueAt      time.Time
+		expectedRemindAt   *time.Time
+		expectedRecurrence string
+		expectedErr        error
+	}{
+		"every weekday": {
+			task:               common.Task{DueAt: &dueAt, RemindAt: &remindAt, Recurrence: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+			expectedNext:       true,
+			expectedDueAt:      time.Date(2026, time.January, 12, 9, 0, 0, 0, time.UTC),
+			expectedRemindAt:   func() *time.Time { t := time.Date(2026, time.January, 12, 8, 0, 0, 0, time.UTC); return &t }(),
+			expectedRecurrence: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		},
+		"last friday of the month": {
+			task:               common.Task{DueAt: &dueAt, Recurrence: "FREQ=MONTHLY;BYDAY=-1FR"},
+			expectedNext:       true,
+			expectedDueAt:      time.Date(2026, time.January, 30, 9, 0, 0, 0, time.UTC),
+			expectedRecurrence: "FREQ=MONTHLY;BYDAY=-1FR",
+		},
+		"without due date": {
+			task:               common.Task{Recurrence: "FREQ=DAILY"},
+			expectedNext:       true,
+			expectedDueAt:      completedAt.AddDate(0, 0, 1),
+			expectedRecurrence: "FREQ=DAILY",
+		},
+		"count decremented": {
+			task:               common.Task{DueAt: &dueAt, Recurrence: "FREQ=DAILY;COUNT=3"},
+			expectedNext:       true,
+			expectedDueAt:      dueAt.AddDate(0, 0, 1),
+			expectedRecurrence: "FREQ=DAILY;COUNT=2",
+		},
+		"last occurrence": {
+			task: common.Task{DueAt: &dueAt, Recurrence: "FREQ=DAILY;COUNT=1"},
+		},
+		"until passed": {
+			task: common.Task{DueAt: &dueAt, Recurrence: "FREQ=DAILY;UNTIL=20260109"},
+		},
+		"not recurring": {
+			task: common.Task{DueAt: &dueAt},
+		},
+		"invalid rule": {
+			task:        common.Task{DueAt: &dueAt, Recurrence: "FREQ=HOURLY"},
+			expectedErr: ErrInvalidRecurrence,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			task := test.task
+			task.Id = "0001"
+			task.UserId = "00001"
+			task.Description = "standup"
+			task.State = common.TaskStateDone
+			task.CompletedAt = &completedAt
+			task.ParentId = &parentId
+
+			next, err := nextOccurrence(&task)
+			assert.ErrorIs(t, err, test.expectedErr)
+			if !test.expectedNext {
+				assert.Nil(t, next)
+				return
+			}
+
+			require.NotNil(t, next)
+			assert.NotEmpty(t, next.Id)
+			assert.NotEqual(t, task.Id, next.Id)
+			assert.Equal(t, task.UserId, next.UserId)
+			assert.Equal(t, task.Description, next.Description)
+			assert.Equal(t, common.TaskStateToDo, next.State)
+			assert.Equal(t, task.ParentId, next.ParentId)
+			assert.Nil(t, next.CompletedAt)
+			assert.Equal(t, test.expectedDueAt, *next.DueAt)
+			assert.Equal(t, test.expectedRemindAt, next.RemindAt)
+			assert.Equal(t, test.expectedRecurrence, next.Recurrence)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestUpdateRecurringTask() {
+	errAddTask := errors.New("error inserting task")
+	dueAt := time.Date(2026, time.January, 9, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		recurrence  string
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			recurrence: "FREQ=DAILY",
+		},
+		"invalid recurrence": {
+			recurrence:  "FREQ=DAILY;BYHOUR=9",
+			expectedErr: ErrInvalidRecurrence,
+		},
+		"fail": {
+			recurrence:  "FREQ=DAILY",
+			dbError:     errAddTask,
+			expectedErr: errAddTask,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			task := &common.Task{
+				Id:          "0001",
+				UserId:      "00001",
+				Description: "water the plants",
+				State:       common.TaskStateDone,
+				DueAt:       &dueAt,
+				Recurrence:  test.recurrence,
+			}
+
+			// set up dao mock
+			s.getDB().
+				GetTask(task.Id).
+				Return(&common.Task{Id: task.Id, UserId: task.UserId, State: common.TaskStateInProgress}, nil)
+			s.getDB().
+				ListWIPLimits(task.UserId).
+				Return(common.WIPLimits{}, nil)
+
+			var next *common.Task
+			if test.expectedErr != ErrInvalidRecurrence {
+				s.getDB().
+					CountOpenDependencies(task.Id).
+					Return(0, nil)
+				s.getDB().
+					UpdateTask(task).
+					Return(nil)
+				s.getDB().
+					AddTaskRevision(gomock.Any()).
+					Return(nil)
+				s.getDB().
+					LastTaskPosition(task.UserId).
+					Return("", nil)
+				s.getDB().
+					AddTask(gomock.Any()).
+					Do(func(task *common.Task) { next = task }).
+					Return(test.dbError)
+			}
+			if test.expectedErr == nil {
+				s.getDB().
+					CopyTaskLabels(task.Id, gomock.Any()).
+					Return(nil)
+			}
+
+			s.expectTx()
+			_, err := s.svc.UpdateTask(task, "00001", false)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == ErrInvalidRecurrence {
+				return
+			}
+
+			// the recurrence moved to the next occurrence
+			s.Assert().Empty(task.Recurrence)
+			s.Require().NotNil(next)
+			s.Assert().Equal(test.recurrence, next.Recurrence)
+			s.Assert().Equal(dueAt.AddDate(0, 0, 1), *next.DueAt)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// revisionFields are the task fields tracked by the revision history, by their JSON name,
+// each giving a pointer to the field of a task.
+var revisionFields = []struct {
+	name  string
+	field func(task *common.Task) any
+}{
+	{"user_id", func(task *common.Task) any { return &task.UserId }},
+	{"description", func(task *common.Task) any { return &task.Description }},
+	{"state", func(task *common.Task) any { return &task.State }},
+	{"due_at", func(task *common.Task) any { return &task.DueAt }},
+	{"remind_at", func(task *common.Task) any { return &task.RemindAt }},
+	{"parent_id", func(task *common.Task) any { return &task.ParentId }},
+	{"recurrence", func(task *common.Task) any { return &task.Recurrence }},
+	{"project_id", func(task *common.Task) any { return &task.ProjectId }},
+}
+
+// diffTask returns the changes of the tracked fields between two versions of a task.
+func diffTask(old *common.Task, new *common.Task) ([]common.FieldChange, error) {
+	changes := make([]common.FieldChange, 0)
+	for _, field := range revisionFields {
+		oldValue, err := marshalField(field.field(old))
+		if err != nil {
+			return nil, err
+		}
+		newValue, err := marshalField(field.field(new))
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(oldValue, newValue) {
+			changes = append(changes, common.FieldChange{Field: field.name, Old: oldValue, New: newValue})
+		}
+	}
+
+	return changes, nil
+}
+
+// marshalField encodes the field a pointer points to. Times are encoded in UTC, so the same
+// instant read back from the database in another location is not seen as a change.
+func marshalField(field any) (json.RawMessage, error) {
+	if t, ok := field.(**time.Time); ok && *t != nil {
+		utc := (*t).UTC()
+		return json.Marshal(utc)
+	}
+
+	return json.Marshal(field)
+}
+
+// addRevision saves the changes made by an actor to a task, if there are any.
+func (svc *Service) addRevision(taskId string, actorId string, changes []common.FieldChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return svc.db.AddTaskRevision(&common.TaskRevision{
+		Id:        uuid.New().String(),
+		TaskId:    taskId,
+		ActorId:   actorId,
+		CreatedAt: time.Now(),
+		Changes:   changes,
+	})
+}
+
+// ListTaskRevisions returns the revision history of a task, oldest first.
+func (svc *Service) ListTaskRevisions(taskId string) ([]common.TaskRevision, error) {
+	task, err := svc.db.GetTask(taskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	revisions, err := svc.db.ListTaskRevisions(taskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task revisions.", zap.Error(err))
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// RevertTask restores a task to how it was right after the given revision, undoing every later
+// one; revision 0 restores the task as it was created. The restore goes through UpdateTask, so it
+// is checked like any other update and recorded as a new revision made by actorId, in the same
+// transaction as the revisions it is computed from are read.
+func (svc *Service) RevertTask(id string, revision int, actorId string) (*common.Task, error) {
+	// a revert racing another update is computed again from the task it lost to
+	var reverted *common.Task
+	err := retryUpdate(0, func() error {
+		return svc.db.InTx(func(tx db.DBInterface) error {
+			var err error
+			reverted, err = svc.withDB(tx).revertTask(id, revision, actorId)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reverted, nil
+}
+
+func (svc *Service) revertTask(id string, revision int, actorId string) (*common.Task, error) {
+	current, err := svc.db.GetTask(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if current.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	revisions, err := svc.db.ListTaskRevisions(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task revisions.", zap.Error(err))
+		return nil, err
+	}
+	latest := 0
+	if len(revisions) > 0 {
+		latest = revisions[len(revisions)-1].Revision
+	}
+	if revision < 0 || revision > latest {
+		return nil, fmt.Errorf("revision %w", ErrNotFound)
+	}
+
+	task := *current
+	for i := len(revisions) - 1; i >= 0 && revisions[i].Revision > revision; i-- {
+		for _, change := range revisions[i].Changes {
+			if err := restoreField(&task, change); err != nil {
+				svc.logger.Error("Unable to revert task.", zap.Error(err))
+				return nil, err
+			}
+		}
+	}
+
+	return svc.updateTask(&task, actorId, false)
+}
+
+// restoreField sets the field of a task changed by a revision back to its old value.
+func restoreField(task *common.Task, change common.FieldChange) error {
+	for _, field := range revisionFields {
+		if field.name == change.Field {
+			return json.Unmarshal(change.Old, field.field(task))
+		}
+	}
+
+	return fmt.Errorf("unknown revision field %q", change.Field)
+}
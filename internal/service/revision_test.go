@@ -0,0 +1,227 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestUpdateTaskRevision() {
+	errAddRevision := errors.New("error inserting revision")
+	dueAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	// the same instant, as read back from the database in another location
+	storedDueAt := dueAt.In(time.FixedZone("BRT", -3*60*60))
+
+	tests := map[string]struct {
+		description     string
+		state           common.TaskState
+		dbError         error
+		expectedChanges []common.FieldChange
+		expectedErr     error
+	}{
+		"success": {
+			description: "buy bread",
+			state:       common.TaskStateInProgress,
+			expectedChanges: []common.FieldChange{
+				{Field: "description", Old: json.RawMessage(`"buy milk"`), New: json.RawMessage(`"buy bread"`)},
+				{Field: "state", Old: json.RawMessage(`"to_do"`), New: json.RawMessage(`"in_progress"`)},
+			},
+		},
+		"no changes": {
+			description: "buy milk",
+			state:       common.TaskStateToDo,
+		},
+		"fail": {
+			description: "buy bread",
+			state:       common.TaskStateToDo,
+			dbError:     errAddRevision,
+			expectedErr: errAddRevision,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			task := &common.Task{Id: "0001", UserId: "00001", Description: test.description, State: test.state, DueAt: &dueAt}
+
+			// set up dao mock
+			s.getDB().
+				GetTask(task.Id).
+				Return(&common.Task{Id: "0001", UserId: "00001", Description: "buy milk", State: common.TaskStateToDo, DueAt: &storedDueAt}, nil)
+			if test.state != common.TaskStateToDo {
+				s.getDB().
+					ListWIPLimits("00001").
+					Return(common.WIPLimits{}, nil)
+			}
+			s.getDB().
+				UpdateTask(task).
+				Return(nil)
+			if test.expectedChanges != nil || test.dbError != nil {
+				s.getDB().
+					AddTaskRevision(gomock.Any()).
+					DoAndReturn(func(revision *common.TaskRevision) error {
+						s.Assert().NotEmpty(revision.Id)
+						s.Assert().Equal(task.Id, revision.TaskId)
+						s.Assert().Equal("000001", revision.ActorId)
+						s.Assert().False(revision.CreatedAt.IsZero())
+						if test.expectedChanges != nil {
+							s.Assert().Equal(test.expectedChanges, revision.Changes)
+						}
+						return test.dbError
+					})
+			}
+
+			s.expectTx()
+			_, err := s.svc.UpdateTask(task, "000001", false)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestListTaskRevisions() {
+	errListRevisions := errors.New("any error")
+	revisions := []common.TaskRevision{
+		{Id: "0001", TaskId: "00001", Revision: 1, Changes: []common.FieldChange{{Field: "state", Old: json.RawMessage(`"to_do"`), New: json.RawMessage(`"done"`)}}},
+	}
+
+	tests := map[string]struct {
+		task         *common.Task
+		dbError      error
+		expectedResp []common.TaskRevision
+		expectedErr  error
+	}{
+		"success": {
+			task:         &common.Task{Id: "00001"},
+			expectedResp: revisions,
+		},
+		"task not found": {
+			task:        &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			task:        &common.Task{Id: "00001"},
+			dbError:     errListRevisions,
+			expectedErr: errListRevisions,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetTask("00001").
+				Return(test.task, nil)
+			if test.task.Id != "" {
+				s.getDB().
+					ListTaskRevisions("00001").
+					Return(revisions, test.dbError)
+			}
+
+			resp, err := s.svc.ListTaskRevisions("00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestRevertTask() {
+	current := &common.Task{Id: "0001", UserId: "00001", Description: "buy bread", State: common.TaskStateInProgress}
+	revisions := []common.TaskRevision{
+		{
+			Id: "0001", TaskId: "0001", Revision: 1,
+			Changes: []common.FieldChange{{Field: "description", Old: json.RawMessage(`"buy milk"`), New: json.RawMessage(`"buy bread"`)}},
+		},
+		{
+			Id: "0002", TaskId: "0001", Revision: 2,
+			Changes: []common.FieldChange{{Field: "state", Old: json.RawMessage(`"to_do"`), New: json.RawMessage(`"in_progress"`)}},
+		},
+	}
+
+	tests := map[string]struct {
+		revision      int
+		current       *common.Task
+		expectedTask  *common.Task
+		expectedField []string
+		expectedErr   error
+	}{
+		"previous revision": {
+			revision:      1,
+			current:       current,
+			expectedTask:  &common.Task{Id: "0001", UserId: "00001", Description: "buy bread", State: common.TaskStateToDo},
+			expectedField: []string{"state"},
+		},
+		"as created": {
+			revision:      0,
+			current:       current,
+			expectedTask:  &common.Task{Id: "0001", UserId: "00001", Description: "buy milk", State: common.TaskStateToDo},
+			expectedField: []string{"description", "state"},
+		},
+		"latest revision": {
+			revision:     2,
+			current:      current,
+			expectedTask: current,
+		},
+		"unknown revision": {
+			revision:    3,
+			current:     current,
+			expectedErr: ErrNotFound,
+		},
+		"task not found": {
+			revision:    1,
+			current:     &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetTask("0001").
+				Return(test.current, nil)
+			if test.current.Id != "" {
+				s.getDB().
+					ListTaskRevisions("0001").
+					Return(revisions, nil)
+			}
+			if test.expectedErr == nil {
+				stored := *current
+				s.getDB().
+					GetTask("0001").
+					Return(&stored, nil)
+				if test.expectedTask.State != current.State {
+					s.getDB().
+						ListWIPLimits("00001").
+						Return(common.WIPLimits{}, nil)
+				}
+				s.getDB().
+					UpdateTask(test.expectedTask).
+					Return(nil)
+			}
+			if test.expectedField != nil {
+				s.getDB().
+					AddTaskRevision(gomock.Any()).
+					DoAndReturn(func(revision *common.TaskRevision) error {
+						fields := make([]string, 0)
+						for _, change := range revision.Changes {
+							fields = append(fields, change.Field)
+						}
+						s.Assert().Equal(test.expectedField, fields)
+						s.Assert().Equal("000001", revision.ActorId)
+						return nil
+					})
+			}
+
+			s.expectTx()
+			resp, err := s.svc.RevertTask("0001", test.revision, "000001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedTask, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSearchLimit is the number of search results returned when no limit is requested.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit caps the number of search results that can be requested.
+	MaxSearchLimit = 100
+)
+
+// SearchTasks runs a full-text search over the task descriptions, returning the most relevant tasks first.
+func (svc *Service) SearchTasks(search common.TaskSearch) ([]common.TaskSearchResult, error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Query == "" {
+		err := fmt.Errorf("%w: query is required", ErrInvalidSearch)
+		svc.logger.Error("Unable to search tasks.", zap.Error(err))
+		return nil, err
+	}
+
+	if search.State != "" {
+		if err := validateState(search.State); err != nil {
+			svc.logger.Error("Unable to search tasks.", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	switch {
+	case search.Limit <= 0:
+		search.Limit = DefaultSearchLimit
+	case search.Limit > MaxSearchLimit:
+		search.Limit = MaxSearchLimit
+	}
+
+	results, err := svc.db.SearchTasks(search)
+	if err != nil {
+		svc.logger.Error("Unable to search tasks.", zap.Error(err))
+		return nil, err
+	}
+
+	tasks := make([]common.Task, len(results))
+	for i := range results {
+		tasks[i] = results[i].Task
+	}
+	if err := svc.loadLabels(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task labels.", zap.Error(err))
+		return nil, err
+	}
+	if err := svc.loadChecklists(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task checklists.", zap.Error(err))
+		return nil, err
+	}
+	for i := range results {
+		results[i].Task = tasks[i]
+	}
+
+	return results, nil
+}
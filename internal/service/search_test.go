@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (s *svcTestSuite) TestSearchTasks() {
+	errSearchTasks := errors.New("any error")
+	results := []common.TaskSearchResult{
+		{
+			Task:    common.Task{Id: "0001", UserId: "00001", Description: "buy milk", State: "to_do"},
+			Rank:    0.06,
+			Snippet: "buy <b>milk</b>",
+		},
+	}
+	labeledResults := []common.TaskSearchResult{results[0]}
+	labeledResults[0].Labels = []string{"home"}
+
+	tests := map[string]struct {
+		search       common.TaskSearch
+		dbSearch     common.TaskSearch
+		dbError1     error
+		dbError2     error
+		expectedResp []common.TaskSearchResult
+		expectedErr  error
+	}{
+		"success": {
+			search:       common.TaskSearch{Query: " milk "},
+			dbSearch:     common.TaskSearch{Query: "milk", Limit: DefaultSearchLimit},
+			expectedResp: labeledResults,
+		},
+		"filtered": {
+			search:       common.TaskSearch{Query: "milk", UserId: "00001", State: "to_do", Limit: 5},
+			dbSearch:     common.TaskSearch{Query: "milk", UserId: "00001", State: "to_do", Limit: 5},
+			expectedResp: labeledResults,
+		},
+		"capped limit": {
+			search:       common.TaskSearch{Query: "milk", Limit: 1000},
+			dbSearch:     common.TaskSearch{Query: "milk", Limit: MaxSearchLimit},
+			expectedResp: labeledResults,
+		},
+		"empty query": {
+			search:      common.TaskSearch{Query: "  "},
+			expectedErr: ErrInvalidSearch,
+		},
+		"invalid state": {
+			search:      common.TaskSearch{Query: "milk", State: "sleeping"},
+			expectedErr: ErrInvalidState,
+		},
+		"fail1": {
+			search:      common.TaskSearch{Query: "milk"},
+			dbSearch:    common.TaskSearch{Query: "milk", Limit: DefaultSearchLimit},
+			dbError1:    errSearchTasks,
+			expectedErr: errSearchTasks,
+		},
+		"fail2": {
+			search:      common.TaskSearch{Query: "milk"},
+			dbSearch:    common.TaskSearch{Query: "milk", Limit: DefaultSearchLimit},
+			dbError2:    errSearchTasks,
+			expectedErr: errSearchTasks,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbSearch.Query != "" {
+				s.getDB().
+					SearchTasks(test.dbSearch).
+					Return([]common.TaskSearchResult{results[0]}, test.dbError1)
+			}
+			if test.dbSearch.Query != "" && test.dbError1 == nil {
+				s.getDB().
+					ListTaskLabels([]string{"0001"}).
+					Return(map[string][]string{"0001": {"home"}}, test.dbError2)
+			}
+			if test.dbSearch.Query != "" && test.dbError1 == nil && test.dbError2 == nil {
+				s.getDB().
+					ListChecklistItems([]string{"0001"}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+			}
+
+			resp, err := s.svc.SearchTasks(test.search)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedResp, resp)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/blob"
 	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/events"
 	"go.uber.org/zap"
 )
 
@@ -16,10 +21,33 @@ func New(cfg Config) (*Service, error) {
 		logger.Error("Error getting database instance", zap.Error(err))
 		return nil, err
 	}
+
+	emitter := cfg.Emitter
+	if emitter == nil {
+		emitter = events.NewZapEmitter(logger)
+	}
+
+	transitions := cfg.Transitions
+	if transitions == nil {
+		transitions = DefaultTransitions
+	}
+	attachments := cfg.Attachments
+	if attachments.Store == nil {
+		attachments.Store = blob.NewFileStore(filepath.Join(os.TempDir(), "to-do-api", "attachments"))
+	}
+	if attachments.MaxSize <= 0 {
+		attachments.MaxSize = DefaultMaxAttachmentSize
+	}
+	if attachments.URLExpiry <= 0 {
+		attachments.URLExpiry = DefaultAttachmentURLExpiry
+	}
 	logger.Info("service created")
 
 	return &Service{
-		logger: logger,
-		db:     db,
+		logger:      logger,
+		db:          db,
+		emitter:     emitter,
+		transitions: transitions,
+		attachments: attachments,
 	}, nil
 }
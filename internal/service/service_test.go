@@ -3,7 +3,10 @@ package service
 import (
 	"testing"
 
+	mock_blob "github.com/aborgesrodrigues/to-do-api/internal/blob/mock"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
 	mock_db "github.com/aborgesrodrigues/to-do-api/internal/db/mock"
+	mock_events "github.com/aborgesrodrigues/to-do-api/internal/events/mock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -28,6 +31,12 @@ func (s *svcTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.Suite.T())
 	dbInterface := mock_db.NewMockDBInterface(s.ctrl)
 	s.svc.db = dbInterface
+	s.svc.emitter = mock_events.NewMockEmitter(s.ctrl)
+	s.svc.attachments = AttachmentConfig{
+		Store:     mock_blob.NewMockStore(s.ctrl),
+		MaxSize:   DefaultMaxAttachmentSize,
+		URLExpiry: DefaultAttachmentURLExpiry,
+	}
 }
 
 func (s *svcTestSuite) TearDownTest() {
@@ -38,6 +47,23 @@ func (s *svcTestSuite) getDB() *mock_db.MockDBInterfaceMockRecorder {
 	return s.svc.db.(*mock_db.MockDBInterface).EXPECT()
 }
 
+func (s *svcTestSuite) getEmitter() *mock_events.MockEmitterMockRecorder {
+	return s.svc.emitter.(*mock_events.MockEmitter).EXPECT()
+}
+
+func (s *svcTestSuite) getBlobs() *mock_blob.MockStoreMockRecorder {
+	return s.svc.attachments.Store.(*mock_blob.MockStore).EXPECT()
+}
+
+// expectTx expects a transaction whose statements run on the database mock itself.
+func (s *svcTestSuite) expectTx() {
+	s.getDB().
+		InTx(gomock.Any()).
+		DoAndReturn(func(fn func(tx db.DBInterface) error) error {
+			return fn(s.svc.db)
+		})
+}
+
 func TestService(t *testing.T) {
 	suite.Run(t, new(svcTestSuite))
 }
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/events"
+	"go.uber.org/zap"
+)
+
+// SnoozeTask hides a task from the lists until the time of the snooze, snoozing it again if it
+// already is. Done and cancelled tasks cannot be snoozed.
+func (svc *Service) SnoozeTask(id string, snooze common.Snooze) (*common.Task, error) {
+	now := time.Now()
+	until, err := snoozeTime(snooze, now)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := svc.db.GetTask(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+	if task.State == common.TaskStateDone || task.State == common.TaskStateCancelled {
+		return nil, fmt.Errorf("%w: the task is %s", ErrInvalidSnooze, task.State)
+	}
+
+	if err := svc.db.SnoozeTask(id, &until); err != nil {
+		svc.logger.Error("Unable to snooze task.", zap.Error(err))
+		return nil, err
+	}
+	task.SnoozedUntil = &until
+	task.Version++
+
+	return task, nil
+}
+
+// UnsnoozeTask shows a snoozed task in the lists again, before its snooze time.
+func (svc *Service) UnsnoozeTask(id string) (*common.Task, error) {
+	task, err := svc.db.GetTask(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	if task.SnoozedUntil != nil {
+		if err := svc.db.SnoozeTask(id, nil); err != nil {
+			svc.logger.Error("Unable to unsnooze task.", zap.Error(err))
+			return nil, err
+		}
+		task.SnoozedUntil = nil
+		task.Version++
+	}
+
+	return task, nil
+}
+
+// WakeSnoozedTasks ends the snooze of every task whose snooze time is at or before now, emitting
+// a change event for each, and returns how many tasks woke up.
+func (svc *Service) WakeSnoozedTasks(now time.Time) (int, error) {
+	tasks, err := svc.db.ListSnoozedTasks(now)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve snoozed tasks.", zap.Error(err))
+		return 0, err
+	}
+
+	woken := 0
+	for i := range tasks {
+		task := &tasks[i]
+		snoozedUntil := *task.SnoozedUntil
+
+		// a task snoozed again since it was listed stays asleep
+		ok, err := svc.db.WakeTask(task.Id, snoozedUntil)
+		if err != nil {
+			svc.logger.Error("Unable to wake task.", zap.String("task", task.Id), zap.Error(err))
+			return woken, err
+		}
+		if !ok {
+			continue
+		}
+		woken++
+		task.SnoozedUntil = nil
+		task.Version++
+
+		old, err := json.Marshal(snoozedUntil)
+		if err != nil {
+			svc.logger.Error("Unable to encode snooze time.", zap.String("task", task.Id), zap.Error(err))
+			continue
+		}
+		if err := svc.emitter.Emit(events.Event{
+			Type:      events.TaskChangedType,
+			Timestamp: now,
+			Task:      task,
+			Changes:   []common.FieldChange{{Field: "snoozed_until", Old: old, New: json.RawMessage("null")}},
+		}); err != nil {
+			svc.logger.Error("Unable to emit task change.", zap.String("task", task.Id), zap.Error(err))
+		}
+	}
+
+	return woken, nil
+}
+
+// snoozeTime returns the time a snooze ends at, checking that it is after now.
+func snoozeTime(snooze common.Snooze, now time.Time) (time.Time, error) {
+	if (snooze.Until == nil) == (snooze.Duration == "") {
+		return time.Time{}, fmt.Errorf("%w: exactly one of until and duration is required", ErrInvalidSnooze)
+	}
+
+	until := now
+	if snooze.Until != nil {
+		until = *snooze.Until
+	} else {
+		duration, err := time.ParseDuration(snooze.Duration)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: invalid duration %q", ErrInvalidSnooze, snooze.Duration)
+		}
+		until = now.Add(duration)
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("%w: the snooze must end in the future", ErrInvalidSnooze)
+	}
+
+	return until, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/events"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestSnoozeTask() {
+	errSnooze := errors.New("error snoozing task")
+	until := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	task := &common.Task{Id: "0001", UserId: "00001", Description: "description 1", State: common.TaskStateToDo}
+
+	tests := map[string]struct {
+		snooze        common.Snooze
+		dbTask        *common.Task
+		dbError       error
+		expectedUntil time.Time
+		expectedErr   error
+	}{
+		"until": {
+			snooze:        common.Snooze{Until: &until},
+			dbTask:        task,
+			expectedUntil: until,
+		},
+		"duration": {
+			snooze:        common.Snooze{Duration: "2h30m"},
+			dbTask:        task,
+			expectedUntil: time.Now().Add(2*time.Hour + 30*time.Minute),
+		},
+		"until and duration": {
+			snooze:      common.Snooze{Until: &until, Duration: "2h"},
+			expectedErr: ErrInvalidSnooze,
+		},
+		"neither until nor duration": {
+			expectedErr: ErrInvalidSnooze,
+		},
+		"invalid duration": {
+			snooze:      common.Snooze{Duration: "tomorrow"},
+			expectedErr: ErrInvalidSnooze,
+		},
+		"past": {
+			snooze:      common.Snooze{Until: &past},
+			expectedErr: ErrInvalidSnooze,
+		},
+		"negative duration": {
+			snooze:      common.Snooze{Duration: "-1h"},
+			expectedErr: ErrInvalidSnooze,
+		},
+		"done": {
+			snooze:      common.Snooze{Until: &until},
+			dbTask:      &common.Task{Id: "0001", UserId: "00001", Description: "description 1", State: common.TaskStateDone},
+			expectedErr: ErrInvalidSnooze,
+		},
+		"not found": {
+			snooze:      common.Snooze{Until: &until},
+			dbTask:      &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			snooze:      common.Snooze{Until: &until},
+			dbTask:      task,
+			dbError:     errSnooze,
+			expectedErr: errSnooze,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbTask != nil {
+				dbTask := *test.dbTask
+				s.getDB().
+					GetTask("0001").
+					Return(&dbTask, nil)
+			}
+			if test.dbTask != nil && test.dbTask.State == common.TaskStateToDo {
+				s.getDB().
+					SnoozeTask("0001", gomock.Any()).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.SnoozeTask("0001", test.snooze)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Require().NotNil(resp.SnoozedUntil)
+				s.Assert().WithinDuration(test.expectedUntil, *resp.SnoozedUntil, time.Second)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestUnsnoozeTask() {
+	errUnsnooze := errors.New("error unsnoozing task")
+	until := time.Now().Add(time.Hour)
+
+	tests := map[string]struct {
+		dbTask      *common.Task
+		dbError     error
+		expectedErr error
+	}{
+		"snoozed": {
+			dbTask: &common.Task{Id: "0001", State: common.TaskStateToDo, SnoozedUntil: &until},
+		},
+		"not snoozed": {
+			dbTask: &common.Task{Id: "0001", State: common.TaskStateToDo},
+		},
+		"not found": {
+			dbTask:      &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbTask:      &common.Task{Id: "0001", State: common.TaskStateToDo, SnoozedUntil: &until},
+			dbError:     errUnsnooze,
+			expectedErr: errUnsnooze,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetTask("0001").
+				Return(test.dbTask, nil)
+			if test.dbTask.SnoozedUntil != nil {
+				s.getDB().
+					SnoozeTask("0001", nil).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.UnsnoozeTask("0001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Nil(resp.SnoozedUntil)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestWakeSnoozedTasks() {
+	errWake := errors.New("any error")
+	now := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	newTasks := func() []common.Task {
+		return []common.Task{
+			{Id: "0001", UserId: "00001", Description: "description 1", State: common.TaskStateToDo, SnoozedUntil: &now},
+			{Id: "0002", UserId: "00001", Description: "description 2", State: common.TaskStateToDo, SnoozedUntil: &now},
+		}
+	}
+	changes := []common.FieldChange{{Field: "snoozed_until", Old: json.RawMessage(`"2026-01-31T09:00:00Z"`), New: json.RawMessage("null")}}
+
+	tests := map[string]struct {
+		dbTasks       []common.Task
+		dbListError   error
+		dbWoken       []bool
+		dbWakeError   error
+		emitError     error
+		expectedWoken int
+		expectedErr   error
+	}{
+		"success": {
+			dbTasks:       newTasks(),
+			dbWoken:       []bool{true, true},
+			expectedWoken: 2,
+		},
+		"snoozed again": {
+			dbTasks:       newTasks(),
+			dbWoken:       []bool{false, true},
+			expectedWoken: 1,
+		},
+		"nothing to wake": {
+			dbTasks: []common.Task{},
+		},
+		"fail list": {
+			dbListError: errWake,
+			expectedErr: errWake,
+		},
+		"fail wake": {
+			dbTasks:     newTasks(),
+			dbWoken:     []bool{false},
+			dbWakeError: errWake,
+			expectedErr: errWake,
+		},
+		"fail emit": {
+			dbTasks:       newTasks(),
+			dbWoken:       []bool{true, true},
+			emitError:     errWake,
+			expectedWoken: 2,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				ListSnoozedTasks(now).
+				Return(test.dbTasks, test.dbListError)
+
+			for i, woken := range test.dbWoken {
+				task := test.dbTasks[i]
+				s.getDB().
+					WakeTask(task.Id, now).
+					Return(woken, test.dbWakeError)
+				if !woken {
+					continue
+				}
+
+				task.SnoozedUntil = nil
+				task.Version++
+				s.getEmitter().
+					Emit(events.Event{Type: events.TaskChangedType, Timestamp: now, Task: &task, Changes: changes}).
+					Return(test.emitError)
+			}
+
+			woken, err := s.svc.WakeSnoozedTasks(now)
+			s.Assert().Equal(test.expectedWoken, woken)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
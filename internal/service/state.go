@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+// Transitions maps each task state to the states a task may move to from it.
+// Staying in the same state is always allowed.
+type Transitions map[common.TaskState][]common.TaskState
+
+// DefaultTransitions is the transition table used when Config.Transitions is not set.
+var DefaultTransitions = Transitions{
+	common.TaskStateToDo: {
+		common.TaskStateInProgress,
+		common.TaskStateBlocked,
+		common.TaskStateDone,
+		common.TaskStateCancelled,
+	},
+	common.TaskStateInProgress: {
+		common.TaskStateToDo,
+		common.TaskStateBlocked,
+		common.TaskStateDone,
+		common.TaskStateCancelled,
+	},
+	common.TaskStateBlocked: {
+		common.TaskStateToDo,
+		common.TaskStateInProgress,
+		common.TaskStateCancelled,
+	},
+	common.TaskStateDone: {
+		common.TaskStateToDo,
+	},
+	common.TaskStateCancelled: {
+		common.TaskStateToDo,
+	},
+}
+
+// Allowed reports whether a task may move from one state to another.
+func (t Transitions) Allowed(from, to common.TaskState) bool {
+	if from == to {
+		return true
+	}
+
+	for _, state := range t[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+func validateState(state common.TaskState) error {
+	if !state.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidState, state)
+	}
+	return nil
+}
+
+func (svc *Service) validateTransition(from, to common.TaskState) error {
+	if err := validateState(to); err != nil {
+		return err
+	}
+
+	if !svc.transitions.Allowed(from, to) {
+		return fmt.Errorf("%w: from %q to %q", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
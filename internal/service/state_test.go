@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionsAllowed(t *testing.T) {
+	tests := map[string]struct {
+		from     common.TaskState
+		to       common.TaskState
+		expected bool
+	}{
+		"start":            {from: common.TaskStateToDo, to: common.TaskStateInProgress, expected: true},
+		"finish":           {from: common.TaskStateInProgress, to: common.TaskStateDone, expected: true},
+		"same state":       {from: common.TaskStateDone, to: common.TaskStateDone, expected: true},
+		"reopen":           {from: common.TaskStateCancelled, to: common.TaskStateToDo, expected: true},
+		"done to blocked":  {from: common.TaskStateDone, to: common.TaskStateBlocked, expected: false},
+		"blocked to done":  {from: common.TaskStateBlocked, to: common.TaskStateDone, expected: false},
+		"unknown state":    {from: common.TaskStateToDo, to: "finished", expected: false},
+		"from unknown one": {from: "finished", to: common.TaskStateToDo, expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DefaultTransitions.Allowed(test.from, test.to))
+		})
+	}
+}
+
+func TestCustomTransitions(t *testing.T) {
+	svc := &Service{
+		transitions: Transitions{
+			common.TaskStateToDo: {common.TaskStateDone},
+		},
+	}
+
+	assert.NoError(t, svc.validateTransition(common.TaskStateToDo, common.TaskStateDone))
+	assert.ErrorIs(t, svc.validateTransition(common.TaskStateToDo, common.TaskStateInProgress), ErrInvalidTransition)
+	assert.ErrorIs(t, svc.validateTransition(common.TaskStateToDo, "finished"), ErrInvalidState)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+func (svc *Service) ListSubtasks(id string) ([]common.Task, error) {
+	tasks, err := svc.db.ListSubtasks(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve subtasks.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.loadLabels(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task labels.", zap.Error(err))
+		return nil, err
+	}
+	if err := svc.loadChecklists(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task checklists.", zap.Error(err))
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// validateParent checks that the parent of a task exists and belongs to the same user.
+func (svc *Service) validateParent(task *common.Task) error {
+	if task.ParentId == nil {
+		return nil
+	}
+
+	parent, err := svc.db.GetTask(*task.ParentId)
+	if err != nil {
+		return err
+	}
+	if parent.Id == "" {
+		return fmt.Errorf("%w: task %s not found", ErrInvalidParent, *task.ParentId)
+	}
+	if parent.UserId != task.UserId {
+		return fmt.Errorf("%w: task %s belongs to another user", ErrInvalidParent, parent.Id)
+	}
+
+	return nil
+}
+
+// validateHierarchy checks that moving an existing task under its new parent does not create a cycle.
+func (svc *Service) validateHierarchy(task *common.Task) error {
+	if task.ParentId == nil {
+		return nil
+	}
+
+	if err := svc.validateParent(task); err != nil {
+		return err
+	}
+
+	ancestors, err := svc.db.ListTaskAncestors(*task.ParentId)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(ancestors, task.Id) {
+		return fmt.Errorf("%w: task %s cannot be a subtask of itself or of its own subtasks", ErrInvalidParent, task.Id)
+	}
+
+	return nil
+}
+
+// loadSubtasks fills the subtasks and the progress of a task.
+func (svc *Service) loadSubtasks(task *common.Task) error {
+	subtasks, err := svc.db.ListSubtasks(task.Id)
+	if err != nil {
+		return err
+	}
+	task.Subtasks = subtasks
+
+	done, total, err := svc.db.GetTaskProgress(task.Id)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		progress := done * 100 / total
+		task.Progress = &progress
+	}
+
+	return nil
+}
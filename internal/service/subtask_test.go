@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestListSubtasks() {
+	errGetTasks := errors.New("any error")
+	parentId := "0001"
+	tasks := []common.Task{
+		{
+			Id:          "0002",
+			UserId:      "00001",
+			Description: "description 2",
+			State:       "to_do",
+			ParentId:    &parentId,
+		},
+	}
+
+	tests := map[string]struct {
+		dbError      error
+		dbTasks      []common.Task
+		expectedResp []common.Task
+		expectedErr  error
+	}{
+		"success": {
+			dbError:      nil,
+			dbTasks:      tasks,
+			expectedResp: tasks,
+			expectedErr:  nil,
+		},
+		"fail": {
+			dbError:      errGetTasks,
+			dbTasks:      nil,
+			expectedResp: nil,
+			expectedErr:  errGetTasks,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				ListSubtasks(parentId).
+				Return(test.dbTasks, test.dbError)
+
+			if test.dbError == nil {
+				s.getDB().
+					ListTaskLabels([]string{"0002"}).
+					Return(map[string][]string{}, nil)
+				s.getDB().
+					ListChecklistItems([]string{"0002"}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+			}
+
+			tasks, err := s.svc.ListSubtasks(parentId)
+			s.Assert().Equal(test.expectedResp, tasks)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestGetTaskProgress() {
+	task := &common.Task{
+		Id:          "0001",
+		UserId:      "00001",
+		Description: "description 1",
+		State:       "to_do",
+	}
+	subtasks := []common.Task{
+		{Id: "0002", UserId: "00001", State: common.TaskStateDone, ParentId: &task.Id},
+		{Id: "0003", UserId: "00001", State: common.TaskStateToDo, ParentId: &task.Id},
+		{Id: "0004", UserId: "00001", State: common.TaskStateToDo, ParentId: &task.Id},
+	}
+
+	// set up dao mock
+	s.getDB().
+		GetTask(task.Id).
+		Return(task, nil)
+	s.getDB().
+		GetUser(task.UserId).
+		Return(&common.User{Id: task.UserId}, nil)
+	s.getDB().
+		ListSubtasks(task.Id).
+		Return(subtasks, nil)
+	s.getDB().
+		GetTaskProgress(task.Id).
+		Return(1, 3, nil)
+	s.getDB().
+		ListTaskLabels([]string{task.Id}).
+		Return(map[string][]string{}, nil)
+	s.getDB().
+		ListChecklistItems([]string{task.Id}).
+		Return(map[string][]common.ChecklistItem{}, nil)
+
+	resp, err := s.svc.GetTask(task.Id)
+	s.Assert().NoError(err)
+	s.Assert().Equal(subtasks, resp.Subtasks)
+	s.Assert().Equal(33, *resp.Progress)
+}
+
+func (s *svcTestSuite) TestAddSubtask() {
+	parentId := "0001"
+	otherId := "0009"
+
+	tests := map[string]struct {
+		parentId    *string
+		dbParent    *common.Task
+		expectedErr error
+	}{
+		"success": {
+			parentId: &parentId,
+			dbParent: &common.Task{Id: parentId, UserId: "00001"},
+		},
+		"parent not found": {
+			parentId:    &otherId,
+			dbParent:    &common.Task{},
+			expectedErr: ErrInvalidParent,
+		},
+		"parent of another user": {
+			parentId:    &parentId,
+			dbParent:    &common.Task{Id: parentId, UserId: "00002"},
+			expectedErr: ErrInvalidParent,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			task := &common.Task{
+				UserId:      "00001",
+				Description: "description 2",
+				State:       common.TaskStateToDo,
+				ParentId:    test.parentId,
+			}
+
+			// set up dao mock
+			s.getDB().
+				GetTask(*test.parentId).
+				Return(test.dbParent, nil)
+
+			if test.expectedErr == nil {
+				s.getDB().
+					LastTaskPosition(task.UserId).
+					Return("", nil)
+				s.getDB().
+					AddTask(task).
+					Return(nil)
+			}
+
+			s.expectTx()
+			_, err := s.svc.AddTask(task)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestUpdateSubtaskCycle() {
+	tests := map[string]struct {
+		parentId    string
+		ancestors   []string
+		expectedErr error
+	}{
+		"success": {
+			parentId:  "0002",
+			ancestors: []string{"0002", "0009"},
+		},
+		"own parent": {
+			parentId:    "0001",
+			ancestors:   []string{"0001"},
+			expectedErr: ErrInvalidParent,
+		},
+		"descendant as parent": {
+			parentId:    "0003",
+			ancestors:   []string{"0003", "0002", "0001"},
+			expectedErr: ErrInvalidParent,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			task := &common.Task{
+				Id:          "0001",
+				UserId:      "00001",
+				Description: "description 1",
+				State:       common.TaskStateToDo,
+				ParentId:    &test.parentId,
+			}
+
+			// set up dao mock
+			s.getDB().
+				GetTask(task.Id).
+				Return(&common.Task{Id: task.Id, UserId: task.UserId, State: common.TaskStateToDo}, nil)
+			s.getDB().
+				GetTask(test.parentId).
+				Return(&common.Task{Id: test.parentId, UserId: task.UserId}, nil)
+			s.getDB().
+				ListTaskAncestors(test.parentId).
+				Return(test.ancestors, nil)
+
+			if test.expectedErr == nil {
+				s.getDB().
+					UpdateTask(task).
+					Return(nil)
+				s.getDB().
+					AddTaskRevision(gomock.Any()).
+					Return(nil)
+			}
+
+			s.expectTx()
+			_, err := s.svc.UpdateTask(task, "00001", false)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -1,29 +1,236 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/events"
+	"github.com/aborgesrodrigues/to-do-api/internal/filter"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// AddTask creates a task after the other tasks of its user. The task and its labels are saved in
+// one transaction.
 func (svc *Service) AddTask(task *common.Task) (*common.Task, error) {
+	var added *common.Task
+	err := svc.db.InTx(func(tx db.DBInterface) error {
+		var err error
+		added, err = svc.withDB(tx).addTask(task)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// addTask runs the creation of a task on the database of the service, a transaction of AddTask or
+// of a batch, an import or a template instantiation.
+func (svc *Service) addTask(task *common.Task) (*common.Task, error) {
 	// add uuid
 	task.Id = uuid.New().String()
 
+	if task.State == "" {
+		task.State = common.TaskStateToDo
+	}
+	if err := validateState(task.State); err != nil {
+		svc.logger.Error("Unable add Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.validateParent(task); err != nil {
+		svc.logger.Error("Unable add Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.validateProject(task.UserId, task.ProjectId); err != nil {
+		svc.logger.Error("Unable add Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := validateRecurrence(task); err != nil {
+		svc.logger.Error("Unable add Task.", zap.Error(err))
+		return nil, err
+	}
+
+	// creation and completion times are managed by the service
+	now := time.Now()
+	task.CreatedAt = &now
+	task.CompletedAt = nil
+	if task.State == common.TaskStateDone {
+		task.CompletedAt = &now
+	}
+	// a new task starts at the first version
+	task.Version = 1
+
+	// new tasks go after the other tasks of their user
+	if err := svc.setLastPosition(task); err != nil {
+		svc.logger.Error("Unable to set task position.", zap.Error(err))
+		return nil, err
+	}
+
 	if err := svc.db.AddTask(task); err != nil {
 		svc.logger.Error("Unable add Task.", zap.Error(err))
 		return nil, err
 	}
 
+	if len(task.Labels) > 0 {
+		if err := svc.setTaskLabels(task); err != nil {
+			svc.logger.Error("Unable to set task labels.", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return task, nil
 }
 
-func (svc *Service) UpdateTask(task *common.Task) (*common.Task, error) {
+// UpdateTask updates a task on behalf of actorId, recording the changed fields as a new revision.
+// A task moved into a column of its user's board that is full is rejected, unless force is set.
+// The update, its revision, its labels and the next occurrence of a recurring task are saved in
+// one transaction.
+func (svc *Service) UpdateTask(task *common.Task, actorId string, force bool) (*common.Task, error) {
+	request := *task
+	var updated *common.Task
+	err := retryUpdate(task.Version, func() error {
+		// every attempt starts over from the request
+		*task = request
+		return svc.db.InTx(func(tx db.DBInterface) error {
+			var err error
+			updated, err = svc.withDB(tx).updateTask(task, actorId, force)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// updateTask runs an update of a task on the database of the service, a transaction of UpdateTask
+// or of a batch.
+func (svc *Service) updateTask(task *common.Task, actorId string, force bool) (*common.Task, error) {
+	current, err := svc.db.GetTask(task.Id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if current.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	// a task carrying a version is only updated from that version
+	if task.Version != 0 && task.Version != current.Version {
+		return nil, fmt.Errorf("%w: the task is at version %d", ErrVersionMismatch, current.Version)
+	}
+	task.Version = current.Version
+
+	if err := svc.validateTransition(current.State, task.State); err != nil {
+		svc.logger.Error("Unable update Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if !force && (task.State != current.State || task.UserId != current.UserId) {
+		if err := svc.validateWIPLimit(task.UserId, task.State); err != nil {
+			svc.logger.Error("Unable update Task.", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err := svc.validateHierarchy(task); err != nil {
+		svc.logger.Error("Unable update Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.validateProject(task.UserId, task.ProjectId); err != nil {
+		svc.logger.Error("Unable update Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := validateRecurrence(task); err != nil {
+		svc.logger.Error("Unable update Task.", zap.Error(err))
+		return nil, err
+	}
+
+	if task.State == common.TaskStateDone && current.State != common.TaskStateDone {
+		if err := svc.validateDependenciesDone(task.Id); err != nil {
+			svc.logger.Error("Unable update Task.", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	// the position only changes through MoveTask
+	task.Position = current.Position
+	task.CreatedAt = current.CreatedAt
+
+	// completion time is managed by the service
+	task.CompletedAt = current.CompletedAt
+	switch {
+	case task.State != common.TaskStateDone:
+		task.CompletedAt = nil
+	case current.State != common.TaskStateDone:
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+
+	// completing a recurring task moves its recurrence to the next occurrence
+	var next *common.Task
+	if task.State == common.TaskStateDone && current.State != common.TaskStateDone {
+		if next, err = nextOccurrence(task); err != nil {
+			svc.logger.Error("Unable update Task.", zap.Error(err))
+			return nil, err
+		}
+		if next != nil {
+			task.Recurrence = ""
+		}
+	}
+
+	changes, err := diffTask(current, task)
+	if err != nil {
+		svc.logger.Error("Unable update Task.", zap.Error(err))
+		return nil, err
+	}
+
 	if err := svc.db.UpdateTask(task); err != nil {
 		svc.logger.Error("Unable add Task.", zap.Error(err))
 		return nil, err
 	}
 
+	if err := svc.addRevision(task.Id, actorId, changes); err != nil {
+		svc.logger.Error("Unable to save task revision.", zap.Error(err))
+		return nil, err
+	}
+
+	if task.Labels != nil {
+		if err := svc.setTaskLabels(task); err != nil {
+			svc.logger.Error("Unable to set task labels.", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if next != nil {
+		// the next occurrence is created as the task is completed
+		next.CreatedAt = task.CompletedAt
+		next.Version = 1
+		if err := svc.setLastPosition(next); err != nil {
+			svc.logger.Error("Unable to set task position.", zap.Error(err))
+			return nil, err
+		}
+		if err := svc.db.AddTask(next); err != nil {
+			svc.logger.Error("Unable add next task occurrence.", zap.Error(err))
+			return nil, err
+		}
+		if err := svc.db.CopyTaskLabels(task.Id, next.Id); err != nil {
+			svc.logger.Error("Unable to copy task labels.", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return task, nil
 }
 
@@ -33,6 +240,9 @@ func (svc *Service) GetTask(id string) (*common.Task, error) {
 		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
 		return nil, err
 	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
 
 	// get user
 	user, err := svc.db.GetUser(task.UserId)
@@ -42,11 +252,37 @@ func (svc *Service) GetTask(id string) (*common.Task, error) {
 	}
 	task.User = user
 
+	if err := svc.loadSubtasks(task); err != nil {
+		svc.logger.Error("Unable to retrieve subtasks.", zap.Error(err))
+		return nil, err
+	}
+
+	labels, err := svc.db.ListTaskLabels([]string{task.Id})
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task labels.", zap.Error(err))
+		return nil, err
+	}
+	task.Labels = labels[task.Id]
+
+	tasks := []common.Task{*task}
+	if err := svc.loadChecklists(tasks); err != nil {
+		svc.logger.Error("Unable to retrieve task checklist.", zap.Error(err))
+		return nil, err
+	}
+	task = &tasks[0]
+
 	return task, nil
 }
 
-func (svc *Service) DeleteTask(id string) error {
-	err := svc.db.DeleteTask(id)
+// DeleteTask moves a task to the trash, along with its subtasks when cascade is set.
+// Otherwise the subtasks are moved up to the parent of the deleted task.
+func (svc *Service) DeleteTask(id string, cascade bool) error {
+	deleteTask := svc.db.DeleteTask
+	if cascade {
+		deleteTask = svc.db.DeleteTaskTree
+	}
+
+	err := deleteTask(id, time.Now())
 	if err != nil {
 		svc.logger.Error("Unable to delete tasks.", zap.Error(err))
 		return err
@@ -55,12 +291,87 @@ func (svc *Service) DeleteTask(id string) error {
 	return nil
 }
 
-func (svc *Service) ListTasks() ([]common.Task, error) {
-	tasks, err := svc.db.ListTasks()
+func (svc *Service) ListTasks(filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	result, err := svc.db.ListTasks(filter, page)
 	if err != nil {
 		svc.logger.Error("Unable to retrieve tasks.", zap.Error(err))
+		return nil, listError(err)
+	}
+
+	if err := svc.loadLabels(result.Items); err != nil {
+		svc.logger.Error("Unable to retrieve task labels.", zap.Error(err))
+		return nil, err
+	}
+	if err := svc.loadChecklists(result.Items); err != nil {
+		svc.logger.Error("Unable to retrieve task checklists.", zap.Error(err))
 		return nil, err
 	}
 
-	return tasks, nil
+	return result, nil
+}
+
+// listError returns an error about the filter expression or the cursor of a list as an
+// ErrInvalidFilter or an ErrInvalidPage, and any other error as is.
+func listError(err error) error {
+	switch {
+	case errors.Is(err, filter.ErrInvalidExpression):
+		return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	case errors.Is(err, db.ErrInvalidCursor):
+		return fmt.Errorf("%w: %w", ErrInvalidPage, err)
+	default:
+		return err
+	}
+}
+
+// maxUpdateAttempts bounds how many times an update without a version is run again after losing
+// the race against a concurrent update of the same row.
+const maxUpdateAttempts = 3
+
+// retryUpdate runs an update that reads a row and writes it back from the version it read. When the
+// row changed in between, an update the client made from a version fails with ErrVersionMismatch,
+// while an update without a version, 0, runs again from a fresh read and fails with
+// ErrConcurrentUpdate once the attempts run out.
+func retryUpdate(version int, update func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := update()
+		switch {
+		case !errors.Is(err, db.ErrVersionConflict):
+			return err
+		case version != 0:
+			return fmt.Errorf("%w: %w", ErrVersionMismatch, err)
+		case attempt == maxUpdateAttempts:
+			return fmt.Errorf("%w: %w", ErrConcurrentUpdate, err)
+		}
+	}
+}
+
+// SendDueReminders emits a reminder event for every task whose reminder is due at now
+// and returns how many reminders were sent.
+func (svc *Service) SendDueReminders(now time.Time) (int, error) {
+	tasks, err := svc.db.ListDueReminders(now)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve due reminders.", zap.Error(err))
+		return 0, err
+	}
+
+	sent := 0
+	for i := range tasks {
+		task := &tasks[i]
+		if err := svc.emitter.Emit(events.Event{
+			Type:      events.TaskReminderType,
+			Timestamp: now,
+			Task:      task,
+		}); err != nil {
+			svc.logger.Error("Unable to emit task reminder.", zap.String("task", task.Id), zap.Error(err))
+			continue
+		}
+
+		if err := svc.db.MarkTaskReminded(task.Id, now); err != nil {
+			svc.logger.Error("Unable to mark task as reminded.", zap.String("task", task.Id), zap.Error(err))
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
 }
@@ -2,8 +2,15 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/events"
+	taskfilter "github.com/aborgesrodrigues/to-do-api/internal/filter"
+	"github.com/golang/mock/gomock"
 )
 
 func (s *svcTestSuite) TestAddTask() {
@@ -34,15 +41,21 @@ func (s *svcTestSuite) TestAddTask() {
 	for index, test := range tests {
 		s.Run(index, func() {
 			// set up dao mock
+			s.getDB().
+				LastTaskPosition(test.task.UserId).
+				Return("a5", nil)
 			s.getDB().
 				AddTask(test.task).
 				Return(test.dbError)
 
+			s.expectTx()
 			task, err := s.svc.AddTask(test.task)
 			s.Assert().Equal(err, test.expectedResp)
 
 			if test.dbError == nil {
 				s.Assert().NotEmpty(task.Id)
+				s.Assert().Equal("b", task.Position)
+				s.Assert().NotNil(task.CreatedAt)
 			}
 		})
 
@@ -51,43 +64,231 @@ func (s *svcTestSuite) TestAddTask() {
 
 func (s *svcTestSuite) TestUpdateTask() {
 	errAddTask := errors.New("error inserting task")
-	task := &common.Task{
-		UserId:      "00001",
-		Description: "description 1",
-		State:       "to_do",
-	}
+	completedAt := time.Now()
 
 	tests := map[string]struct {
-		task         *common.Task
-		dbError      error
-		expectedResp error
+		current             *common.Task
+		state               common.TaskState
+		version             int
+		force               bool
+		limits              common.WIPLimits
+		count               int
+		dbError1            error
+		dbError2            error
+		expectedErr         error
+		openDependencies    int
+		expectedCompleted   bool
+		expectedCompletedAt *time.Time
 	}{
 		"success": {
-			task:         task,
-			dbError:      nil,
-			expectedResp: nil,
+			current: &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo},
+			state:   common.TaskStateInProgress,
 		},
-		"fail": {
-			task:         task,
-			dbError:      errAddTask,
-			expectedResp: errAddTask,
+		"column with room": {
+			current: &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo},
+			state:   common.TaskStateInProgress,
+			limits:  common.WIPLimits{common.TaskStateInProgress: 3},
+			count:   2,
+		},
+		"full column": {
+			current:     &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo},
+			state:       common.TaskStateInProgress,
+			limits:      common.WIPLimits{common.TaskStateInProgress: 3},
+			count:       3,
+			expectedErr: ErrWIPLimitReached,
+		},
+		"forced into a full column": {
+			current: &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo},
+			state:   common.TaskStateInProgress,
+			force:   true,
+		},
+		"same state": {
+			current: &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateBlocked},
+			state:   common.TaskStateBlocked,
+		},
+		"complete": {
+			current:           &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateInProgress},
+			state:             common.TaskStateDone,
+			expectedCompleted: true,
+		},
+		"blocked": {
+			current:           &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateInProgress},
+			state:             common.TaskStateDone,
+			openDependencies:  1,
+			expectedErr:       ErrTaskBlocked,
+			expectedCompleted: true,
+		},
+		"keep completion": {
+			current:             &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateDone, CompletedAt: &completedAt},
+			state:               common.TaskStateDone,
+			expectedCompleted:   true,
+			expectedCompletedAt: &completedAt,
+		},
+		"reopen": {
+			current: &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateDone, CompletedAt: &completedAt},
+			state:   common.TaskStateToDo,
+		},
+		"invalid state": {
+			current:     &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo},
+			state:       "finished",
+			expectedErr: ErrInvalidState,
+		},
+		"invalid transition": {
+			current:     &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateDone},
+			state:       common.TaskStateBlocked,
+			expectedErr: ErrInvalidTransition,
+		},
+		"not found": {
+			current:     &common.Task{},
+			state:       common.TaskStateToDo,
+			expectedErr: ErrNotFound,
+		},
+		"from current version": {
+			current: &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo, Version: 2},
+			state:   common.TaskStateToDo,
+			version: 2,
+		},
+		"stale version": {
+			current:     &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo, Version: 3},
+			state:       common.TaskStateToDo,
+			version:     2,
+			expectedErr: ErrVersionMismatch,
+		},
+		"version conflict": {
+			current:     &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo, Version: 2},
+			state:       common.TaskStateToDo,
+			version:     2,
+			dbError2:    db.ErrVersionConflict,
+			expectedErr: ErrVersionMismatch,
+		},
+		"fail1": {
+			current:     nil,
+			state:       common.TaskStateToDo,
+			dbError1:    errAddTask,
+			expectedErr: errAddTask,
+		},
+		"fail2": {
+			current:     &common.Task{Id: "0001", UserId: "00001", State: common.TaskStateToDo},
+			state:       common.TaskStateToDo,
+			dbError2:    errAddTask,
+			expectedErr: errAddTask,
 		},
 	}
 
 	for index, test := range tests {
 		s.Run(index, func() {
+			task := &common.Task{
+				Id:          "0001",
+				UserId:      "00001",
+				Description: "description 1",
+				State:       test.state,
+				Version:     test.version,
+			}
+
 			// set up dao mock
 			s.getDB().
-				UpdateTask(test.task).
-				Return(test.dbError)
+				GetTask(task.Id).
+				Return(test.current, test.dbError1)
 
-			_, err := s.svc.UpdateTask(test.task)
-			s.Assert().Equal(err, test.expectedResp)
+			// only a task changing columns is checked against the wip limits
+			changesColumn := test.current != nil && test.current.Id != "" && test.current.State != test.state &&
+				!errors.Is(test.expectedErr, ErrInvalidState) && !errors.Is(test.expectedErr, ErrInvalidTransition)
+			if changesColumn && !test.force {
+				s.getDB().
+					ListWIPLimits("00001").
+					Return(test.limits, nil)
+				if _, ok := test.limits[test.state]; ok {
+					s.getDB().
+						CountStateTasks("00001", test.state).
+						Return(test.count, nil)
+				}
+			}
+
+			if test.expectedCompleted && test.current.State != common.TaskStateDone {
+				s.getDB().
+					CountOpenDependencies(task.Id).
+					Return(test.openDependencies, nil)
+			}
+
+			if test.dbError1 == nil && (test.expectedErr == nil || test.dbError2 != nil) {
+				s.getDB().
+					UpdateTask(task).
+					Return(test.dbError2)
+			}
+
+			if test.expectedErr == nil {
+				s.getDB().
+					AddTaskRevision(gomock.Any()).
+					Return(nil)
+			}
+
+			s.expectTx()
+			_, err := s.svc.UpdateTask(task, "00001", test.force)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedCompleted, task.CompletedAt != nil)
+			}
+			if test.expectedCompletedAt != nil {
+				s.Assert().Equal(test.expectedCompletedAt, task.CompletedAt)
+			}
 		})
 
 	}
 }
 
+func (s *svcTestSuite) TestUpdateTaskRetry() {
+	tests := map[string]struct {
+		dbErrors        []error
+		expectedVersion int
+		expectedErr     error
+	}{
+		"retried conflict": {
+			dbErrors:        []error{db.ErrVersionConflict, nil},
+			expectedVersion: 3,
+		},
+		"concurrent update": {
+			dbErrors:    []error{db.ErrVersionConflict, db.ErrVersionConflict, db.ErrVersionConflict},
+			expectedErr: ErrConcurrentUpdate,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// an update without a version runs again from a fresh read of the task
+			task := &common.Task{Id: "0001", UserId: "00001", Description: "description 2", State: common.TaskStateToDo}
+
+			// set up dao mock
+			for _, dbError := range test.dbErrors {
+				s.expectTx()
+				s.getDB().
+					GetTask("0001").
+					Return(&common.Task{Id: "0001", UserId: "00001", Description: "description 1", State: common.TaskStateToDo, Version: 2}, nil)
+				s.getDB().
+					UpdateTask(gomock.Any()).
+					DoAndReturn(func(task *common.Task) error {
+						s.Assert().Equal(2, task.Version)
+						if dbError != nil {
+							return dbError
+						}
+						task.Version++
+						return nil
+					})
+			}
+			if test.expectedErr == nil {
+				s.getDB().
+					AddTaskRevision(gomock.Any()).
+					Return(nil)
+			}
+
+			resp, err := s.svc.UpdateTask(task, "00001", false)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedVersion, resp.Version)
+			}
+		})
+	}
+}
+
 func (s *svcTestSuite) TestGetTask() {
 	errGetTask := errors.New("any error")
 	user := &common.User{
@@ -95,13 +296,13 @@ func (s *svcTestSuite) TestGetTask() {
 		Name:     "User Name 1",
 	}
 	task := &common.Task{
+		Id:          "0001",
 		UserId:      "00001",
 		Description: "description 1",
 		State:       "to_do",
 	}
 
 	tests := map[string]struct {
-		id           string
 		dbError1     error
 		dbError2     error
 		dbTask       *common.Task
@@ -110,27 +311,22 @@ func (s *svcTestSuite) TestGetTask() {
 		expectedErr  error
 	}{
 		"success": {
-			dbError1:     nil,
-			dbError2:     nil,
 			dbTask:       task,
 			dbUser:       user,
-			expectedResp: task,
-			expectedErr:  nil,
+			expectedResp: &common.Task{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do", User: user, Subtasks: []common.Task{}},
+		},
+		"not found": {
+			dbTask:      &common.Task{},
+			expectedErr: ErrNotFound,
 		},
 		"fail1": {
-			dbError1:     errGetTask,
-			dbError2:     nil,
-			dbTask:       nil,
-			dbUser:       nil,
-			expectedResp: nil,
-			expectedErr:  errGetTask,
+			dbError1:    errGetTask,
+			expectedErr: errGetTask,
 		},
 		"fail2": {
-			dbError1:     nil,
-			dbError2:     errGetTask,
-			dbTask:       task,
-			expectedResp: nil,
-			expectedErr:  errGetTask,
+			dbError2:    errGetTask,
+			dbTask:      task,
+			expectedErr: errGetTask,
 		},
 	}
 
@@ -138,18 +334,34 @@ func (s *svcTestSuite) TestGetTask() {
 		s.Run(index, func() {
 			// set up dao mock
 			s.getDB().
-				GetTask(test.id).
+				GetTask("0001").
 				Return(test.dbTask, test.dbError1)
 
-			if test.dbError1 == nil {
+			found := test.dbError1 == nil && test.dbTask.Id != ""
+			if found {
 				s.getDB().
 					GetUser("00001").
-					Return(&common.User{}, test.dbError2)
+					Return(test.dbUser, test.dbError2)
 			}
 
-			task, err := s.svc.GetTask(test.id)
-			s.Assert().Equal(task, test.expectedResp)
-			s.Assert().Equal(err, test.expectedErr)
+			if found && test.dbError2 == nil {
+				s.getDB().
+					ListSubtasks("0001").
+					Return([]common.Task{}, nil)
+				s.getDB().
+					GetTaskProgress("0001").
+					Return(0, 0, nil)
+				s.getDB().
+					ListTaskLabels([]string{"0001"}).
+					Return(map[string][]string{}, nil)
+				s.getDB().
+					ListChecklistItems([]string{"0001"}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+			}
+
+			resp, err := s.svc.GetTask("0001")
+			s.Assert().Equal(test.expectedResp, resp)
+			s.Assert().ErrorIs(err, test.expectedErr)
 		})
 
 	}
@@ -160,6 +372,7 @@ func (s *svcTestSuite) TestDeleteTask() {
 
 	tests := map[string]struct {
 		id           string
+		cascade      bool
 		dbError      error
 		expectedResp error
 	}{
@@ -168,6 +381,12 @@ func (s *svcTestSuite) TestDeleteTask() {
 			dbError:      nil,
 			expectedResp: nil,
 		},
+		"cascade": {
+			id:           "0001",
+			cascade:      true,
+			dbError:      nil,
+			expectedResp: nil,
+		},
 		"fail": {
 			id:           "0001",
 			dbError:      errAddTask,
@@ -178,11 +397,17 @@ func (s *svcTestSuite) TestDeleteTask() {
 	for index, test := range tests {
 		s.Run(index, func() {
 			// set up dao mock
-			s.getDB().
-				DeleteTask(test.id).
-				Return(test.dbError)
+			if test.cascade {
+				s.getDB().
+					DeleteTaskTree(test.id, gomock.Any()).
+					Return(test.dbError)
+			} else {
+				s.getDB().
+					DeleteTask(test.id, gomock.Any()).
+					Return(test.dbError)
+			}
 
-			err := s.svc.DeleteTask(test.id)
+			err := s.svc.DeleteTask(test.id, test.cascade)
 			s.Assert().Equal(err, test.expectedResp)
 		})
 	}
@@ -190,50 +415,230 @@ func (s *svcTestSuite) TestDeleteTask() {
 
 func (s *svcTestSuite) TestListTasks() {
 	errGetTasks := errors.New("any error")
+	errFilter := taskfilter.Errorf(1, "unknown field %q", "priority")
+	filter := common.TaskFilter{Labels: []string{"home"}}
+	page := common.PageRequest{Limit: 2}
 	tasks := []common.Task{
 		{
+			Id:          "0001",
 			UserId:      "00001",
 			Description: "description 1",
 			State:       "to_do",
 		},
 		{
+			Id:          "0002",
 			UserId:      "00002",
 			Description: "description 2",
 			State:       "to_do",
 		},
 	}
+	labeledTasks := []common.Task{tasks[0], tasks[1]}
+	labeledTasks[0].Labels = []string{"home", "urgent"}
+	labeledTasks[1].Labels = []string{"home"}
+	checklist := []common.ChecklistItem{
+		{Id: "1001", TaskId: "0001", Text: "milk", Checked: true, Position: "a"},
+		{Id: "1002", TaskId: "0001", Text: "bread", Position: "b"},
+	}
+	labeledTasks[0].Checklist = checklist
+	labeledTasks[0].ChecklistProgress = &common.ChecklistProgress{Done: 1, Total: 2}
 
 	tests := map[string]struct {
-		dbError      error
+		dbError1     error
+		dbError2     error
 		dbTasks      []common.Task
-		expectedResp []common.Task
+		expectedResp *common.TaskPage
 		expectedErr  error
 	}{
 		"success": {
-			dbError:      nil,
 			dbTasks:      tasks,
-			expectedResp: tasks,
-			expectedErr:  nil,
+			expectedResp: &common.TaskPage{Items: labeledTasks, NextCursor: "next"},
 		},
-		"fail": {
-			dbError:      errGetTasks,
+		"fail1": {
+			dbError1:     errGetTasks,
 			dbTasks:      nil,
 			expectedResp: nil,
 			expectedErr:  errGetTasks,
 		},
+		"fail2": {
+			dbError2:     errGetTasks,
+			dbTasks:      tasks,
+			expectedResp: nil,
+			expectedErr:  errGetTasks,
+		},
+		"invalid filter": {
+			dbError1:     errFilter,
+			dbTasks:      nil,
+			expectedResp: nil,
+			expectedErr:  fmt.Errorf("%w: %w", ErrInvalidFilter, errFilter),
+		},
+		"invalid cursor": {
+			dbError1:     db.ErrInvalidCursor,
+			dbTasks:      nil,
+			expectedResp: nil,
+			expectedErr:  fmt.Errorf("%w: %w", ErrInvalidPage, db.ErrInvalidCursor),
+		},
 	}
 
 	for index, test := range tests {
 		s.Run(index, func() {
+			dbTasks := slices.Clone(test.dbTasks)
+
 			// set up dao mock
 			s.getDB().
-				ListTasks().
-				Return(test.dbTasks, test.dbError)
+				ListTasks(filter, page).
+				Return(&common.TaskPage{Items: dbTasks, NextCursor: "next"}, test.dbError1)
+
+			if test.dbError1 == nil {
+				s.getDB().
+					ListTaskLabels([]string{"0001", "0002"}).
+					Return(map[string][]string{"0001": {"home", "urgent"}, "0002": {"home"}}, test.dbError2)
+			}
+			if test.dbError1 == nil && test.dbError2 == nil {
+				s.getDB().
+					ListChecklistItems([]string{"0001", "0002"}).
+					Return(map[string][]common.ChecklistItem{"0001": checklist}, nil)
+			}
 
-			tasks, err := s.svc.ListTasks()
+			tasks, err := s.svc.ListTasks(filter, page)
 			s.Assert().Equal(tasks, test.expectedResp)
 			s.Assert().Equal(err, test.expectedErr)
 		})
 
 	}
 }
+
+func (s *svcTestSuite) TestSendDueReminders() {
+	errReminder := errors.New("any error")
+	now := time.Now()
+	tasks := []common.Task{
+		{
+			Id:          "0001",
+			UserId:      "00001",
+			Description: "description 1",
+			State:       "to_do",
+			RemindAt:    &now,
+		},
+		{
+			Id:          "0002",
+			UserId:      "00001",
+			Description: "description 2",
+			State:       "to_do",
+			RemindAt:    &now,
+		},
+	}
+
+	tests := map[string]struct {
+		dbTasks      []common.Task
+		dbError1     error
+		emitError    error
+		dbError2     error
+		expectedSent int
+		expectedErr  error
+	}{
+		"success": {
+			dbTasks:      tasks,
+			expectedSent: 2,
+		},
+		"nothing due": {
+			dbTasks:      []common.Task{},
+			expectedSent: 0,
+		},
+		"fail list": {
+			dbError1:     errReminder,
+			expectedSent: 0,
+			expectedErr:  errReminder,
+		},
+		"fail emit": {
+			dbTasks:      tasks,
+			emitError:    errReminder,
+			expectedSent: 0,
+		},
+		"fail mark": {
+			dbTasks:      tasks,
+			dbError2:     errReminder,
+			expectedSent: 0,
+			expectedErr:  errReminder,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				ListDueReminders(now).
+				Return(test.dbTasks, test.dbError1)
+
+			for i, task := range test.dbTasks {
+				s.getEmitter().
+					Emit(events.Event{Type: events.TaskReminderType, Timestamp: now, Task: &test.dbTasks[i]}).
+					Return(test.emitError)
+
+				if test.emitError != nil {
+					continue
+				}
+
+				s.getDB().
+					MarkTaskReminded(task.Id, now).
+					Return(test.dbError2)
+
+				if test.dbError2 != nil {
+					break
+				}
+			}
+
+			sent, err := s.svc.SendDueReminders(now)
+			s.Assert().Equal(test.expectedSent, sent)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestAddTaskState() {
+	tests := map[string]struct {
+		state             common.TaskState
+		expectedState     common.TaskState
+		expectedErr       error
+		expectedCompleted bool
+	}{
+		"default state": {
+			state:         "",
+			expectedState: common.TaskStateToDo,
+		},
+		"done": {
+			state:             common.TaskStateDone,
+			expectedState:     common.TaskStateDone,
+			expectedCompleted: true,
+		},
+		"invalid state": {
+			state:       "DONE",
+			expectedErr: ErrInvalidState,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			task := &common.Task{
+				UserId:      "00001",
+				Description: "description 1",
+				State:       test.state,
+			}
+
+			if test.expectedErr == nil {
+				s.getDB().
+					LastTaskPosition(task.UserId).
+					Return("", nil)
+				s.getDB().
+					AddTask(task).
+					Return(nil)
+			}
+
+			s.expectTx()
+			_, err := s.svc.AddTask(task)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedState, task.State)
+				s.Assert().Equal(test.expectedCompleted, task.CompletedAt != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/rank"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxTemplateTasks caps the number of tasks of a template.
+const MaxTemplateTasks = 100
+
+// AddTemplate saves a named set of tasks to be instantiated later by the user of the template.
+func (svc *Service) AddTemplate(template *common.Template) (*common.Template, error) {
+	template.Id = uuid.New().String()
+
+	if err := validateTemplate(template); err != nil {
+		svc.logger.Error("Unable add template.", zap.Error(err))
+		return nil, err
+	}
+
+	if err := svc.db.AddTemplate(template); err != nil {
+		svc.logger.Error("Unable add template.", zap.Error(err))
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetTemplate returns a template of actorId. The templates of other users are not found.
+func (svc *Service) GetTemplate(id string, actorId string) (*common.Template, error) {
+	template, err := svc.db.GetTemplate(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve template.", zap.Error(err))
+		return nil, err
+	}
+	if template.Id == "" || template.UserId != actorId {
+		return nil, fmt.Errorf("template %w", ErrNotFound)
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate deletes a template of actorId. The tasks instantiated from it are kept.
+func (svc *Service) DeleteTemplate(id string, actorId string) error {
+	if _, err := svc.GetTemplate(id, actorId); err != nil {
+		return err
+	}
+
+	if err := svc.db.DeleteTemplate(id); err != nil {
+		svc.logger.Error("Unable to delete template.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (svc *Service) ListTemplates(userId string) ([]common.Template, error) {
+	templates, err := svc.db.ListTemplates(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve templates.", zap.Error(err))
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// InstantiateTemplate creates the tasks of a template of actorId, with their labels and checklists,
+// for actorId, in a single transaction. Due times are counted from the start of the instantiation.
+// Templates are private, so they are never instantiated for another user.
+func (svc *Service) InstantiateTemplate(id string, instantiation common.TemplateInstantiation, actorId string) ([]common.Task, error) {
+	template, err := svc.GetTemplate(id, actorId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := svc.db.GetUser(template.UserId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	start := time.Now()
+	if instantiation.Start != nil {
+		start = *instantiation.Start
+	}
+
+	tasks := make([]common.Task, len(template.Tasks))
+	err = svc.db.InTx(func(tx db.DBInterface) error {
+		for i, templateTask := range template.Tasks {
+			task, err := svc.withDB(tx).instantiateTask(templateTask, user.Id, start)
+			if err != nil {
+				return err
+			}
+			tasks[i] = *task
+		}
+
+		return nil
+	})
+	if err != nil {
+		svc.logger.Error("Unable to instantiate template.", zap.Error(err))
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// instantiateTask creates a task of a template for a user, through the same method as a single
+// task, followed by its checklist.
+func (svc *Service) instantiateTask(templateTask common.TemplateTask, userId string, start time.Time) (*common.Task, error) {
+	request := &common.Task{
+		UserId:      userId,
+		Description: templateTask.Description,
+		Labels:      append([]string(nil), templateTask.Labels...),
+	}
+	if templateTask.DueOffset != "" {
+		offset, err := time.ParseDuration(templateTask.DueOffset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid due offset %q", ErrInvalidTemplate, templateTask.DueOffset)
+		}
+		dueAt := start.Add(offset)
+		request.DueAt = &dueAt
+	}
+
+	task, err := svc.addTask(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateTask.Checklist) == 0 {
+		return task, nil
+	}
+
+	// the checklist is new, so its items simply get evenly spaced positions
+	positions := rank.Spread(len(templateTask.Checklist))
+	for i, text := range templateTask.Checklist {
+		item := common.ChecklistItem{
+			Id:       uuid.New().String(),
+			TaskId:   task.Id,
+			Text:     text,
+			Position: positions[i],
+		}
+		if err := svc.db.AddChecklistItem(&item); err != nil {
+			return nil, err
+		}
+		task.Checklist = append(task.Checklist, item)
+	}
+	task.ChecklistProgress = &common.ChecklistProgress{Total: len(task.Checklist)}
+
+	return task, nil
+}
+
+// validateTemplate checks that a template has a name and between one and MaxTemplateTasks tasks,
+// each with a description, a valid due offset and non-empty labels and checklist items. Surrounding
+// spaces are trimmed along the way.
+func validateTemplate(template *common.Template) error {
+	if template.Name = strings.TrimSpace(template.Name); template.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+
+	if len(template.Tasks) == 0 {
+		return fmt.Errorf("%w: no tasks", ErrInvalidTemplate)
+	}
+	if len(template.Tasks) > MaxTemplateTasks {
+		return fmt.Errorf("%w: more than %d tasks", ErrInvalidTemplate, MaxTemplateTasks)
+	}
+
+	for i := range template.Tasks {
+		task := &template.Tasks[i]
+		if task.Description = strings.TrimSpace(task.Description); task.Description == "" {
+			return fmt.Errorf("%w: task %d has no description", ErrInvalidTemplate, i)
+		}
+
+		if task.DueOffset != "" {
+			if _, err := time.ParseDuration(task.DueOffset); err != nil {
+				return fmt.Errorf("%w: task %d has an invalid due offset %q", ErrInvalidTemplate, i, task.DueOffset)
+			}
+		}
+
+		for j, label := range task.Labels {
+			if task.Labels[j] = strings.TrimSpace(label); task.Labels[j] == "" {
+				return fmt.Errorf("%w: task %d has an empty label", ErrInvalidTemplate, i)
+			}
+		}
+
+		for j, text := range task.Checklist {
+			if task.Checklist[j] = strings.TrimSpace(text); task.Checklist[j] == "" {
+				return fmt.Errorf("%w: task %d has an empty checklist item", ErrInvalidTemplate, i)
+			}
+		}
+	}
+
+	return nil
+}
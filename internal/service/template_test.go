@@ -0,0 +1,235 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/aborgesrodrigues/to-do-api/internal/rank"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestAddTemplate() {
+	errAddTemplate := errors.New("error inserting template")
+
+	tests := map[string]struct {
+		template      common.Template
+		dbError       error
+		expectedTasks []common.TemplateTask
+		expectedErr   error
+	}{
+		"success": {
+			template: common.Template{Name: " onboarding ", Tasks: []common.TemplateTask{
+				{Description: " get a laptop ", DueOffset: "24h", Labels: []string{" it "}, Checklist: []string{" charger "}},
+				{Description: "meet the team"},
+			}},
+			expectedTasks: []common.TemplateTask{
+				{Description: "get a laptop", DueOffset: "24h", Labels: []string{"it"}, Checklist: []string{"charger"}},
+				{Description: "meet the team"},
+			},
+		},
+		"no name": {
+			template:    common.Template{Name: " ", Tasks: []common.TemplateTask{{Description: "get a laptop"}}},
+			expectedErr: ErrInvalidTemplate,
+		},
+		"no tasks": {
+			template:    common.Template{Name: "onboarding"},
+			expectedErr: ErrInvalidTemplate,
+		},
+		"too many tasks": {
+			template:    common.Template{Name: "onboarding", Tasks: make([]common.TemplateTask, MaxTemplateTasks+1)},
+			expectedErr: ErrInvalidTemplate,
+		},
+		"no description": {
+			template:    common.Template{Name: "onboarding", Tasks: []common.TemplateTask{{Description: " "}}},
+			expectedErr: ErrInvalidTemplate,
+		},
+		"invalid due offset": {
+			template:    common.Template{Name: "onboarding", Tasks: []common.TemplateTask{{Description: "get a laptop", DueOffset: "2 days"}}},
+			expectedErr: ErrInvalidTemplate,
+		},
+		"empty checklist item": {
+			template:    common.Template{Name: "onboarding", Tasks: []common.TemplateTask{{Description: "get a laptop", Checklist: []string{""}}}},
+			expectedErr: ErrInvalidTemplate,
+		},
+		"fail": {
+			template:      common.Template{Name: "onboarding", Tasks: []common.TemplateTask{{Description: "meet the team"}}},
+			dbError:       errAddTemplate,
+			expectedTasks: []common.TemplateTask{{Description: "meet the team"}},
+			expectedErr:   errAddTemplate,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.expectedTasks != nil {
+				s.getDB().
+					AddTemplate(gomock.Any()).
+					Do(func(template *common.Template) {
+						s.Assert().NotEmpty(template.Id)
+						s.Assert().Equal("00001", template.UserId)
+						s.Assert().Equal("onboarding", template.Name)
+						s.Assert().Equal(test.expectedTasks, template.Tasks)
+					}).
+					Return(test.dbError)
+			}
+
+			test.template.UserId = "00001"
+			_, err := s.svc.AddTemplate(&test.template)
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestDeleteTemplate() {
+	errDeleteTemplate := errors.New("error deleting template")
+
+	tests := map[string]struct {
+		dbTemplate  *common.Template
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			dbTemplate: &common.Template{Id: "0001", UserId: "00001", Name: "onboarding"},
+		},
+		"not found": {
+			dbTemplate:  &common.Template{},
+			expectedErr: ErrNotFound,
+		},
+		"other user": {
+			dbTemplate:  &common.Template{Id: "0001", UserId: "00002", Name: "onboarding"},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbTemplate:  &common.Template{Id: "0001", UserId: "00001", Name: "onboarding"},
+			dbError:     errDeleteTemplate,
+			expectedErr: errDeleteTemplate,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetTemplate("0001").
+				Return(test.dbTemplate, nil)
+			if test.dbTemplate.UserId == "00001" {
+				s.getDB().
+					DeleteTemplate("0001").
+					Return(test.dbError)
+			}
+
+			err := s.svc.DeleteTemplate("0001", "00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestInstantiateTemplate() {
+	errAddItem := errors.New("error inserting checklist item")
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	dueAt := start.Add(48 * time.Hour)
+	user := &common.User{Id: "00001", Username: "user1", Name: "User 1"}
+	template := &common.Template{Id: "0001", UserId: user.Id, Name: "release", Tasks: []common.TemplateTask{
+		{Description: "freeze the branch", DueOffset: "48h", Labels: []string{"release"}, Checklist: []string{"tag", "announce"}},
+	}}
+	spread := rank.Spread(2)
+
+	tests := map[string]struct {
+		dbTemplate  *common.Template
+		dbUser      *common.User
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			dbTemplate: template,
+			dbUser:     user,
+		},
+		"template not found": {
+			dbTemplate:  &common.Template{},
+			expectedErr: ErrNotFound,
+		},
+		"template of other user": {
+			dbTemplate:  &common.Template{Id: "0001", UserId: "00002", Name: "release", Tasks: template.Tasks},
+			expectedErr: ErrNotFound,
+		},
+		"user not found": {
+			dbTemplate:  template,
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbTemplate:  template,
+			dbUser:      user,
+			dbError:     errAddItem,
+			expectedErr: errAddItem,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetTemplate("0001").
+				Return(test.dbTemplate, nil)
+			if test.dbUser != nil {
+				s.getDB().
+					GetUser(user.Id).
+					Return(test.dbUser, nil)
+			}
+			if test.dbUser != nil && test.dbUser.Id != "" {
+				s.getDB().
+					InTx(gomock.Any()).
+					DoAndReturn(func(fn func(tx db.DBInterface) error) error {
+						return fn(s.svc.db)
+					})
+				s.getDB().
+					LastTaskPosition(user.Id).
+					Return("", nil)
+				s.getDB().
+					AddTask(gomock.Any()).
+					Do(func(task *common.Task) {
+						s.Assert().Equal("freeze the branch", task.Description)
+						s.Assert().Equal(user.Id, task.UserId)
+						s.Assert().Equal(dueAt, *task.DueAt)
+					}).
+					Return(nil)
+				s.getDB().
+					ListUserLabels(user.Id).
+					Return([]common.Label{{Id: "1001", UserId: user.Id, Name: "release"}}, nil)
+				s.getDB().
+					SetTaskLabels(gomock.Any(), []string{"1001"}).
+					Return(nil)
+				s.getDB().
+					AddChecklistItem(gomock.Any()).
+					Do(func(item *common.ChecklistItem) {
+						s.Assert().Equal("tag", item.Text)
+						s.Assert().Equal(spread[0], item.Position)
+					}).
+					Return(test.dbError)
+				if test.dbError == nil {
+					s.getDB().
+						AddChecklistItem(gomock.Any()).
+						Do(func(item *common.ChecklistItem) {
+							s.Assert().Equal("announce", item.Text)
+							s.Assert().Equal(spread[1], item.Position)
+						}).
+						Return(nil)
+				}
+			}
+
+			tasks, err := s.svc.InstantiateTemplate("0001", common.TemplateInstantiation{Start: &start}, user.Id)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Len(tasks, 1)
+				s.Assert().Equal([]string{"release"}, tasks[0].Labels)
+				s.Assert().Len(tasks[0].Checklist, 2)
+				s.Assert().Equal(&common.ChecklistProgress{Total: 2}, tasks[0].ChecklistProgress)
+				// the template itself is left untouched
+				s.Assert().Equal([]string{"release"}, template.Tasks[0].Labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// StartTimer starts a timer on a task for a user, who must not have a timer running already.
+func (svc *Service) StartTimer(taskId string, userId string) (*common.TimeEntry, error) {
+	task, err := svc.db.GetTask(taskId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task.", zap.Error(err))
+		return nil, err
+	}
+	if task.Id == "" {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	running, err := svc.db.GetRunningTimeEntry(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve running timer.", zap.Error(err))
+		return nil, err
+	}
+	if running.Id != "" {
+		return nil, fmt.Errorf("%w on task %s", ErrTimerRunning, running.TaskId)
+	}
+
+	entry := &common.TimeEntry{
+		Id:        uuid.New().String(),
+		TaskId:    taskId,
+		UserId:    userId,
+		StartedAt: time.Now(),
+	}
+	if err := svc.db.AddTimeEntry(entry); err != nil {
+		svc.logger.Error("Unable to start timer.", zap.Error(err))
+		if errors.Is(err, db.ErrTimeEntryRunning) {
+			return nil, fmt.Errorf("%w: %w", ErrTimerRunning, err)
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// StopTimer stops the timer a user is running on a task.
+func (svc *Service) StopTimer(taskId string, userId string) (*common.TimeEntry, error) {
+	entry, err := svc.db.GetRunningTimeEntry(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve running timer.", zap.Error(err))
+		return nil, err
+	}
+	if entry.Id == "" || entry.TaskId != taskId {
+		return nil, fmt.Errorf("running timer %w", ErrNotFound)
+	}
+
+	now := time.Now()
+	if err := svc.db.StopTimeEntry(entry.Id, now); err != nil {
+		svc.logger.Error("Unable to stop timer.", zap.Error(err))
+		return nil, err
+	}
+	entry.StoppedAt = &now
+
+	return entry, nil
+}
+
+// Timesheet sums up the time a user logged from from, included, to to, excluded.
+func (svc *Service) Timesheet(userId string, from time.Time, to time.Time) (*common.Timesheet, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: the end must come after the start", ErrInvalidTimesheet)
+	}
+
+	user, err := svc.db.GetUser(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	entries, err := svc.db.ListTimeEntries(userId, from, to)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve time entries.", zap.Error(err))
+		return nil, err
+	}
+
+	timesheet := summarizeTime(entries, from, to, time.Now())
+	timesheet.UserId = userId
+
+	return timesheet, nil
+}
+
+// summarizeTime adds up the time entries within a period, per task and per UTC day. Running
+// entries count up to now.
+func summarizeTime(entries []common.TimeEntry, from time.Time, to time.Time, now time.Time) *common.Timesheet {
+	var total time.Duration
+	tasks := make(map[string]time.Duration)
+	days := make(map[string]time.Duration)
+
+	for _, entry := range entries {
+		start, stop := entry.StartedAt, now
+		if entry.StoppedAt != nil {
+			stop = *entry.StoppedAt
+		}
+		start, stop = later(start, from), earlier(stop, to)
+
+		for start.Before(stop) {
+			// the part of the entry up to the end of its day
+			day := start.UTC().Truncate(24 * time.Hour)
+			end := earlier(stop, day.Add(24*time.Hour))
+
+			days[day.Format(time.DateOnly)] += end.Sub(start)
+			tasks[entry.TaskId] += end.Sub(start)
+			total += end.Sub(start)
+			start = end
+		}
+	}
+
+	timesheet := &common.Timesheet{
+		From:         from,
+		To:           to,
+		TotalSeconds: int64(total.Seconds()),
+		Tasks:        make([]common.TaskTime, 0, len(tasks)),
+		Days:         make([]common.DayTime, 0, len(days)),
+	}
+	for taskId, duration := range tasks {
+		timesheet.Tasks = append(timesheet.Tasks, common.TaskTime{TaskId: taskId, Seconds: int64(duration.Seconds())})
+	}
+	for date, duration := range days {
+		timesheet.Days = append(timesheet.Days, common.DayTime{Date: date, Seconds: int64(duration.Seconds())})
+	}
+
+	// the tasks most worked on first, the days in calendar order
+	slices.SortFunc(timesheet.Tasks, func(a, b common.TaskTime) int {
+		return cmp.Or(cmp.Compare(b.Seconds, a.Seconds), cmp.Compare(a.TaskId, b.TaskId))
+	})
+	slices.SortFunc(timesheet.Days, func(a, b common.DayTime) int {
+		return cmp.Compare(a.Date, b.Date)
+	})
+
+	return timesheet
+}
+
+func later(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
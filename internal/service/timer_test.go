@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *svcTestSuite) TestStartTimer() {
+	errAddTimeEntry := errors.New("error inserting time entry")
+
+	tests := map[string]struct {
+		dbTask      *common.Task
+		dbRunning   *common.TimeEntry
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			dbTask:    &common.Task{Id: "0001"},
+			dbRunning: &common.TimeEntry{},
+		},
+		"already running": {
+			dbTask:      &common.Task{Id: "0001"},
+			dbRunning:   &common.TimeEntry{Id: "000001", TaskId: "0002", UserId: "00001"},
+			expectedErr: ErrTimerRunning,
+		},
+		"task not found": {
+			dbTask:      &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"started concurrently": {
+			dbTask:      &common.Task{Id: "0001"},
+			dbRunning:   &common.TimeEntry{},
+			dbError:     db.ErrTimeEntryRunning,
+			expectedErr: ErrTimerRunning,
+		},
+		"fail": {
+			dbTask:      &common.Task{Id: "0001"},
+			dbRunning:   &common.TimeEntry{},
+			dbError:     errAddTimeEntry,
+			expectedErr: errAddTimeEntry,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetTask("0001").
+				Return(test.dbTask, nil)
+			if test.dbRunning != nil {
+				s.getDB().
+					GetRunningTimeEntry("00001").
+					Return(test.dbRunning, nil)
+			}
+			if test.dbRunning != nil && test.dbRunning.Id == "" {
+				s.getDB().
+					AddTimeEntry(gomock.Any()).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.StartTimer("0001", "00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().NotEmpty(resp.Id)
+				s.Assert().Equal("0001", resp.TaskId)
+				s.Assert().Equal("00001", resp.UserId)
+				s.Assert().Nil(resp.StoppedAt)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestStopTimer() {
+	errStopTimeEntry := errors.New("error stopping time entry")
+	startedAt := time.Now().Add(-time.Hour)
+
+	tests := map[string]struct {
+		dbRunning   *common.TimeEntry
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			dbRunning: &common.TimeEntry{Id: "000001", TaskId: "0001", UserId: "00001", StartedAt: startedAt},
+		},
+		"not running": {
+			dbRunning:   &common.TimeEntry{},
+			expectedErr: ErrNotFound,
+		},
+		"running on another task": {
+			dbRunning:   &common.TimeEntry{Id: "000001", TaskId: "0002", UserId: "00001", StartedAt: startedAt},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			dbRunning:   &common.TimeEntry{Id: "000001", TaskId: "0001", UserId: "00001", StartedAt: startedAt},
+			dbError:     errStopTimeEntry,
+			expectedErr: errStopTimeEntry,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetRunningTimeEntry("00001").
+				Return(test.dbRunning, nil)
+			if test.dbRunning.TaskId == "0001" {
+				s.getDB().
+					StopTimeEntry("000001", gomock.Any()).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.StopTimer("0001", "00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().NotNil(resp.StoppedAt)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestTimesheet() {
+	errListTimeEntries := errors.New("any error")
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	tests := map[string]struct {
+		from        time.Time
+		to          time.Time
+		dbUser      *common.User
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			from:   from,
+			to:     to,
+			dbUser: &common.User{Id: "00001"},
+		},
+		"empty period": {
+			from:        to,
+			to:          from,
+			expectedErr: ErrInvalidTimesheet,
+		},
+		"user not found": {
+			from:        from,
+			to:          to,
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			from:        from,
+			to:          to,
+			dbUser:      &common.User{Id: "00001"},
+			dbError:     errListTimeEntries,
+			expectedErr: errListTimeEntries,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			if test.dbUser != nil {
+				s.getDB().
+					GetUser("00001").
+					Return(test.dbUser, nil)
+			}
+			if test.dbUser != nil && test.dbUser.Id != "" {
+				s.getDB().
+					ListTimeEntries("00001", test.from, test.to).
+					Return([]common.TimeEntry{}, test.dbError)
+			}
+
+			resp, err := s.svc.Timesheet("00001", test.from, test.to)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(&common.Timesheet{UserId: "00001", From: from, To: to, Tasks: []common.TaskTime{}, Days: []common.DayTime{}}, resp)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestSummarizeTime() {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	at := func(day int, hour int, minute int) *time.Time {
+		t := time.Date(2024, 5, day, hour, minute, 0, 0, time.UTC)
+		return &t
+	}
+
+	entries := []common.TimeEntry{
+		// started before the period
+		{TaskId: "0001", StartedAt: time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC), StoppedAt: at(1, 1, 0)},
+		{TaskId: "0002", StartedAt: *at(1, 9, 0), StoppedAt: at(1, 9, 30)},
+		// across midnight
+		{TaskId: "0001", StartedAt: *at(1, 23, 0), StoppedAt: at(2, 0, 30)},
+		// still running, counted up to now
+		{TaskId: "0002", StartedAt: *at(2, 22, 0)},
+	}
+	now := *at(2, 23, 15)
+
+	resp := summarizeTime(entries, from, to, now)
+	s.Assert().Equal(&common.Timesheet{
+		From:         from,
+		To:           to,
+		TotalSeconds: (60 + 30 + 90 + 75) * 60,
+		Tasks: []common.TaskTime{
+			{TaskId: "0001", Seconds: (60 + 90) * 60},
+			{TaskId: "0002", Seconds: (30 + 75) * 60},
+		},
+		Days: []common.DayTime{
+			{Date: "2024-05-01", Seconds: (60 + 30 + 60) * 60},
+			{Date: "2024-05-02", Seconds: (30 + 75) * 60},
+		},
+	}, resp)
+}
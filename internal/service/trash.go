@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"go.uber.org/zap"
+)
+
+// ListTrash returns the deleted tasks of a user, and the user when deleted, that have not been
+// purged yet.
+func (svc *Service) ListTrash(userId string) (*common.Trash, error) {
+	tasks, err := svc.db.ListDeletedTasks(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve deleted tasks.", zap.Error(err))
+		return nil, err
+	}
+
+	users, err := svc.db.ListDeletedUsers(userId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve deleted users.", zap.Error(err))
+		return nil, err
+	}
+
+	return &common.Trash{Tasks: tasks, Users: users}, nil
+}
+
+// RestoreTask takes a task of actorId out of the trash, together with the subtasks deleted along
+// with it. The parent task and the user of the task must not be in the trash themselves.
+func (svc *Service) RestoreTask(id string, actorId string) (*common.Task, error) {
+	task, err := svc.db.GetDeletedTask(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve deleted task.", zap.Error(err))
+		return nil, err
+	}
+	// the trash of another user is not found, as in ListTrash
+	if task.Id == "" || task.UserId != actorId {
+		return nil, fmt.Errorf("task %w", ErrNotFound)
+	}
+
+	if task.ParentId != nil {
+		parent, err := svc.db.GetTask(*task.ParentId)
+		if err != nil {
+			svc.logger.Error("Unable to retrieve parent task.", zap.Error(err))
+			return nil, err
+		}
+		if parent.Id == "" {
+			return nil, fmt.Errorf("%w: the parent task is deleted", ErrInvalidRestore)
+		}
+	}
+
+	user, err := svc.db.GetUser(task.UserId)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve task user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("%w: the user of the task is deleted", ErrInvalidRestore)
+	}
+
+	if err := svc.db.RestoreTask(id, *task.DeletedAt); err != nil {
+		svc.logger.Error("Unable to restore task.", zap.Error(err))
+		return nil, err
+	}
+	task.DeletedAt = nil
+
+	return task, nil
+}
+
+// RestoreUser takes actorId out of the trash, together with the tasks deleted along with the user.
+// Users only restore themselves.
+func (svc *Service) RestoreUser(id string, actorId string) (*common.User, error) {
+	user, err := svc.db.GetDeletedUser(id)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve deleted user.", zap.Error(err))
+		return nil, err
+	}
+	if user.Id == "" || user.Id != actorId {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	if err := svc.db.RestoreUser(id, *user.DeletedAt); err != nil {
+		svc.logger.Error("Unable to restore user.", zap.Error(err))
+		return nil, err
+	}
+	user.DeletedAt = nil
+
+	return user, nil
+}
+
+// PurgeTrash permanently removes the tasks and users deleted before a given time, along with the
+// content of the attachments of the removed tasks, and returns how many tasks and users were removed.
+func (svc *Service) PurgeTrash(before time.Time) (int, error) {
+	attachments, err := svc.db.ListDeletedAttachments(before)
+	if err != nil {
+		svc.logger.Error("Unable to retrieve deleted attachments.", zap.Error(err))
+		return 0, err
+	}
+
+	purged, err := svc.db.PurgeDeleted(before)
+	if err != nil {
+		svc.logger.Error("Unable to purge the trash.", zap.Error(err))
+		return 0, err
+	}
+
+	// the content goes once nothing refers to it anymore
+	for i := range attachments {
+		svc.deleteBlob(attachmentKey(&attachments[i]))
+	}
+
+	return purged, nil
+}
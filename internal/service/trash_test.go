@@ -0,0 +1,241 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aborgesrodrigues/to-do-api/internal/common"
+)
+
+func (s *svcTestSuite) TestListTrash() {
+	errListTrash := errors.New("any error")
+	deletedAt := time.Now()
+	tasks := []common.Task{{Id: "0001", UserId: "00001", Description: "description 1", State: "to_do", DeletedAt: &deletedAt}}
+	users := []common.User{{Id: "00002", Username: "username2", Name: "User Name 2", DeletedAt: &deletedAt}}
+
+	tests := map[string]struct {
+		dbError1     error
+		dbError2     error
+		expectedResp *common.Trash
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: &common.Trash{Tasks: tasks, Users: users},
+		},
+		"fail1": {
+			dbError1:    errListTrash,
+			expectedErr: errListTrash,
+		},
+		"fail2": {
+			dbError2:    errListTrash,
+			expectedErr: errListTrash,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				ListDeletedTasks("00001").
+				Return(tasks, test.dbError1)
+			if test.dbError1 == nil {
+				s.getDB().
+					ListDeletedUsers("00001").
+					Return(users, test.dbError2)
+			}
+
+			resp, err := s.svc.ListTrash("00001")
+			s.Assert().Equal(test.expectedResp, resp)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
+
+func (s *svcTestSuite) TestRestoreTask() {
+	errRestoreTask := errors.New("error restoring task")
+	deletedAt := time.Now()
+	parentId := "0002"
+
+	tests := map[string]struct {
+		task        *common.Task
+		parent      *common.Task
+		user        *common.User
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			task: &common.Task{Id: "0001", UserId: "00001", State: "to_do", DeletedAt: &deletedAt},
+			user: &common.User{Id: "00001"},
+		},
+		"subtask": {
+			task:   &common.Task{Id: "0001", UserId: "00001", State: "to_do", ParentId: &parentId, DeletedAt: &deletedAt},
+			parent: &common.Task{Id: parentId},
+			user:   &common.User{Id: "00001"},
+		},
+		"not in the trash": {
+			task:        &common.Task{},
+			expectedErr: ErrNotFound,
+		},
+		"other user": {
+			task:        &common.Task{Id: "0001", UserId: "00002", State: "to_do", DeletedAt: &deletedAt},
+			expectedErr: ErrNotFound,
+		},
+		"parent deleted": {
+			task:        &common.Task{Id: "0001", UserId: "00001", State: "to_do", ParentId: &parentId, DeletedAt: &deletedAt},
+			parent:      &common.Task{},
+			expectedErr: ErrInvalidRestore,
+		},
+		"user deleted": {
+			task:        &common.Task{Id: "0001", UserId: "00001", State: "to_do", DeletedAt: &deletedAt},
+			user:        &common.User{},
+			expectedErr: ErrInvalidRestore,
+		},
+		"fail": {
+			task:        &common.Task{Id: "0001", UserId: "00001", State: "to_do", DeletedAt: &deletedAt},
+			user:        &common.User{Id: "00001"},
+			dbError:     errRestoreTask,
+			expectedErr: errRestoreTask,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			s.getDB().
+				GetDeletedTask("0001").
+				Return(test.task, nil)
+			if test.parent != nil {
+				s.getDB().
+					GetTask(parentId).
+					Return(test.parent, nil)
+			}
+			if test.user != nil {
+				s.getDB().
+					GetUser("00001").
+					Return(test.user, nil)
+			}
+			if test.user != nil && test.user.Id != "" {
+				s.getDB().
+					RestoreTask("0001", deletedAt).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.RestoreTask("0001", "00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal("0001", resp.Id)
+				s.Assert().Nil(resp.DeletedAt)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestRestoreUser() {
+	errRestoreUser := errors.New("error restoring user")
+	deletedAt := time.Now()
+
+	tests := map[string]struct {
+		id          string
+		user        *common.User
+		dbError     error
+		expectedErr error
+	}{
+		"success": {
+			user: &common.User{Id: "00001", Username: "username1", DeletedAt: &deletedAt},
+		},
+		"not in the trash": {
+			user:        &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"other user": {
+			id:          "00002",
+			user:        &common.User{Id: "00002", Username: "username2", DeletedAt: &deletedAt},
+			expectedErr: ErrNotFound,
+		},
+		"fail": {
+			user:        &common.User{Id: "00001", Username: "username1", DeletedAt: &deletedAt},
+			dbError:     errRestoreUser,
+			expectedErr: errRestoreUser,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao mock
+			id := "00001"
+			if test.id != "" {
+				id = test.id
+			}
+			s.getDB().
+				GetDeletedUser(id).
+				Return(test.user, nil)
+			if test.user.Id == "00001" {
+				s.getDB().
+					RestoreUser("00001", deletedAt).
+					Return(test.dbError)
+			}
+
+			resp, err := s.svc.RestoreUser(id, "00001")
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(&common.User{Id: "00001", Username: "username1"}, resp)
+			}
+		})
+	}
+}
+
+func (s *svcTestSuite) TestPurgeTrash() {
+	errPurge := errors.New("error purging")
+	before := time.Now()
+	attachments := []common.Attachment{{Id: "0001", TaskId: "00001"}, {Id: "0002", TaskId: "00002"}}
+
+	tests := map[string]struct {
+		dbError1     error
+		dbError2     error
+		storeError   error
+		expectedResp int
+		expectedErr  error
+	}{
+		"success": {
+			expectedResp: 3,
+		},
+		"content already gone": {
+			storeError:   errors.New("error deleting content"),
+			expectedResp: 3,
+		},
+		"fail1": {
+			dbError1:    errPurge,
+			expectedErr: errPurge,
+		},
+		"fail2": {
+			dbError2:    errPurge,
+			expectedErr: errPurge,
+		},
+	}
+
+	for index, test := range tests {
+		s.Run(index, func() {
+			// set up dao and store mocks
+			s.getDB().
+				ListDeletedAttachments(before).
+				Return(attachments, test.dbError1)
+			if test.dbError1 == nil {
+				s.getDB().
+					PurgeDeleted(before).
+					Return(test.expectedResp, test.dbError2)
+			}
+			if test.expectedErr == nil {
+				s.getBlobs().
+					Delete("tasks/00001/0001").
+					Return(test.storeError)
+				s.getBlobs().
+					Delete("tasks/00002/0002").
+					Return(test.storeError)
+			}
+
+			purged, err := s.svc.PurgeTrash(before)
+			s.Assert().Equal(test.expectedResp, purged)
+			s.Assert().Equal(test.expectedErr, err)
+		})
+	}
+}
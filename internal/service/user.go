@@ -1,13 +1,18 @@
 package service
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 func (svc *Service) AddUser(user *common.User) (*common.User, error) {
 	user.Id = uuid.New().String()
+	user.Version = 1
 
 	if err := svc.db.AddUser(user); err != nil {
 		svc.logger.Error("Unable add user.", zap.Error(err))
@@ -17,57 +22,100 @@ func (svc *Service) AddUser(user *common.User) (*common.User, error) {
 	return user, nil
 }
 
+// UpdateUser updates a user. A user carrying a version is only updated from that version.
 func (svc *Service) UpdateUser(user *common.User) (*common.User, error) {
-	if err := svc.db.UpdateUser(user); err != nil {
-		svc.logger.Error("Unable add user.", zap.Error(err))
+	request := *user
+	err := retryUpdate(user.Version, func() error {
+		// every attempt starts over from the request
+		*user = request
+		return svc.updateUser(user)
+	})
+	if err != nil {
+		svc.logger.Error("Unable update user.", zap.Error(err))
 		return nil, err
 	}
 
 	return user, nil
 }
 
+// updateUser updates a user from the version it is read at.
+func (svc *Service) updateUser(user *common.User) error {
+	current, err := svc.db.GetUser(user.Id)
+	if err != nil {
+		return err
+	}
+	if current.Id == "" {
+		return fmt.Errorf("user %w", ErrNotFound)
+	}
+
+	if user.Version != 0 && user.Version != current.Version {
+		return fmt.Errorf("%w: the user is at version %d", ErrVersionMismatch, current.Version)
+	}
+	user.Version = current.Version
+
+	return svc.db.UpdateUser(user)
+}
+
 func (svc *Service) GetUser(id string) (*common.User, error) {
 	user, err := svc.db.GetUser(id)
 	if err != nil {
 		svc.logger.Error("Unable to retrieve users.", zap.Error(err))
 		return nil, err
 	}
+	if user.Id == "" {
+		return nil, fmt.Errorf("user %w", ErrNotFound)
+	}
 
 	return user, nil
 }
 
+// DeleteUser moves a user to the trash along with their tasks, in a single transaction. Both share
+// the same deletion time, so restoring the user brings the tasks back too.
 func (svc *Service) DeleteUser(id string) error {
-	// delete user tasks
-	if err := svc.db.DeleteUserTasks(id); err != nil {
-		svc.logger.Error("Unable to delete user tasks.", zap.Error(err))
-		return err
-	}
+	now := time.Now()
 
-	// delete user
-	if err := svc.db.DeleteUser(id); err != nil {
-		svc.logger.Error("Unable to delete users.", zap.Error(err))
-		return err
-	}
+	return svc.db.InTx(func(tx db.DBInterface) error {
+		// delete user tasks
+		if err := tx.DeleteUserTasks(id, now); err != nil {
+			svc.logger.Error("Unable to delete user tasks.", zap.Error(err))
+			return err
+		}
+
+		// delete user
+		if err := tx.DeleteUser(id, now); err != nil {
+			svc.logger.Error("Unable to delete users.", zap.Error(err))
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
 
-func (svc *Service) ListUsers() ([]common.User, error) {
-	users, err := svc.db.ListUsers()
+func (svc *Service) ListUsers(page common.PageRequest) (*common.UserPage, error) {
+	result, err := svc.db.ListUsers(page)
 	if err != nil {
 		svc.logger.Error("Unable to retrieve users.", zap.Error(err))
-		return nil, err
+		return nil, listError(err)
 	}
 
-	return users, nil
+	return result, nil
 }
 
-func (svc *Service) ListUserTasks(id string) ([]common.Task, error) {
-	users, err := svc.db.ListUserTasks(id)
+func (svc *Service) ListUserTasks(id string, filter common.TaskFilter, page common.PageRequest) (*common.TaskPage, error) {
+	result, err := svc.db.ListUserTasks(id, filter, page)
 	if err != nil {
 		svc.logger.Error("Unable to retrieve user tasks.", zap.Error(err))
+		return nil, listError(err)
+	}
+
+	if err := svc.loadLabels(result.Items); err != nil {
+		svc.logger.Error("Unable to retrieve task labels.", zap.Error(err))
+		return nil, err
+	}
+	if err := svc.loadChecklists(result.Items); err != nil {
+		svc.logger.Error("Unable to retrieve task checklists.", zap.Error(err))
 		return nil, err
 	}
 
-	return users, nil
+	return result, nil
 }
@@ -2,8 +2,12 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/aborgesrodrigues/to-do-api/internal/common"
+	"github.com/aborgesrodrigues/to-do-api/internal/db"
+	"github.com/golang/mock/gomock"
 )
 
 func (s *svcTestSuite) TestAddUser() {
@@ -50,37 +54,86 @@ func (s *svcTestSuite) TestAddUser() {
 
 func (s *svcTestSuite) TestUpdateUser() {
 	errAddUser := errors.New("error inserting user")
-	user := &common.User{
-		Username: "username1",
-		Name:     "User Name 1",
-	}
 
 	tests := map[string]struct {
-		user        *common.User
-		dbError     error
-		expectedErr error
+		version         int
+		dbUser          *common.User
+		dbErrors        []error
+		expectedVersion int
+		expectedErr     error
 	}{
 		"success": {
-			user:        user,
-			dbError:     nil,
-			expectedErr: nil,
+			dbUser:          &common.User{Id: "00001", Version: 2},
+			dbErrors:        []error{nil},
+			expectedVersion: 3,
+		},
+		"from current version": {
+			version:         2,
+			dbUser:          &common.User{Id: "00001", Version: 2},
+			dbErrors:        []error{nil},
+			expectedVersion: 3,
+		},
+		"stale version": {
+			version:     1,
+			dbUser:      &common.User{Id: "00001", Version: 2},
+			expectedErr: ErrVersionMismatch,
+		},
+		"not found": {
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
+		"version conflict": {
+			version:     2,
+			dbUser:      &common.User{Id: "00001", Version: 2},
+			dbErrors:    []error{db.ErrVersionConflict},
+			expectedErr: ErrVersionMismatch,
+		},
+		"retried conflict": {
+			dbUser:          &common.User{Id: "00001", Version: 2},
+			dbErrors:        []error{db.ErrVersionConflict, nil},
+			expectedVersion: 3,
+		},
+		"concurrent update": {
+			dbUser:      &common.User{Id: "00001", Version: 2},
+			dbErrors:    []error{db.ErrVersionConflict, db.ErrVersionConflict, db.ErrVersionConflict},
+			expectedErr: ErrConcurrentUpdate,
 		},
 		"fail": {
-			user:        user,
-			dbError:     errAddUser,
+			dbUser:      &common.User{Id: "00001", Version: 2},
+			dbErrors:    []error{errAddUser},
 			expectedErr: errAddUser,
 		},
 	}
 
 	for index, test := range tests {
 		s.Run(index, func() {
-			// set up dao mock
+			user := &common.User{Id: "00001", Username: "username1", Name: "User Name 1", Version: test.version}
+
+			// set up dao mock, every attempt reading the user again
+			attempts := max(len(test.dbErrors), 1)
 			s.getDB().
-				UpdateUser(test.user).
-				Return(test.dbError)
+				GetUser("00001").
+				Return(test.dbUser, nil).
+				Times(attempts)
+			for _, dbError := range test.dbErrors {
+				s.getDB().
+					UpdateUser(gomock.Any()).
+					DoAndReturn(func(user *common.User) error {
+						// the update runs from the version read
+						s.Assert().Equal(test.dbUser.Version, user.Version)
+						if dbError != nil {
+							return dbError
+						}
+						user.Version++
+						return nil
+					})
+			}
 
-			_, err := s.svc.UpdateUser(test.user)
-			s.Assert().Equal(err, test.expectedErr)
+			resp, err := s.svc.UpdateUser(user)
+			s.Assert().ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				s.Assert().Equal(test.expectedVersion, resp.Version)
+			}
 		})
 
 	}
@@ -89,6 +142,7 @@ func (s *svcTestSuite) TestUpdateUser() {
 func (s *svcTestSuite) TestGetUser() {
 	errGetUser := errors.New("any error")
 	user := &common.User{
+		Id:       "0001",
 		Username: "username1",
 		Name:     "User Name 1",
 	}
@@ -106,6 +160,10 @@ func (s *svcTestSuite) TestGetUser() {
 			expectedResp: user,
 			expectedErr:  nil,
 		},
+		"not found": {
+			dbUser:      &common.User{},
+			expectedErr: ErrNotFound,
+		},
 		"fail": {
 			dbError:      errGetUser,
 			dbUser:       nil,
@@ -122,8 +180,8 @@ func (s *svcTestSuite) TestGetUser() {
 				Return(test.dbUser, test.dbError)
 
 			user, err := s.svc.GetUser(test.id)
-			s.Assert().Equal(user, test.expectedResp)
-			s.Assert().Equal(err, test.expectedErr)
+			s.Assert().Equal(test.expectedResp, user)
+			s.Assert().ErrorIs(err, test.expectedErr)
 		})
 
 	}
@@ -160,14 +218,18 @@ func (s *svcTestSuite) TestDeleteUser() {
 
 	for index, test := range tests {
 		s.Run(index, func() {
-			// set up dao mock
+			// set up dao mock, the user and their tasks sharing the same deletion time
+			var deletedAt time.Time
+			s.expectTx()
 			s.getDB().
-				DeleteUserTasks(test.id).
+				DeleteUserTasks(test.id, gomock.Any()).
+				Do(func(id string, at time.Time) { deletedAt = at }).
 				Return(test.dbError1)
 
 			if test.dbError1 == nil {
 				s.getDB().
-					DeleteUser(test.id).
+					DeleteUser(test.id, gomock.Any()).
+					Do(func(id string, at time.Time) { s.Assert().Equal(deletedAt, at) }).
 					Return(test.dbError2)
 			}
 
@@ -190,18 +252,26 @@ func (s *svcTestSuite) TestListUsers() {
 		},
 	}
 
+	page := common.PageRequest{Limit: 2, Cursor: "cursor"}
+
 	tests := map[string]struct {
 		dbError      error
-		dbUsers      []common.User
-		expectedResp []common.User
+		dbUsers      *common.UserPage
+		expectedResp *common.UserPage
 		expectedErr  error
 	}{
 		"success": {
 			dbError:      nil,
-			dbUsers:      users,
-			expectedResp: users,
+			dbUsers:      &common.UserPage{Items: users, NextCursor: "next"},
+			expectedResp: &common.UserPage{Items: users, NextCursor: "next"},
 			expectedErr:  nil,
 		},
+		"invalid cursor": {
+			dbError:      db.ErrInvalidCursor,
+			dbUsers:      nil,
+			expectedResp: nil,
+			expectedErr:  fmt.Errorf("%w: %w", ErrInvalidPage, db.ErrInvalidCursor),
+		},
 		"fail": {
 			dbError:      errGetUsers,
 			dbUsers:      nil,
@@ -214,10 +284,10 @@ func (s *svcTestSuite) TestListUsers() {
 		s.Run(index, func() {
 			// set up dao mock
 			s.getDB().
-				ListUsers().
+				ListUsers(page).
 				Return(test.dbUsers, test.dbError)
 
-			user, err := s.svc.ListUsers()
+			user, err := s.svc.ListUsers(page)
 			s.Assert().Equal(user, test.expectedResp)
 			s.Assert().Equal(err, test.expectedErr)
 		})
@@ -244,13 +314,13 @@ func (s *svcTestSuite) TestListUserTasks() {
 		id           string
 		dbError      error
 		dbTasks      []common.Task
-		expectedResp []common.Task
+		expectedResp *common.TaskPage
 		expectedErr  error
 	}{
 		"success": {
 			dbError:      nil,
 			dbTasks:      tasks,
-			expectedResp: tasks,
+			expectedResp: &common.TaskPage{Items: tasks},
 			expectedErr:  nil,
 		},
 		"fail": {
@@ -265,10 +335,19 @@ func (s *svcTestSuite) TestListUserTasks() {
 		s.Run(index, func() {
 			// set up dao mock
 			s.getDB().
-				ListUserTasks(test.id).
-				Return(test.dbTasks, test.dbError)
+				ListUserTasks(test.id, common.TaskFilter{}, common.PageRequest{}).
+				Return(&common.TaskPage{Items: test.dbTasks}, test.dbError)
+
+			if test.dbError == nil {
+				s.getDB().
+					ListTaskLabels([]string{"", ""}).
+					Return(map[string][]string{}, nil)
+				s.getDB().
+					ListChecklistItems([]string{"", ""}).
+					Return(map[string][]common.ChecklistItem{}, nil)
+			}
 
-			users, err := s.svc.ListUserTasks(test.id)
+			users, err := s.svc.ListUserTasks(test.id, common.TaskFilter{}, common.PageRequest{})
 			s.Assert().Equal(users, test.expectedResp)
 			s.Assert().Equal(err, test.expectedErr)
 		})
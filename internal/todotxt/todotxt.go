@@ -0,0 +1,107 @@
+// Package todotxt parses the lines of task lists in the todo.txt format
+// (https://github.com/todotxt/todo.txt), such as:
+//
+//	(A) 2026-01-10 call mom +family @phone due:2026-01-12
+//	x 2026-01-11 2026-01-10 pay the rent +home
+//
+// A line is, in order: an optional "x" marking the task as done, an optional priority between
+// parentheses, the optional completion and creation dates, and the description, holding
+// +project and @context words and key:value tags.
+package todotxt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLine is returned when a line holds no task description.
+var ErrInvalidLine = errors.New("invalid todo.txt line")
+
+// dateLayout is the layout of the dates of a line.
+const dateLayout = "2006-01-02"
+
+// Task is a line of a todo.txt file.
+type Task struct {
+	Done bool
+	// Priority is the priority of the task, from "A" to "Z", or empty when it has none.
+	Priority    string
+	CompletedAt *time.Time
+	CreatedAt   *time.Time
+	// Description is the description of the task, without its projects, contexts and tags.
+	Description string
+	Projects    []string
+	Contexts    []string
+	// Tags are the key:value words of the description, such as due:2026-01-12.
+	Tags map[string]string
+}
+
+// Parse parses a line of a todo.txt file.
+func Parse(line string) (*Task, error) {
+	words := strings.Fields(line)
+	task := &Task{Tags: make(map[string]string)}
+
+	if len(words) > 0 && words[0] == "x" {
+		task.Done = true
+		words = words[1:]
+	}
+
+	if len(words) > 0 && isPriority(words[0]) {
+		task.Priority = words[0][1:2]
+		words = words[1:]
+	}
+
+	// a done task may carry its completion date before its creation date, which it must then have
+	dates := make([]*time.Time, 0, 2)
+	for len(words) > 0 && len(dates) < 2 {
+		date, err := time.Parse(dateLayout, words[0])
+		if err != nil {
+			break
+		}
+		dates = append(dates, &date)
+		words = words[1:]
+	}
+	switch {
+	case len(dates) == 2 && !task.Done:
+		return nil, fmt.Errorf("%w: only done tasks have a completion date", ErrInvalidLine)
+	case len(dates) == 2:
+		task.CompletedAt, task.CreatedAt = dates[0], dates[1]
+	case len(dates) == 1:
+		task.CreatedAt = dates[0]
+	}
+
+	description := make([]string, 0, len(words))
+	for _, word := range words {
+		switch {
+		case len(word) > 1 && word[0] == '+':
+			task.Projects = append(task.Projects, word[1:])
+		case len(word) > 1 && word[0] == '@':
+			task.Contexts = append(task.Contexts, word[1:])
+		case isTag(word):
+			key, value, _ := strings.Cut(word, ":")
+			task.Tags[key] = value
+		default:
+			description = append(description, word)
+		}
+	}
+
+	task.Description = strings.Join(description, " ")
+	if task.Description == "" {
+		return nil, fmt.Errorf("%w: no description", ErrInvalidLine)
+	}
+
+	return task, nil
+}
+
+// isPriority tells whether word is a priority, an upper case letter between parentheses.
+func isPriority(word string) bool {
+	return len(word) == 3 && word[0] == '(' && word[1] >= 'A' && word[1] <= 'Z' && word[2] == ')'
+}
+
+// isTag tells whether word is a key:value tag: both parts are set and neither holds a colon. A
+// value starting with // belongs to a URL, such as http://example.com, and not to a tag.
+func isTag(word string) bool {
+	key, value, found := strings.Cut(word, ":")
+	return found && key != "" && value != "" && !strings.Contains(value, ":") && !strings.HasPrefix(value, "//")
+}
@@ -0,0 +1,79 @@
+package todotxt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		line     string
+		expected *Task
+	}{
+		"description only": {
+			line:     "call mom",
+			expected: &Task{Description: "call mom", Tags: map[string]string{}},
+		},
+		"priority and creation date": {
+			line:     "(A) 2026-01-10 call mom",
+			expected: &Task{Priority: "A", CreatedAt: date(2026, time.January, 10), Description: "call mom", Tags: map[string]string{}},
+		},
+		"projects, contexts and tags": {
+			line: "call +family mom @phone due:2026-01-12 +home",
+			expected: &Task{
+				Description: "call mom",
+				Projects:    []string{"family", "home"},
+				Contexts:    []string{"phone"},
+				Tags:        map[string]string{"due": "2026-01-12"},
+			},
+		},
+		"done": {
+			line:     "x 2026-01-11 2026-01-10 pay the rent",
+			expected: &Task{Done: true, CompletedAt: date(2026, time.January, 11), CreatedAt: date(2026, time.January, 10), Description: "pay the rent", Tags: map[string]string{}},
+		},
+		"done with a creation date only": {
+			line:     "x 2026-01-10 pay the rent",
+			expected: &Task{Done: true, CreatedAt: date(2026, time.January, 10), Description: "pay the rent", Tags: map[string]string{}},
+		},
+		"lookalikes": {
+			// a lower case x, a priority not at the start, a lone sign and a URL stay in the description
+			line:     "xylophone (B) lessons + @ https://music.example:8080",
+			expected: &Task{Description: "xylophone (B) lessons + @ https://music.example:8080", Tags: map[string]string{}},
+		},
+		"url": {
+			line:     "see http://example.com due:2026-01-12",
+			expected: &Task{Description: "see http://example.com", Tags: map[string]string{"due": "2026-01-12"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			task, err := Parse(test.line)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, task)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":                       "",
+		"no description":              "(A) 2026-01-10 +family @phone",
+		"open with a completion date": "2026-01-11 2026-01-10 pay the rent",
+	}
+
+	for name, line := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(line)
+			assert.ErrorIs(t, err, ErrInvalidLine)
+		})
+	}
+}